@@ -0,0 +1,97 @@
+package binary
+
+import (
+	"fmt"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+// Adapter gives a format-struct the methods of encoding.BinaryMarshaler
+// and encoding.BinaryUnmarshaler, for libraries that expect them, e.g.
+//
+//	var header binary.Adapter[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+//
+//	e = header.UnmarshalBinary(bytes)
+//
+// Adapters may also be nested as words in other formats,
+// to which Marshal and Unmarshal delegate.
+type Adapter[T any] struct {
+	Value T
+}
+
+func (a *Adapter[T]) MarshalBinary() (bytes []byte, e error) {
+	const (
+		functionName = "MarshalBinary"
+	)
+
+	defer func() {
+		const (
+			marshalBinaryError = "MarshalBinary error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(marshalBinaryError, e)
+		}
+
+		return
+	}()
+
+	if a == nil {
+		e = validation.NewNilPointerError()
+
+		return
+	}
+
+	bytes, e = marshal(&a.Value)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (a *Adapter[T]) AppendBinary(b []byte) (bytes []byte, e error) {
+	bytes, e = a.MarshalBinary()
+	if e != nil {
+		return
+	}
+
+	bytes = append(b, bytes...)
+
+	return
+}
+
+func (a *Adapter[T]) UnmarshalBinary(bytes []byte) (e error) {
+	const (
+		functionName = "UnmarshalBinary"
+	)
+
+	defer func() {
+		const (
+			unmarshalBinaryError = "UnmarshalBinary error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(unmarshalBinaryError, e)
+		}
+
+		return
+	}()
+
+	if a == nil {
+		e = validation.NewNilPointerError()
+
+		return
+	}
+
+	e = unmarshal(bytes, &a.Value)
+	if e != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,197 @@
+package binary
+
+import (
+	"encoding"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAddress [4]byte
+
+func (a testAddress) MarshalBinary() ([]byte, error) {
+	return a[:], nil
+}
+
+func (a *testAddress) UnmarshalBinary(bytes []byte) error {
+	copy(a[:], bytes)
+
+	return nil
+}
+
+type testPriority string
+
+func (p testPriority) MarshalBinary() (bytes []byte, e error) {
+	switch p {
+	case "low":
+		bytes = []byte{1}
+
+	case "high":
+		bytes = []byte{2}
+
+	case "overflowing":
+		bytes = []byte{16}
+
+	default:
+		e = errors.New("unknown priority")
+	}
+
+	return
+}
+
+func (p *testPriority) UnmarshalBinary(bytes []byte) error {
+	switch bytes[0] {
+	case 1:
+		*p = "low"
+
+	case 2:
+		*p = "high"
+
+	default:
+		return errors.New("unknown priority")
+	}
+
+	return nil
+}
+
+type testDatagram struct {
+	Source      testAddress                 `word:"32"`
+	Header      Adapter[testDatagramHeader] `word:"16"`
+	Destination testAddress                 `word:"32,littleendian"`
+}
+
+type testDatagramHeader struct {
+	TestDatagramHeaderWord `word:"16"`
+}
+
+type TestDatagramHeaderWord struct {
+	Version  uint8        `bitfield:"4"`
+	Priority testPriority `bitfield:"4"`
+	Length   uint8        `bitfield:"8"`
+}
+
+var (
+	testDatagramStruct = testDatagram{
+		Source: testAddress{192, 0, 2, 1},
+		Header: Adapter[testDatagramHeader]{
+			Value: testDatagramHeader{
+				TestDatagramHeaderWord{
+					Version:  4,
+					Priority: "high",
+					Length:   42,
+				},
+			},
+		},
+		Destination: testAddress{198, 51, 100, 1},
+	}
+
+	testDatagramBytes = []byte{
+		192, 0, 2, 1,
+		0x42, 42,
+		1, 100, 51, 198,
+	}
+)
+
+func TestAdapter(t *testing.T) {
+	var (
+		adapter Adapter[testDatagram]
+		bytes   []byte
+		e       error
+
+		_ encoding.BinaryMarshaler   = &adapter
+		_ encoding.BinaryUnmarshaler = &adapter
+	)
+
+	e = adapter.UnmarshalBinary(testDatagramBytes)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		testDatagramStruct, adapter.Value,
+	)
+
+	bytes, e = adapter.MarshalBinary()
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		testDatagramBytes, bytes,
+	)
+
+	bytes, e = adapter.AppendBinary([]byte{0xff})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		append([]byte{0xff}, testDatagramBytes...), bytes,
+	)
+
+	e = adapter.UnmarshalBinary(testDatagramBytes[:4])
+
+	assert.NotNil(t, e)
+}
+
+func TestMarshalShouldDelegateToBinaryMarshalers(t *testing.T) {
+	var (
+		bytes    []byte
+		datagram testDatagram
+		e        error
+	)
+
+	bytes, e = Marshal(&testDatagramStruct)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		testDatagramBytes, bytes,
+	)
+
+	e = Unmarshal(bytes, &datagram)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		testDatagramStruct, datagram,
+	)
+}
+
+func TestMarshalShouldReturnErrorGivenFailingBinaryMarshaler(t *testing.T) {
+	var (
+		datagram testDatagram
+		e        error
+	)
+
+	datagram = testDatagramStruct
+
+	datagram.Header.Value.Priority = "overflowing"
+
+	_, e = Marshal(&datagram)
+
+	assert.NotNil(t, e)
+
+	assert.Contains(t,
+		e.Error(), "that has a bit field \"Priority\" "+
+			"that failed: marshalled into a value 16 overflowing 4 bit(s).",
+	)
+
+	datagram.Header.Value.Priority = "unknown"
+
+	_, e = Marshal(&datagram)
+
+	assert.Contains(t,
+		e.Error(), "Argument to Marshal points to a format-struct "+
+			"\"binary.testDatagram\" nesting a word-struct \"Header\" "+
+			"that failed: MarshalBinary error: ",
+	)
+
+	e = Unmarshal(
+		[]byte{192, 0, 2, 1, 0x4f, 42, 1, 100, 51, 198},
+		&datagram,
+	)
+
+	assert.Contains(t,
+		e.Error(), "that has a bit field \"Priority\" "+
+			"that failed: unknown priority.",
+	)
+}
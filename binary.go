@@ -4,6 +4,7 @@ import (
 	stdlib "encoding/binary"
 	"fmt"
 	"io"
+	"reflect"
 
 	"github.com/encodingx/binary/internal/codecs"
 	"github.com/encodingx/binary/internal/validation"
@@ -18,10 +19,6 @@ func Marshal(iface interface{}) (bytes []byte, e error) {
 		functionName = "Marshal"
 	)
 
-	var (
-		operation codecs.CodecOperation
-	)
-
 	defer func() {
 		const (
 			marshalError = "Marshal error: %w"
@@ -36,6 +33,30 @@ func Marshal(iface interface{}) (bytes []byte, e error) {
 		return
 	}()
 
+	bytes, e = marshal(iface)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func marshal(iface interface{}) (bytes []byte, e error) {
+	var (
+		generated GeneratedFormat
+		ok        bool
+		operation codecs.CodecOperation
+	)
+
+	generated, ok = generatedFormat(iface)
+	if ok {
+		bytes, e = marshalGenerated(generated,
+			reflect.TypeOf(iface).Elem().String(),
+		)
+
+		return
+	}
+
 	operation, e = defaultCodec.NewOperation(iface)
 	if e != nil {
 		return
@@ -54,10 +75,6 @@ func Unmarshal(bytes []byte, iface interface{}) (e error) {
 		functionName = "Unmarshal"
 	)
 
-	var (
-		operation codecs.CodecOperation
-	)
-
 	defer func() {
 		const (
 			unmarshalError = "Unmarshal error: %w"
@@ -72,6 +89,30 @@ func Unmarshal(bytes []byte, iface interface{}) (e error) {
 		return
 	}()
 
+	e = unmarshal(bytes, iface)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func unmarshal(bytes []byte, iface interface{}) (e error) {
+	var (
+		generated GeneratedFormat
+		ok        bool
+		operation codecs.CodecOperation
+	)
+
+	generated, ok = generatedFormat(iface)
+	if ok {
+		e = unmarshalGenerated(generated,
+			reflect.TypeOf(iface).Elem().String(), bytes,
+		)
+
+		return
+	}
+
 	operation, e = defaultCodec.NewOperation(iface)
 	if e != nil {
 		return
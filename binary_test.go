@@ -2,9 +2,13 @@ package binary
 
 import (
 	"fmt"
+	"net"
+	"net/netip"
 	"testing"
+	"time"
 
 	"github.com/encodingx/binary/pkg/rfc791"
+	rfc791netip "github.com/encodingx/binary/pkg/rfc791/netip"
 	"github.com/encodingx/binary/pkg/rfc791/v1p1"
 	"github.com/stretchr/testify/assert"
 )
@@ -41,62 +45,1736 @@ func TestMarshalV1p1(t *testing.T) {
 	)
 }
 
+func TestMarshalNetip(t *testing.T) {
+	// Test byte-compatibility of addresses with octets
+
+	var (
+		bytes []byte
+		e     error
+	)
+
+	bytes, e = Marshal(&internetHeaderStructNetip)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		internetHeaderBytes, bytes,
+	)
+}
+
 func BenchmarkMarshal(b *testing.B) {
 	var (
-		e error
-		i int
+		e error
+		i int
+	)
+
+	for i = 0; i < b.N; i++ {
+		_, e = Marshal(&internetHeaderStruct)
+		if e != nil {
+			b.Error(e)
+		}
+	}
+}
+
+func BenchmarkMarshalV1p1(b *testing.B) {
+	// Format-structs of v1.1 have no generated methods,
+	// so that this measures the reflection path.
+
+	var (
+		e error
+		i int
+	)
+
+	for i = 0; i < b.N; i++ {
+		_, e = Marshal(&internetHeaderStructV1p1)
+		if e != nil {
+			b.Error(e)
+		}
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	var (
+		e error
+	)
+
+	e = Unmarshal(internetHeaderBytes, &internetHeaderStruct1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		internetHeaderStruct, internetHeaderStruct1,
+	)
+}
+
+func TestUnmarshalV1p1(t *testing.T) {
+	// Test backwards-compatibility with v1.1
+
+	var (
+		e error
+	)
+
+	e = Unmarshal(internetHeaderBytes, &internetHeaderStruct1V1p1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		internetHeaderStructV1p1, internetHeaderStruct1V1p1,
+	)
+}
+
+func TestUnmarshalNetip(t *testing.T) {
+	var (
+		e      error
+		header rfc791netip.RFC791InternetHeaderFormatWithoutOptions
+	)
+
+	e = Unmarshal(internetHeaderBytes, &header)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		internetHeaderStructNetip, header,
+	)
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	var (
+		e error
+		i int
+	)
+
+	for i = 0; i < b.N; i++ {
+		e = Unmarshal(internetHeaderBytes, &internetHeaderStruct1)
+		if e != nil {
+			b.Error(e)
+		}
+	}
+}
+
+func BenchmarkUnmarshalV1p1(b *testing.B) {
+	var (
+		e error
+		i int
+	)
+
+	for i = 0; i < b.N; i++ {
+		e = Unmarshal(internetHeaderBytes, &internetHeaderStruct1V1p1)
+		if e != nil {
+			b.Error(e)
+		}
+	}
+}
+
+func TestMarshalAndUnmarshalLittleEndianWord(t *testing.T) {
+	type (
+		Word0 struct {
+			Flags uint8 `bitfield:"8"`
+		}
+
+		Word1 struct {
+			High uint16 `bitfield:"16"`
+			Low  uint16 `bitfield:"16"`
+		}
+
+		Format struct {
+			Word0 `word:"8,bigendian"`
+			Word1 `word:"32,littleendian"`
+		}
+	)
+
+	var (
+		bytes  []byte
+		e      error
+		format = Format{
+			Word0{0xaa},
+			Word1{0x0102, 0x0304},
+		}
+		format1 Format
+	)
+
+	bytes, e = Marshal(&format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0xaa, 0x04, 0x03, 0x02, 0x01}, bytes,
+	)
+
+	e = Unmarshal(bytes, &format1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		format, format1,
+	)
+}
+
+func TestMarshalAndUnmarshalLSBFirstWord(t *testing.T) {
+	// Mirror a C struct with bit fields on a little-endian target, e.g.
+	// struct { uint16_t enable : 1; uint16_t mode : 3; uint16_t count : 12; }
+
+	type (
+		Register struct {
+			Enable bool   `bitfield:"1"`
+			Mode   uint8  `bitfield:"3"`
+			Count  uint16 `bitfield:"12"`
+		}
+
+		Format struct {
+			Register `word:"16,littleendian,lsbfirst"`
+		}
+	)
+
+	var (
+		bytes  []byte
+		e      error
+		format = Format{
+			Register{true, 5, 0x123},
+		}
+		format1 Format
+	)
+
+	bytes, e = Marshal(&format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0x3b, 0x12}, bytes,
+	)
+
+	e = Unmarshal(bytes, &format1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		format, format1,
+	)
+}
+
+func TestMarshalAndUnmarshalNetworkTypes(t *testing.T) {
+	// IPv6 addresses fill two words of 64 bits,
+	// reversed as a whole if little-endian.
+
+	type (
+		Link struct {
+			Hardware net.HardwareAddr `bitfield:"48"`
+			Port     uint16           `bitfield:"16"`
+		}
+
+		Format struct {
+			Link     `word:"64"`
+			Service  netip.AddrPort `word:"48"`
+			Station  [3]byte        `word:"24"`
+			Address  netip.Addr     `word:"128"`
+			Reversed netip.Addr     `word:"128,littleendian"`
+		}
+	)
+
+	var (
+		bytes  []byte
+		e      error
+		format = Format{
+			Link: Link{
+				Hardware: net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x53, 0x01},
+				Port:     443,
+			},
+			Service:  netip.MustParseAddrPort("192.0.2.1:53"),
+			Station:  [3]byte{0xab, 0xcd, 0xef},
+			Address:  netip.MustParseAddr("2001:db8::1"),
+			Reversed: netip.MustParseAddr("2001:db8::2"),
+		}
+		format1 Format
+	)
+
+	bytes, e = Marshal(&format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{
+			0x00, 0x00, 0x5e, 0x00, 0x53, 0x01, 0x01, 0xbb,
+			192, 0, 2, 1, 0x00, 0x35,
+			0xab, 0xcd, 0xef,
+			0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x01,
+			0x02, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xb8, 0x0d, 0x01, 0x20,
+		},
+		bytes,
+	)
+
+	e = Unmarshal(bytes, &format1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		format, format1,
+	)
+}
+
+func TestShouldReturnErrorGivenIPv6AddressInIPv4Word(t *testing.T) {
+	const (
+		errorMessage = "Marshal error: " +
+			"A bit field of a network type should hold a value " +
+			"that fits its length (e.g. an IPv4 address in 32 bits). " +
+			"Argument to Marshal points to a format-struct " +
+			"\"netip.RFC791InternetHeaderFormatWithoutOptions\" " +
+			"nesting a word-struct \"Source\" " +
+			"that has a bit field \"Source\" " +
+			"that does not: address 2001:db8::1 not of IPv4."
+	)
+
+	var (
+		e      error
+		header = internetHeaderStructNetip
+	)
+
+	header.Source = netip.MustParseAddr("2001:db8::1")
+
+	_, e = Marshal(&header)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestMarshalAndUnmarshalTimes(t *testing.T) {
+	// A DOS date and time is little-endian as in a ZIP file,
+	// and a PTP timestamp fills two words, of seconds and nanoseconds.
+
+	type (
+		Header struct {
+			Modified time.Time `bitfield:"32,dos"`
+		}
+
+		Format struct {
+			Created  time.Time `word:"32"`
+			Logged   time.Time `word:"64,unix,resolution=1ms"`
+			Sent     time.Time `word:"64,ntp"`
+			Captured time.Time `word:"80,ptp"`
+			Fixed    time.Time `word:"32,gps"`
+			Header   `word:"32,littleendian"`
+			Timeout  time.Duration `word:"16,resolution=1ms"`
+			Delay    time.Duration `word:"32,ntp"`
+		}
+	)
+
+	var (
+		bytes  []byte
+		e      error
+		stamp  = time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+		format = Format{
+			Created:  stamp,
+			Logged:   stamp.Add(678 * time.Millisecond),
+			Sent:     stamp.Add(500 * time.Millisecond),
+			Captured: stamp.Add(123456789 * time.Nanosecond),
+			Fixed:    stamp,
+			Header: Header{
+				Modified: stamp.Add(time.Second),
+			},
+			Timeout: 1500 * time.Millisecond,
+			Delay:   1250 * time.Millisecond,
+		}
+		format1 Format
+	)
+
+	bytes, e = Marshal(&format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{
+			0x65, 0x93, 0x7d, 0x25,
+			0x00, 0x00, 0x01, 0x8c, 0xc8, 0x20, 0xdb, 0x2e,
+			0xe9, 0x3d, 0xfb, 0xa5, 0x80, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x65, 0x93, 0x7d, 0x25, 0x07, 0x5b, 0xcd, 0x15,
+			0x8f, 0x72, 0xce, 0x25,
+			0x83, 0x18, 0x22, 0x58,
+			0x05, 0xdc,
+			0x00, 0x01, 0x40, 0x00,
+		},
+		bytes,
+	)
+
+	e = Unmarshal(bytes, &format1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		format, format1,
+	)
+}
+
+func TestMarshalAndUnmarshalZeroTime(t *testing.T) {
+	type (
+		Format struct {
+			Created  time.Time `word:"32"`
+			Modified time.Time `word:"32,dos"`
+		}
+	)
+
+	var (
+		bytes   []byte
+		e       error
+		format1 Format
+	)
+
+	bytes, e = Marshal(&Format{})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		make([]byte, 8), bytes,
+	)
+
+	e = Unmarshal(bytes, &format1)
+
+	assert.Nil(t, e)
+
+	assert.True(t,
+		format1.Modified.IsZero(),
+	)
+}
+
+func TestShouldReturnErrorGivenTimeBeforeEpoch(t *testing.T) {
+	const (
+		errorMessage = "Marshal error: " +
+			"A bit field holding a time or duration should be given one " +
+			"that its encoding can represent. " +
+			"Argument to Marshal points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Created\" " +
+			"that has a bit field \"Created\" " +
+			"given one out of range: " +
+			"time 1969-12-31T23:59:59Z before epoch 1970-01-01T00:00:00Z."
+	)
+
+	type (
+		Format struct {
+			Created time.Time `word:"32"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Marshal(
+		&Format{
+			Created: time.Date(1969, time.December, 31, 23, 59, 59, 0, time.UTC),
+		},
+	)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestUnmarshalShouldReturnErrorGivenDurationOverflowing(t *testing.T) {
+	const (
+		errorMessage = "Unmarshal error: " +
+			"A bit field holding a time or duration should hold one " +
+			"that its type can represent. " +
+			"Argument to Unmarshal points to a format-struct " +
+			"\"binary.Format\" " +
+			"nesting a word-struct \"Uptime\" " +
+			"that has a bit field \"Uptime\" " +
+			"holding one out of range: " +
+			"1099511627776 overflowing a duration encoded as count."
+	)
+
+	type (
+		Format struct {
+			Uptime time.Duration `word:"64"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	e = Unmarshal(
+		[]byte{0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+		new(Format),
+	)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestUnmarshalShouldReturnErrorGivenTimeBeyondYear9999(t *testing.T) {
+	type (
+		Format struct {
+			Logged time.Time `word:"64,unix,resolution=1ms"`
+		}
+
+		Timestamp struct {
+			Captured time.Time `word:"80,ptp"`
+		}
+	)
+
+	var (
+		bytes = []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+		e     error
+	)
+
+	e = Unmarshal(bytes,
+		new(Format),
+	)
+
+	assert.Contains(t, e.Error(),
+		"holding one out of range: "+
+			"18446744073709551615 beyond year 9999 encoded as unix.",
+	)
+
+	_, e = Marshal(
+		&Format{
+			Logged: time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	)
+
+	assert.Contains(t, e.Error(),
+		"given one out of range: "+
+			"time 10000-01-01T00:00:00Z beyond year 9999.",
+	)
+
+	e = Unmarshal(
+		[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00},
+		new(Timestamp),
+	)
+
+	assert.Contains(t, e.Error(),
+		"holding one out of range: "+
+			"281474976710655 beyond year 9999 encoded as ptp.",
+	)
+
+	e = Unmarshal(
+		[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x3b, 0x9a, 0xca, 0x00},
+		new(Timestamp),
+	)
+
+	assert.Contains(t, e.Error(),
+		"holding one out of range: "+
+			"1000000000 nanoseconds invalid in PTP timestamp.",
+	)
+}
+
+func TestUnmarshalShouldReturnErrorGivenInvalidDOSDateOrTime(t *testing.T) {
+	// Fields of dates and times are 7 bits of years since 1980,
+	// then 4 of month, 5 of day, 5 of hour, 6 of minute
+	// and 5 of seconds halved.
+
+	type (
+		Format struct {
+			Modified time.Time `word:"32,dos"`
+		}
+	)
+
+	var (
+		cause string
+		e     error
+		value uint32
+
+		cases = map[uint32]string{
+			15<<21 | 1<<16:          "month 15 invalid in DOS date",
+			2<<21 | 30<<16:          "day 30 invalid in DOS date of month 2",
+			1<<21 | 1<<16 | 24<<11:  "hour 24 invalid in DOS time",
+			1<<21 | 1<<16 | 60<<5:   "minute 60 invalid in DOS time",
+			1<<21 | 1<<16 | 30:      "second 60 invalid in DOS time",
+			1 << 16:                 "month 0 invalid in DOS date",
+			44<<25 | 2<<21 | 29<<16: "",
+		}
+	)
+
+	for value, cause = range cases {
+		e = Unmarshal(
+			[]byte{
+				byte(value >> 24), byte(value >> 16),
+				byte(value >> 8), byte(value),
+			},
+			new(Format),
+		)
+
+		if cause == "" {
+			assert.Nil(t, e)
+
+			continue
+		}
+
+		assert.Contains(t, e.Error(),
+			"holding one out of range: "+cause+".",
+		)
+	}
+}
+
+func TestShouldReturnErrorGivenDurationOverflowingBitField(t *testing.T) {
+	const (
+		errorMessage = "Marshal error: " +
+			"A bit field holding a time or duration should be given one " +
+			"that its encoding can represent. " +
+			"Argument to Marshal points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Timeout\" " +
+			"that has a bit field \"Timeout\" " +
+			"given one out of range: " +
+			"duration 1m5.536s overflowing 16 bit(s) encoded as count."
+	)
+
+	type (
+		Format struct {
+			Timeout time.Duration `word:"16,resolution=1ms"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Marshal(
+		&Format{
+			Timeout: 65536 * time.Millisecond,
+		},
+	)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenBitFieldOfLengthUnfitForTimeEncoding(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field holding a time or duration should be as long " +
+			"as its encoding requires. " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"encoded as ntp of length 48 not in {32, 64}."
+	)
+
+	type (
+		Word struct {
+			BitField time.Time `bitfield:"48,ntp"`
+			Reserved uint16    `bitfield:"16"`
+		}
+
+		Format struct {
+			Word `word:"64"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenTimeWithContradictoryEncodings(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field is represented " +
+			"by an exported field of a word-struct " +
+			"tagged with a key \"bitfield\" and a value " +
+			"indicating the length of the bit field in number of bits " +
+			"(e.g. `bitfield:\"1\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Word struct {
+			BitField time.Time `bitfield:"32,unix,ntp"`
+		}
+
+		Format struct {
+			Word `word:"32"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestMarshalAndUnmarshalCodedNumbers(t *testing.T) {
+	// Seconds, minutes and hours of a real-time clock in BCD,
+	// and the position of a rotary encoder in Gray code.
+
+	type (
+		Clock struct {
+			Seconds  uint8  `bitfield:"8,bcd"`
+			Minutes  uint8  `bitfield:"8,bcd"`
+			Hours    uint8  `bitfield:"8,bcd"`
+			Position uint16 `bitfield:"8,gray"`
+		}
+
+		Format struct {
+			Clock `word:"32"`
+		}
+	)
+
+	var (
+		bytes   []byte
+		e       error
+		format  Format
+		format1 Format
+	)
+
+	format.Seconds = 59
+	format.Minutes = 7
+	format.Hours = 23
+	format.Position = 200
+
+	bytes, e = Marshal(&format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0x59, 0x07, 0x23, 0xac}, bytes,
+	)
+
+	e = Unmarshal(bytes, &format1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		format, format1,
+	)
+}
+
+func TestShouldReturnErrorGivenNumberOverflowingBCD(t *testing.T) {
+	const (
+		errorMessage = "Marshal error: " +
+			"A bit field encoded as binary-coded decimal should be given " +
+			"a number of no more decimal digits than it holds. " +
+			"Argument to Marshal points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"given one out of range: 100 overflowing 2 decimal digit(s)."
+	)
+
+	type (
+		Word struct {
+			BitField uint8 `bitfield:"8,bcd"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Marshal(
+		&Format{
+			Word{100},
+		},
+	)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenInvalidBCD(t *testing.T) {
+	const (
+		errorMessage = "Unmarshal error: " +
+			"A bit field encoded as binary-coded decimal " +
+			"should hold a decimal digit in every four bits. " +
+			"Argument to Unmarshal points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"holding a value 0x5a with a digit greater than 9."
+	)
+
+	type (
+		Word struct {
+			BitField uint8 `bitfield:"8,bcd"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	e = Unmarshal([]byte{0x5a}, &Format{})
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenBitFieldOfLengthUnfitForBCD(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field encoded as binary-coded decimal " +
+			"should have a length that is a multiple of 4. " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of length 6."
+	)
+
+	type (
+		Word struct {
+			BitField uint8 `bitfield:"6,bcd"`
+			Reserved uint8 `bitfield:"2"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenBoolWithNumberCoding(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field is represented " +
+			"by an exported field of a word-struct " +
+			"tagged with a key \"bitfield\" and a value " +
+			"indicating the length of the bit field in number of bits " +
+			"(e.g. `bitfield:\"1\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Word struct {
+			BitField bool  `bitfield:"1,gray"`
+			Reserved uint8 `bitfield:"7"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestMarshalAndUnmarshalSignedIntegers(t *testing.T) {
+	type (
+		Word struct {
+			TwosComplement int8  `bitfield:"8"`
+			SignMagnitude  int8  `bitfield:"8,signmagnitude"`
+			OnesComplement int16 `bitfield:"12,onescomplement"`
+			Positive       int   `bitfield:"4,signmagnitude"`
+		}
+
+		Format struct {
+			Word `word:"32"`
+		}
+	)
+
+	var (
+		bytes   []byte
+		e       error
+		format  Format
+		format1 Format
+	)
+
+	format.TwosComplement = -1
+	format.SignMagnitude = -5
+	format.OnesComplement = -5
+	format.Positive = 7
+
+	bytes, e = Marshal(&format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0xff, 0x85, 0xff, 0xa7}, bytes,
+	)
+
+	e = Unmarshal(bytes, &format1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		format, format1,
+	)
+
+	// Negative zeroes are zeroes.
+
+	e = Unmarshal([]byte{0x80, 0x80, 0xff, 0xf8}, &format1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		Format{
+			Word{-128, 0, 0, 0},
+		},
+		format1,
+	)
+}
+
+func TestShouldReturnErrorGivenSignedIntegerOverflowingBitField(t *testing.T) {
+	const (
+		errorMessage = "Marshal error: " +
+			"A bit field holding a signed integer should be given one " +
+			"that its length and encoding can represent. " +
+			"Argument to Marshal points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"%s\" " +
+			"given one out of range: %s."
+	)
+
+	type (
+		Word struct {
+			TwosComplement int8 `bitfield:"4"`
+			SignMagnitude  int8 `bitfield:"4,signmagnitude"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Marshal(
+		&Format{
+			Word{-8, -7},
+		},
+	)
+
+	assert.Nil(t, e)
+
+	_, e = Marshal(
+		&Format{
+			Word{8, 0},
+		},
+	)
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage,
+			"TwosComplement", "8 overflowing 4 bit(s) in twoscomplement",
+		),
+		e.Error(),
+	)
+
+	_, e = Marshal(
+		&Format{
+			Word{0, -8},
+		},
+	)
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage,
+			"SignMagnitude", "-8 overflowing 4 bit(s) in signmagnitude",
+		),
+		e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenSignedEncodingOfUnsignedBitField(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field is represented " +
+			"by an exported field of a word-struct " +
+			"tagged with a key \"bitfield\" and a value " +
+			"indicating the length of the bit field in number of bits " +
+			"(e.g. `bitfield:\"1\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Word struct {
+			BitField uint8 `bitfield:"8,signmagnitude"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenSignedBitFieldWithConstraint(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field is represented " +
+			"by an exported field of a word-struct " +
+			"tagged with a key \"bitfield\" and a value " +
+			"indicating the length of the bit field in number of bits " +
+			"(e.g. `bitfield:\"1\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Word struct {
+			BitField int8 `bitfield:"8,max=100"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenBitFieldWithUnknownOption(t *testing.T) {
+	// Misspelt options are refused rather than ignored,
+	// while the offsets in tags of v1.1 are still accepted.
+
+	const (
+		errorMessage = "%[1]s error: " +
+			"Options following the length of a bit field in its struct tag " +
+			"should be those documented (e.g. `bitfield:\"2,reserved\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"with an unknown option \"reseved\"."
+	)
+
+	type (
+		Word struct {
+			Offset   uint8 `bitfield:"6,2"`
+			BitField uint8 `bitfield:"2,reseved"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestMarshalAndUnmarshalStrings(t *testing.T) {
+	// Fields of a tar header: a name longer than a word,
+	// an octal number right-justified, and a magic number.
+
+	type (
+		Word struct {
+			Mode string `bitfield:"32,rightjustified,spacepadded,trim"`
+			Kind string `bitfield:"8"`
+			Code string `bitfield:"24,ascii"`
+		}
+
+		Format struct {
+			Name  string `word:"96,trim"`
+			Word  `word:"64"`
+			Magic string `word:"48"`
+		}
+	)
+
+	var (
+		bytes   []byte
+		e       error
+		format  Format
+		format1 Format
+	)
+
+	format = Format{
+		Name: "README.md",
+		Word: Word{
+			Mode: "644",
+			Kind: "0",
+			Code: "A1",
+		},
+		Magic: "ustar\x00",
+	}
+
+	bytes, e = Marshal(&format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte(
+			"README.md\x00\x00\x00"+" 6440A1\x00"+"ustar\x00",
+		),
+		bytes,
+	)
+
+	e = Unmarshal(bytes, &format1)
+
+	assert.Nil(t, e)
+
+	format.Code = "A1\x00"
+
+	assert.Equal(t,
+		format, format1,
+	)
+}
+
+func TestShouldReturnErrorGivenStringOverflowingBitField(t *testing.T) {
+	const (
+		errorMessage = "Marshal error: " +
+			"A bit field holding a string should hold no more bytes " +
+			"than its length and only characters of its character set. " +
+			"Argument to Marshal points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Name\" " +
+			"that has a bit field \"Name\" " +
+			"that does not: " +
+			"string \"README.markdown\" of 15 byte(s) overflowing 12."
+	)
+
+	type (
+		Format struct {
+			Name string `word:"96"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Marshal(
+		&Format{"README.markdown"},
+	)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenStringNotOfASCII(t *testing.T) {
+	const (
+		errorMessage = "%s error: " +
+			"A bit field holding a string should hold no more bytes " +
+			"than its length and only characters of its character set. " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"Code\" " +
+			"that does not: string %s not of ASCII at byte 1."
+	)
+
+	type (
+		Word struct {
+			Code string `bitfield:"32,ascii"`
+		}
+
+		Format struct {
+			Word `word:"32"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Marshal(
+		&Format{
+			Word{"né"},
+		},
+	)
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage, "Marshal", `"né"`), e.Error(),
+	)
+
+	e = Unmarshal([]byte("n\xc3\xa9\x00"), &Format{})
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage, "Unmarshal", `"né\x00"`), e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenBitFieldOfLengthUnfitForString(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field holding a string should span whole bytes. " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of length 12."
+	)
+
+	type (
+		Word struct {
+			BitField string `bitfield:"12"`
+			Reserved uint8  `bitfield:"4"`
+		}
+
+		Format struct {
+			Word `word:"16"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestMarshalAndUnmarshalVarints(t *testing.T) {
+	// Words following variable-length integers
+	// move with the lengths of their encodings.
+
+	type (
+		Header struct {
+			Type  uint8 `bitfield:"4"`
+			Flags uint8 `bitfield:"4"`
+		}
+
+		Checksum struct {
+			Checksum uint16 `bitfield:"16"`
+		}
+
+		Format struct {
+			Header          `word:"8"`
+			RemainingLength uint32 `word:"28,mqtt"`
+			StreamID        uint64 `word:"62,quic"`
+			Delta           int32  `word:"32,zigzag"`
+			Offset          int64  `word:"64,sleb128"`
+			Size            uint64 `word:"64,uleb128"`
+			Checksum        `word:"16"`
+		}
+	)
+
+	var (
+		bytes   []byte
+		e       error
+		format  Format
+		format1 Format
+	)
+
+	format = Format{
+		Header:          Header{3, 0},
+		RemainingLength: 321,
+		StreamID:        494878333,
+		Delta:           -2,
+		Offset:          -123456,
+		Size:            624485,
+		Checksum:        Checksum{0xbeef},
+	}
+
+	bytes, e = Marshal(&format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{
+			0x30,
+			0xc1, 0x02,
+			0x9d, 0x7f, 0x3e, 0x7d,
+			0x03,
+			0xc0, 0xbb, 0x78,
+			0xe5, 0x8e, 0x26,
+			0xbe, 0xef,
+		},
+		bytes,
+	)
+
+	e = Unmarshal(bytes, &format1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		format, format1,
+	)
+
+	// Encodings longer than needed are accepted.
+
+	e = Unmarshal(
+		[]byte{0x30, 0x00, 0x40, 0x25, 0x00, 0x00, 0x80, 0x00, 0xbe, 0xef},
+		&format1,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		Format{
+			Header:   Header{3, 0},
+			StreamID: 37,
+			Checksum: Checksum{0xbeef},
+		},
+		format1,
+	)
+}
+
+func TestShouldReturnErrorGivenVarintOverflowingBitField(t *testing.T) {
+	const (
+		errorMessage = "Marshal error: " +
+			"A bit field holding a variable-length integer should be given " +
+			"one of no more bits than its length. " +
+			"Argument to Marshal points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"%[1]s\" " +
+			"that has a bit field \"%[1]s\" " +
+			"given one out of range: %[2]s."
+	)
+
+	type (
+		Format struct {
+			Length uint16 `word:"14,quic"`
+			Delta  int8   `word:"4,zigzag"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Marshal(
+		&Format{1<<14 - 1, -8},
+	)
+
+	assert.Nil(t, e)
+
+	_, e = Marshal(
+		&Format{1 << 14, 0},
+	)
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage,
+			"Length", "16384 overflowing 14 bit(s)",
+		),
+		e.Error(),
+	)
+
+	_, e = Marshal(
+		&Format{0, -9},
+	)
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage,
+			"Delta", "-9 overflowing 4 bit(s)",
+		),
+		e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenByteSliceUnfitForVariableLengthFormat(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "Unmarshal error: " +
+			"A byte slice into which a variable-length format-struct " +
+			"would be unmarshalled should hold each of its words in turn " +
+			"and nothing more. " +
+			"Argument to Unmarshal points to a format-struct " +
+			"\"binary.Format\" " +
+			"that the byte slice does not fit: %s."
+	)
+
+	type (
+		Format struct {
+			Length uint16 `word:"14,quic"`
+			Delta  int8   `word:"4,zigzag"`
+		}
+	)
+
+	var (
+		bytes []byte
+		cause string
+		e     error
+
+		cases = map[string][]byte{
+			`word "Length" truncated`:                {0x40},
+			`word "Length" overflowing 2 byte(s)`:    {0x80, 0, 0, 0, 0},
+			`word "Delta" truncated`:                 {0x00},
+			`word "Delta" overflowing 1 byte(s)`:     {0x00, 0x80, 0x00},
+			`word "Delta" of 8 overflowing 4 bit(s)`: {0x00, 0x10},
+			`1 byte(s) left over`:                    {0x00, 0x01, 0xff},
+		}
+	)
+
+	for cause, bytes = range cases {
+		e = Unmarshal(bytes, &Format{})
+
+		assert.Equal(t,
+			fmt.Sprintf(errorMessage, cause), e.Error(),
+		)
+	}
+}
+
+func TestShouldReturnErrorGivenVarintEncodingOfWrongType(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A format-struct should nest exported word-structs " +
+			"tagged with a key \"word\" and a value " +
+			"indicating the length of a word in number of bits " +
+			"(e.g. `word:\"32\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Length\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Format struct {
+			Length uint16 `word:"16,sleb128"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenBitFieldOfLengthUnfitForVarint(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field holding a variable-length integer " +
+			"should be no longer than its encoding allows. " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Length\" " +
+			"that has a bit field \"Length\" " +
+			"encoded as mqtt of length 32 not in [1, 28]."
+	)
+
+	type (
+		Format struct {
+			Length uint32 `word:"32,mqtt"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestMarshalAndUnmarshalBitStreams(t *testing.T) {
+	// Bit fields of bit streams follow one another across bytes,
+	// moving with the lengths of Exp-Golomb codes,
+	// and words following bit streams move with their lengths.
+
+	type (
+		SequenceParameterSet struct {
+			ProfileIDC            uint8    `bitfield:"8"`
+			ConstraintFlags       uint8    `bitfield:"8"`
+			LevelIDC              uint8    `bitfield:"8"`
+			SeqParameterSetID     uint8    `bitfield:"5,ue"`
+			Log2MaxFrameNumMinus4 uint8    `bitfield:"4,ue"`
+			OffsetForNonRefPic    int32    `bitfield:"32,se"`
+			FrameMbsOnlyFlag      bool     `bitfield:"1"`
+			_                     struct{} `bitfield:"0,align"`
+			Trailer               uint8    `bitfield:"8"`
+		}
+
+		Checksum struct {
+			Checksum uint16 `bitfield:"16"`
+		}
+
+		Format struct {
+			SequenceParameterSet `word:"0,bitstream"`
+			Checksum             `word:"16"`
+		}
+	)
+
+	var (
+		bytes   []byte
+		e       error
+		format  Format
+		format1 Format
 	)
 
-	for i = 0; i < b.N; i++ {
-		_, e = Marshal(&internetHeaderStruct)
-		if e != nil {
-			b.Error(e)
-		}
+	format = Format{
+		SequenceParameterSet: SequenceParameterSet{
+			ProfileIDC:            66,
+			ConstraintFlags:       0xc0,
+			LevelIDC:              30,
+			SeqParameterSetID:     0,
+			Log2MaxFrameNumMinus4: 3,
+			OffsetForNonRefPic:    -1,
+			FrameMbsOnlyFlag:      true,
+			Trailer:               0x80,
+		},
+		Checksum: Checksum{0xbeef},
 	}
-}
 
-func TestUnmarshal(t *testing.T) {
-	var (
-		e error
+	bytes, e = Marshal(&format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0x42, 0xc0, 0x1e, 0x91, 0xc0, 0x80, 0xbe, 0xef},
+		bytes,
 	)
 
-	e = Unmarshal(internetHeaderBytes, &internetHeaderStruct1)
+	e = Unmarshal(bytes, &format1)
 
 	assert.Nil(t, e)
 
 	assert.Equal(t,
-		internetHeaderStruct, internetHeaderStruct1,
+		format, format1,
 	)
 }
 
-func TestUnmarshalV1p1(t *testing.T) {
-	// Test backwards-compatibility with v1.1
+func TestShouldReturnErrorGivenExpGolombOverflowingBitField(t *testing.T) {
+	const (
+		errorMessage = "Marshal error: " +
+			"A bit field holding a variable-length integer should be given " +
+			"one of no more bits than its length. " +
+			"Argument to Marshal points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"%[1]s\" " +
+			"given one out of range: %[2]s."
+	)
+
+	type (
+		Word struct {
+			CodeNum uint8 `bitfield:"4,ue"`
+			Delta   int8  `bitfield:"4,se"`
+		}
+
+		Format struct {
+			Word `word:"0,bitstream"`
+		}
+	)
 
 	var (
 		e error
 	)
 
-	e = Unmarshal(internetHeaderBytes, &internetHeaderStruct1V1p1)
+	_, e = Marshal(
+		&Format{
+			Word{15, -8},
+		},
+	)
 
 	assert.Nil(t, e)
 
+	_, e = Marshal(
+		&Format{
+			Word{16, 0},
+		},
+	)
+
 	assert.Equal(t,
-		internetHeaderStructV1p1, internetHeaderStruct1V1p1,
+		fmt.Sprintf(errorMessage,
+			"CodeNum", "16 overflowing 4 bit(s)",
+		),
+		e.Error(),
+	)
+
+	_, e = Marshal(
+		&Format{
+			Word{0, 8},
+		},
+	)
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage,
+			"Delta", "8 overflowing 4 bit(s)",
+		),
+		e.Error(),
 	)
 }
 
-func BenchmarkUnmarshal(b *testing.B) {
+func TestShouldReturnErrorGivenByteSliceUnfitForBitStream(t *testing.T) {
+	const (
+		errorMessage = "Unmarshal error: " +
+			"A byte slice into which a variable-length format-struct " +
+			"would be unmarshalled should hold each of its words in turn " +
+			"and nothing more. " +
+			"Argument to Unmarshal points to a format-struct " +
+			"\"binary.Format\" " +
+			"that the byte slice does not fit: %s."
+	)
+
+	type (
+		Word struct {
+			Flag    bool     `bitfield:"1"`
+			CodeNum uint8    `bitfield:"4,ue"`
+			_       struct{} `bitfield:"0,align"`
+			Delta   int8     `bitfield:"4,se"`
+		}
+
+		Format struct {
+			Word `word:"0,bitstream"`
+		}
+	)
+
+	var (
+		bytes []byte
+		cause string
+		e     error
+
+		cases = map[string][]byte{
+			`word "Word" bit field "Flag" truncated`:    {},
+			`word "Word" bit field "CodeNum" truncated`: {0x80},
+			`word "Word" bit field "CodeNum" of 16 overflowing 4 bit(s)`: {
+				0x84, 0x40,
+			},
+			`word "Word" bit field "Delta" truncated`: {0xc0},
+			`word "Word" bit field "Delta" of 8 overflowing 4 bit(s)`: {
+				0xc0, 0x08, 0x00,
+			},
+			`1 byte(s) left over`: {0xc0, 0x80, 0x00},
+		}
+	)
+
+	for cause, bytes = range cases {
+		e = Unmarshal(bytes, &Format{})
+
+		assert.Equal(t,
+			fmt.Sprintf(errorMessage, cause), e.Error(),
+		)
+	}
+}
+
+func TestShouldReturnErrorGivenBitStreamWithMalformedTag(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A format-struct should nest exported word-structs " +
+			"tagged with a key \"word\" and a value " +
+			"indicating the length of a word in number of bits " +
+			"(e.g. `word:\"32\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Word struct {
+			BitField uint8 `bitfield:"8"`
+		}
+
+		Format struct {
+			Word `word:"8,bitstream"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenExpGolombWithMalformedTag(t *testing.T) {
+	// Exp-Golomb codes are of integers of the right signedness,
+	// in bit streams alone,
+	// and alignment markers are empty structs of no length.
+
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field is represented " +
+			"by an exported field of a word-struct " +
+			"tagged with a key \"bitfield\" and a value " +
+			"indicating the length of the bit field in number of bits " +
+			"(e.g. `bitfield:\"1\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.%[2]s\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"%[3]s\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Signed struct {
+			BitField int8 `bitfield:"8,ue"`
+		}
+
+		Fixed struct {
+			BitField uint8 `bitfield:"8,ue"`
+		}
+
+		Marker struct {
+			BitField uint8    `bitfield:"8"`
+			Align    struct{} `bitfield:"8,align"`
+		}
+
+		SignedFormat struct {
+			Word Signed `word:"0,bitstream"`
+		}
+
+		FixedFormat struct {
+			Word Fixed `word:"8"`
+		}
+
+		MarkerFormat struct {
+			Word Marker `word:"0,bitstream"`
+		}
+	)
+
 	var (
 		e error
-		i int
 	)
 
-	for i = 0; i < b.N; i++ {
-		e = Unmarshal(internetHeaderBytes, &internetHeaderStruct1)
-		if e != nil {
-			b.Error(e)
+	_, e = Marshal(&SignedFormat{})
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage, "Marshal", "SignedFormat", "BitField"),
+		e.Error(),
+	)
+
+	_, e = Marshal(&FixedFormat{})
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage, "Marshal", "FixedFormat", "BitField"),
+		e.Error(),
+	)
+
+	_, e = Marshal(&MarkerFormat{})
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage, "Marshal", "MarkerFormat", "Align"),
+		e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenBitFieldOfLengthUnfitForExpGolomb(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field holding a variable-length integer " +
+			"should be no longer than its encoding allows. " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"encoded as se of length 64 not in [1, 63]."
+	)
+
+	type (
+		Word struct {
+			BitField int64 `bitfield:"64,se"`
 		}
-	}
+
+		Format struct {
+			Word `word:"0,bitstream"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
 }
 
 func TestShouldReturnErrorGivenNonPointer(t *testing.T) {
@@ -226,6 +1904,66 @@ func TestShouldReturnErrorGivenWordWithMalformedTag(
 	)
 }
 
+func TestShouldReturnErrorGivenWordOfContradictoryByteOrders(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A format-struct should nest exported word-structs " +
+			"tagged with a key \"word\" and a value " +
+			"indicating the length of a word in number of bits " +
+			"(e.g. `word:\"32\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Word struct {
+			BitField uint `bitfield:"32"`
+		}
+
+		Format struct {
+			Word `word:"32,bigendian,littleendian"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenWordOfContradictoryBitOrders(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A format-struct should nest exported word-structs " +
+			"tagged with a key \"word\" and a value " +
+			"indicating the length of a word in number of bits " +
+			"(e.g. `word:\"32\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Word struct {
+			BitField uint `bitfield:"32"`
+		}
+
+		Format struct {
+			Word `word:"32,msbfirst,lsbfirst"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
 func TestShouldReturnErrorGivenWordOfIncompatibleLength(t *testing.T) {
 	const (
 		errorMessage = "%[1]s error: " +
@@ -283,16 +2021,19 @@ func TestShouldReturnErrorGivenBitFieldOfUnsupportedType(
 		errorMessage = "%[1]s error: " +
 			"A bit field is represented " +
 			"by an exported field of a word-struct " +
-			"of type uintN or bool. " +
+			"of type uintN, intN, bool, string, " +
+			"netip.Addr, netip.AddrPort, net.HardwareAddr, [N]byte, " +
+			"time.Time or time.Duration, " +
+			"or of a type implementing encoding.BinaryMarshaler. " +
 			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
 			"nesting a word-struct \"Word\" " +
 			"that has a bit field \"BitField\" " +
-			"of unsupported type \"int\"."
+			"of unsupported type \"float32\"."
 	)
 
 	type (
 		Word struct {
-			BitField int `bitfield:"32"`
+			BitField float32 `bitfield:"32"`
 		}
 
 		Format struct {
@@ -393,6 +2134,36 @@ func TestShouldReturnErrorGivenBitFieldOfLengthOverflowingType(t *testing.T) {
 	)
 }
 
+func TestShouldReturnErrorGivenBitFieldOfLengthUnfitForNetworkType(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field of a network type should be exactly as long " +
+			"as the addresses or ports it holds. " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of type \"netip.Addr\" and length 24 not in {32, 128}."
+	)
+
+	type (
+		Word struct {
+			BitField netip.Addr `bitfield:"24"`
+			Reserved uint8      `bitfield:"8"`
+		}
+
+		Format struct {
+			Word `word:"32"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
 func TestShouldReturnErrorGivenWordOfLengthNotEqualToSumOfLengthsOfBitFields(
 	t *testing.T,
 ) {
@@ -509,7 +2280,7 @@ const (
 
 var (
 	internetHeaderStruct = rfc791.RFC791InternetHeaderFormatWithoutOptions{
-		rfc791.RFC791InternetHeaderFormatWord0{
+		RFC791InternetHeaderFormatWord0: rfc791.RFC791InternetHeaderFormatWord0{
 			Version:     rfc791.RFC791InternetHeaderVersion,
 			IHL:         rfc791.RFC791InternetHeaderLengthWithoutOptions,
 			Precedence:  rfc791.RFC791InternetHeaderPrecedenceNetworkControl,
@@ -518,24 +2289,24 @@ var (
 			Reliability: rfc791.RFC791InternetHeaderReliabilityNormal,
 			TotalLength: totalLength,
 		},
-		rfc791.RFC791InternetHeaderFormatWord1{
+		RFC791InternetHeaderFormatWord1: rfc791.RFC791InternetHeaderFormatWord1{
 			Identification: identification,
 			FlagsBit1:      rfc791.RFC791InternetHeaderFlagsBit1DoNotFragment,
 			FlagsBit2:      rfc791.RFC791InternetHeaderFlagsBit2LastFragment,
 			FragmentOffset: fragmentOffset,
 		},
-		rfc791.RFC791InternetHeaderFormatWord2{
+		RFC791InternetHeaderFormatWord2: rfc791.RFC791InternetHeaderFormatWord2{
 			TimeToLive:     timeToLive,
 			Protocol:       rfc791.RFC791InternetHeaderProtocolTCP,
 			HeaderChecksum: headerChecksum,
 		},
-		rfc791.RFC791InternetHeaderFormatWord3{
+		RFC791InternetHeaderFormatWord3: rfc791.RFC791InternetHeaderFormatWord3{
 			SourceAddressOctet0: sourceAddressOctet0,
 			SourceAddressOctet1: sourceAddressOctet1,
 			SourceAddressOctet2: sourceAddressOctet2,
 			SourceAddressOctet3: sourceAddressOctet3,
 		},
-		rfc791.RFC791InternetHeaderFormatWord4{
+		RFC791InternetHeaderFormatWord4: rfc791.RFC791InternetHeaderFormatWord4{
 			DestinationAddressOctet0: destinationAddressOctet0,
 			DestinationAddressOctet1: destinationAddressOctet1,
 			DestinationAddressOctet2: destinationAddressOctet2,
@@ -546,7 +2317,7 @@ var (
 	internetHeaderStruct1 rfc791.RFC791InternetHeaderFormatWithoutOptions
 
 	internetHeaderStructV1p1 = v1p1.RFC791InternetHeaderFormatWithoutOptions{
-		v1p1.RFC791InternetHeaderFormatWord0{
+		RFC791InternetHeaderFormatWord0: v1p1.RFC791InternetHeaderFormatWord0{
 			Version:     v1p1.RFC791InternetHeaderVersion,
 			IHL:         v1p1.RFC791InternetHeaderLengthWithoutOptions,
 			Precedence:  v1p1.RFC791InternetHeaderPrecedenceNetworkControl,
@@ -555,24 +2326,24 @@ var (
 			Reliability: v1p1.RFC791InternetHeaderReliabilityNormal,
 			TotalLength: totalLength,
 		},
-		v1p1.RFC791InternetHeaderFormatWord1{
+		RFC791InternetHeaderFormatWord1: v1p1.RFC791InternetHeaderFormatWord1{
 			Identification: identification,
 			FlagsBit1:      v1p1.RFC791InternetHeaderFlagsBit1DoNotFragment,
 			FlagsBit2:      v1p1.RFC791InternetHeaderFlagsBit2LastFragment,
 			FragmentOffset: fragmentOffset,
 		},
-		v1p1.RFC791InternetHeaderFormatWord2{
+		RFC791InternetHeaderFormatWord2: v1p1.RFC791InternetHeaderFormatWord2{
 			TimeToLive:     timeToLive,
 			Protocol:       v1p1.RFC791InternetHeaderProtocolTCP,
 			HeaderChecksum: headerChecksum,
 		},
-		v1p1.RFC791InternetHeaderFormatWord3{
+		RFC791InternetHeaderFormatWord3: v1p1.RFC791InternetHeaderFormatWord3{
 			SourceAddressOctet0: sourceAddressOctet0,
 			SourceAddressOctet1: sourceAddressOctet1,
 			SourceAddressOctet2: sourceAddressOctet2,
 			SourceAddressOctet3: sourceAddressOctet3,
 		},
-		v1p1.RFC791InternetHeaderFormatWord4{
+		RFC791InternetHeaderFormatWord4: v1p1.RFC791InternetHeaderFormatWord4{
 			DestinationAddressOctet0: destinationAddressOctet0,
 			DestinationAddressOctet1: destinationAddressOctet1,
 			DestinationAddressOctet2: destinationAddressOctet2,
@@ -582,6 +2353,41 @@ var (
 
 	internetHeaderStruct1V1p1 v1p1.RFC791InternetHeaderFormatWithoutOptions
 
+	internetHeaderStructNetip = rfc791netip.RFC791InternetHeaderFormatWithoutOptions{
+		RFC791InternetHeaderFormatWord0: rfc791netip.RFC791InternetHeaderFormatWord0{
+			Version:     rfc791netip.RFC791InternetHeaderVersion,
+			IHL:         rfc791netip.RFC791InternetHeaderLengthWithoutOptions,
+			Precedence:  rfc791netip.RFC791InternetHeaderPrecedenceNetworkControl,
+			Delay:       rfc791netip.RFC791InternetHeaderDelayNormal,
+			Throughput:  rfc791netip.RFC791InternetHeaderThroughputHigh,
+			Reliability: rfc791netip.RFC791InternetHeaderReliabilityNormal,
+			TotalLength: totalLength,
+		},
+		RFC791InternetHeaderFormatWord1: rfc791netip.RFC791InternetHeaderFormatWord1{
+			Identification: identification,
+			FlagsBit1:      rfc791netip.RFC791InternetHeaderFlagsBit1DoNotFragment,
+			FlagsBit2:      rfc791netip.RFC791InternetHeaderFlagsBit2LastFragment,
+			FragmentOffset: fragmentOffset,
+		},
+		RFC791InternetHeaderFormatWord2: rfc791netip.RFC791InternetHeaderFormatWord2{
+			TimeToLive:     timeToLive,
+			Protocol:       rfc791netip.RFC791InternetHeaderProtocolTCP,
+			HeaderChecksum: headerChecksum,
+		},
+		Source: netip.AddrFrom4([4]byte{
+			sourceAddressOctet0,
+			sourceAddressOctet1,
+			sourceAddressOctet2,
+			sourceAddressOctet3,
+		}),
+		Destination: netip.AddrFrom4([4]byte{
+			destinationAddressOctet0,
+			destinationAddressOctet1,
+			destinationAddressOctet2,
+			destinationAddressOctet3,
+		}),
+	}
+
 	internetHeaderBytes = []byte{
 		0b01000101, 0b11101000, 0b11111111, 0b11111111,
 		0b00000000, 0b00000000, 0b01011111, 0b11111111,
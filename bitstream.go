@@ -0,0 +1,48 @@
+package binary
+
+import (
+	"io"
+
+	"github.com/encodingx/binary/internal/bitstream"
+)
+
+// Bit streams are read and written a bit field at a time,
+// most significant bit first by default, across byte boundaries,
+// as are the bit-stream words of format-structs,
+// or least significant bit first, from and to byte slices
+// or any io.Reader or io.Writer.
+
+type (
+	BitReader = bitstream.Reader
+	BitWriter = bitstream.Writer
+	BitOrder  = bitstream.Order
+)
+
+const (
+	MSBFirst = bitstream.MSBFirst
+	LSBFirst = bitstream.LSBFirst
+)
+
+var (
+	ErrExpGolombOverflow = bitstream.ErrExpGolombOverflow
+	ErrNotByteAligned    = bitstream.ErrNotByteAligned
+)
+
+func NewBitReader(bytes []byte) *BitReader {
+	return bitstream.NewReader(bytes)
+}
+
+func NewBitReaderFrom(r io.Reader, order BitOrder) *BitReader {
+	return bitstream.NewReaderFrom(r, order)
+}
+
+func NewBitWriter() *BitWriter {
+	return bitstream.NewWriter()
+}
+
+func NewBitWriterTo(w io.Writer, order BitOrder) *BitWriter {
+	// Bytes are written out to w once whole,
+	// the last once the writer is aligned.
+
+	return bitstream.NewWriterTo(w, order)
+}
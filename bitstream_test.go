@@ -0,0 +1,341 @@
+package binary
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitWriterAndBitReader(t *testing.T) {
+	// Code numbers 0 to 4 are coded 1, 010, 011, 00100 and 00101,
+	// and signed integers 0, 1, -1, 2 and -2 likewise.
+
+	var (
+		codeNum  uint64
+		e        error
+		expected int64
+		number   int64
+		reader   *BitReader
+		signed   = []int64{0, 1, -1, 2, -2}
+		value    uint64
+		writer   *BitWriter
+	)
+
+	writer = NewBitWriter()
+
+	for codeNum = 0; codeNum < 5; codeNum++ {
+		assert.Nil(t,
+			writer.WriteExpGolomb(codeNum),
+		)
+	}
+
+	for _, number = range signed {
+		assert.Nil(t,
+			writer.WriteSignedExpGolomb(number),
+		)
+	}
+
+	assert.Nil(t,
+		writer.WriteBits(0x5, 3),
+	)
+
+	assert.Equal(t,
+		uint(37), writer.Position(),
+	)
+
+	assert.Nil(t,
+		writer.Align(),
+	)
+
+	assert.Nil(t,
+		writer.WriteBits(0xbeef, 16),
+	)
+
+	assert.Equal(t,
+		[]byte{0xa6, 0x42, 0xd3, 0x21, 0x68, 0xbe, 0xef},
+		writer.Bytes(),
+	)
+
+	reader = NewBitReader(
+		writer.Bytes(),
+	)
+
+	for codeNum = 0; codeNum < 5; codeNum++ {
+		value, e = reader.ReadExpGolomb()
+
+		assert.Nil(t, e)
+
+		assert.Equal(t,
+			codeNum, value,
+		)
+	}
+
+	for _, expected = range signed {
+		number, e = reader.ReadSignedExpGolomb()
+
+		assert.Nil(t, e)
+
+		assert.Equal(t,
+			expected, number,
+		)
+	}
+
+	value, e = reader.ReadBits(3)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		uint64(0x5), value,
+	)
+
+	reader.Align()
+
+	value, e = reader.ReadBits(16)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		uint64(0xbeef), value,
+	)
+
+	_, e = reader.ReadBits(1)
+
+	assert.ErrorIs(t, e, io.ErrUnexpectedEOF)
+}
+
+func TestBitWriterAndBitReaderShouldCodeGreatestIntegers(t *testing.T) {
+	var (
+		e      error
+		number int64
+		reader *BitReader
+		value  uint64
+		writer *BitWriter
+	)
+
+	writer = NewBitWriter()
+
+	assert.Nil(t,
+		writer.WriteExpGolomb(math.MaxUint64),
+	)
+
+	assert.Nil(t,
+		writer.WriteSignedExpGolomb(math.MinInt64+1),
+	)
+
+	assert.ErrorIs(t,
+		writer.WriteSignedExpGolomb(math.MinInt64),
+		ErrExpGolombOverflow,
+	)
+
+	assert.Equal(t,
+		uint(129+127), writer.Position(),
+	)
+
+	reader = NewBitReader(
+		writer.Bytes(),
+	)
+
+	value, e = reader.ReadExpGolomb()
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		uint64(math.MaxUint64), value,
+	)
+
+	number, e = reader.ReadSignedExpGolomb()
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		int64(math.MinInt64+1), number,
+	)
+}
+
+func TestBitReaderShouldReturnErrorGivenExpGolombOverflow(t *testing.T) {
+	var (
+		bytes = make([]byte, 17)
+		e     error
+	)
+
+	// Sixty-four zeros followed by a one and a nonzero suffix
+	// code a number greater than the greatest of 64 bits.
+
+	bytes[8] = 0x80
+	bytes[16] = 0x80
+
+	_, e = NewBitReader(bytes).ReadExpGolomb()
+
+	assert.ErrorIs(t, e, ErrExpGolombOverflow)
+
+	_, e = NewBitReader(
+		make([]byte, 9),
+	).ReadExpGolomb()
+
+	assert.ErrorIs(t, e, ErrExpGolombOverflow)
+}
+
+func TestBitWriterShouldReturnErrorGivenValueOverflowingLength(t *testing.T) {
+	assert.EqualError(t,
+		NewBitWriter().WriteBits(8, 3),
+		"8 overflowing 3 bit(s)",
+	)
+}
+
+func TestBitWriterAndBitReaderInEitherOrder(t *testing.T) {
+	// Three bits 101, four bits of -2 and one set,
+	// least significant first, then whole bytes.
+
+	var (
+		buffer bytes.Buffer
+		e      error
+		flag   bool
+		number int64
+		reader *BitReader
+		text   = make([]byte, 2)
+		value  uint64
+		writer *BitWriter
+
+		cases = map[BitOrder]byte{
+			MSBFirst: 0xbd,
+			LSBFirst: 0xf5,
+		}
+
+		expected byte
+		order    BitOrder
+	)
+
+	for order, expected = range cases {
+		buffer.Reset()
+
+		writer = NewBitWriterTo(&buffer, order)
+
+		assert.Nil(t,
+			writer.WriteBits(0x5, 3),
+		)
+
+		assert.Nil(t,
+			writer.WriteSigned(-2, 4),
+		)
+
+		assert.Nil(t,
+			writer.WriteBool(true),
+		)
+
+		assert.Nil(t,
+			writer.WriteBytes([]byte("ok")),
+		)
+
+		assert.Equal(t,
+			[]byte{expected, 'o', 'k'}, buffer.Bytes(),
+		)
+
+		reader = NewBitReaderFrom(
+			bytes.NewReader(buffer.Bytes()), order,
+		)
+
+		value, e = reader.ReadBits(3)
+
+		assert.Nil(t, e)
+
+		assert.Equal(t,
+			uint64(0x5), value,
+		)
+
+		number, e = reader.ReadSigned(4)
+
+		assert.Nil(t, e)
+
+		assert.Equal(t,
+			int64(-2), number,
+		)
+
+		flag, e = reader.ReadBool()
+
+		assert.Nil(t, e)
+
+		assert.True(t, flag)
+
+		assert.Nil(t,
+			reader.ReadBytes(text),
+		)
+
+		assert.Equal(t,
+			"ok", string(text),
+		)
+
+		assert.Equal(t,
+			uint(24), reader.Position(),
+		)
+
+		_, e = reader.ReadBool()
+
+		assert.ErrorIs(t, e, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestBitWriterAndBitReaderShouldReturnErrorGivenBytesNotAligned(
+	t *testing.T,
+) {
+	var (
+		reader = NewBitReader([]byte{0x80, 0x00})
+		writer = NewBitWriter()
+	)
+
+	assert.Nil(t,
+		writer.WriteBool(true),
+	)
+
+	assert.ErrorIs(t,
+		writer.WriteBytes([]byte{0x00}),
+		ErrNotByteAligned,
+	)
+
+	_, _ = reader.ReadBool()
+
+	assert.ErrorIs(t,
+		reader.ReadBytes(
+			make([]byte, 1),
+		),
+		ErrNotByteAligned,
+	)
+
+	reader.Align()
+
+	assert.Nil(t,
+		reader.ReadBytes(
+			make([]byte, 1),
+		),
+	)
+}
+
+func TestBitWriterShouldReturnErrorGivenSignedOverflowingLength(t *testing.T) {
+	var (
+		writer = NewBitWriter()
+	)
+
+	assert.Nil(t,
+		writer.WriteSigned(7, 4),
+	)
+
+	assert.Nil(t,
+		writer.WriteSigned(-8, 4),
+	)
+
+	assert.EqualError(t,
+		writer.WriteSigned(8, 4),
+		"8 overflowing 4 bit(s)",
+	)
+
+	assert.EqualError(t,
+		writer.WriteSigned(-9, 4),
+		"-9 overflowing 4 bit(s)",
+	)
+
+	assert.Equal(t,
+		[]byte{0x78}, writer.Bytes(),
+	)
+}
@@ -0,0 +1,517 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/encodingx/binary"
+)
+
+type sourceEnumeration struct {
+	typeName    string
+	bitSize     int
+	flags       bool
+	enumeration []binary.EnumeratedValue
+}
+
+const (
+	registerEnumeration = "RegisterEnumeration"
+	registerFlags       = "RegisterFlags"
+)
+
+var (
+	bitSizes = map[string]int{
+		"uint":   0,
+		"uint8":  8,
+		"uint16": 16,
+		"uint32": 32,
+		"uint64": 64,
+	}
+)
+
+func parseEnumerations(directory string, typeNames []string) (
+	packageName string, enumerations []sourceEnumeration, e error,
+) {
+	// Find the enumerated values registered for each type
+	// by a call to binary.RegisterEnumeration with literal arguments, e.g.
+	//
+	//	binary.RegisterEnumeration[Protocol](
+	//		binary.EnumeratedValue{Name: "TCP", Value: 6},
+	//	)
+	//
+	// or the flags registered by a call to binary.RegisterFlags.
+
+	var (
+		calls      = make(map[string]*ast.CallExpr)
+		file       *ast.File
+		name       string
+		ok         bool
+		pkg        *ast.Package
+		source     sourceEnumeration
+		typeExprs  = make(map[string]ast.Expr)
+		underlying string
+	)
+
+	packageName, pkg, e = loadPackage(directory)
+	if e != nil {
+		return
+	}
+
+	for _, file = range pkg.Files {
+		ast.Inspect(file,
+			func(node ast.Node) bool {
+				var (
+					call  *ast.CallExpr
+					index *ast.IndexExpr
+					ok    bool
+					spec  *ast.TypeSpec
+				)
+
+				spec, ok = node.(*ast.TypeSpec)
+				if ok {
+					typeExprs[spec.Name.Name] = spec.Type
+				}
+
+				call, ok = node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				index, ok = call.Fun.(*ast.IndexExpr)
+				if ok && registeringFunction(index.X) != "" {
+					calls[typeName(index.Index)] = call
+				}
+
+				return true
+			},
+		)
+	}
+
+	for _, name = range typeNames {
+		source = sourceEnumeration{
+			typeName: name,
+		}
+
+		underlying, _ = underlyingType(name, typeExprs)
+
+		source.bitSize, ok = bitSizes[underlying]
+		if !ok {
+			e = fmt.Errorf("enumerated type %s is not an unsigned integer",
+				name,
+			)
+
+			return
+		}
+
+		_, ok = calls[name]
+		if !ok {
+			e = fmt.Errorf("enumerated type %s is not registered "+
+				"by a call to binary.RegisterEnumeration or RegisterFlags",
+				name,
+			)
+
+			return
+		}
+
+		source.flags = registeringFunction(
+			calls[name].Fun.(*ast.IndexExpr).X,
+		) == registerFlags
+
+		source.enumeration, e = parseEnumeratedValues(calls[name],
+			source.bitSize, source.flags,
+		)
+		if e != nil {
+			e = fmt.Errorf("enumerated type %s: %w", name, e)
+
+			return
+		}
+
+		enumerations = append(enumerations, source)
+	}
+
+	return
+}
+
+func registeringFunction(expr ast.Expr) (name string) {
+	var (
+		ident    *ast.Ident
+		ok       bool
+		selector *ast.SelectorExpr
+	)
+
+	selector, ok = expr.(*ast.SelectorExpr)
+	if ok {
+		name = selector.Sel.Name
+	}
+
+	ident, ok = expr.(*ast.Ident)
+	if ok {
+		name = ident.Name
+	}
+
+	if name != registerEnumeration && name != registerFlags {
+		name = ""
+	}
+
+	return
+}
+
+func parseEnumeratedValues(call *ast.CallExpr, bitSize int, flags bool) (
+	enumeration []binary.EnumeratedValue, e error,
+) {
+	var (
+		arg        ast.Expr
+		enumerated binary.EnumeratedValue
+		i          int
+		literal    *ast.CompositeLit
+		names      = make(map[string]bool)
+		ok         bool
+	)
+
+	for i, arg = range call.Args {
+		literal, ok = arg.(*ast.CompositeLit)
+		if !ok || len(literal.Elts) != 2 {
+			e = fmt.Errorf("enumerated value %d is not a literal", i)
+
+			return
+		}
+
+		enumerated, e = parseEnumeratedValue(literal, bitSize)
+		if e != nil {
+			e = fmt.Errorf("enumerated value %d: %w", i, e)
+
+			return
+		}
+
+		if !token.IsIdentifier(enumerated.Name) || names[enumerated.Name] {
+			e = fmt.Errorf("enumerated value %d: name %q "+
+				"is not a unique identifier",
+				i, enumerated.Name,
+			)
+
+			return
+		}
+
+		if flags && bits.OnesCount64(enumerated.Value) != 1 {
+			e = fmt.Errorf("flag %d: value %#x is not a single bit",
+				i, enumerated.Value,
+			)
+
+			return
+		}
+
+		names[enumerated.Name] = true
+
+		enumeration = append(enumeration, enumerated)
+	}
+
+	return
+}
+
+func parseEnumeratedValue(literal *ast.CompositeLit, bitSize int) (
+	enumerated binary.EnumeratedValue, e error,
+) {
+	// Accept elements keyed by field (Name, Value) or in that order.
+
+	const (
+		valueBase = 0
+	)
+
+	var (
+		element  ast.Expr
+		elements = map[string]ast.Expr{
+			"Name":  literal.Elts[0],
+			"Value": literal.Elts[1],
+		}
+		key      *ast.Ident
+		keyValue *ast.KeyValueExpr
+		name     *ast.BasicLit
+		ok       bool
+		value    *ast.BasicLit
+	)
+
+	for _, element = range literal.Elts {
+		keyValue, ok = element.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key, ok = keyValue.Key.(*ast.Ident)
+		if ok {
+			elements[key.Name] = keyValue.Value
+		}
+	}
+
+	name, ok = elements["Name"].(*ast.BasicLit)
+	if !ok || name.Kind != token.STRING {
+		e = errors.New("name is not a string literal")
+
+		return
+	}
+
+	value, ok = elements["Value"].(*ast.BasicLit)
+	if !ok || value.Kind != token.INT {
+		e = errors.New("value is not an integer literal")
+
+		return
+	}
+
+	enumerated.Name, e = strconv.Unquote(name.Value)
+	if e != nil {
+		return
+	}
+
+	enumerated.Value, e = strconv.ParseUint(value.Value, valueBase, bitSize)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func generateEnumerations(generator, packageName string,
+	enumerations []sourceEnumeration,
+) (
+	source []byte, e error,
+) {
+	const (
+		header = "" +
+			"// Code generated by %s; DO NOT EDIT.\n" +
+			"\n" +
+			"package %s\n" +
+			"\n" +
+			"import (\n" +
+			"\t\"fmt\"\n" +
+			"%s" +
+			")\n"
+	)
+
+	var (
+		body        bytes.Buffer
+		buffer      bytes.Buffer
+		enumeration sourceEnumeration
+		imports     = make(map[string]bool)
+	)
+
+	for _, enumeration = range enumerations {
+		if enumeration.flags {
+			generateFlags(&body, enumeration)
+
+			imports["strings"] = true
+
+		} else {
+			generateEnumeration(&body, enumeration)
+
+			imports["strconv"] = true
+		}
+	}
+
+	fmt.Fprintf(&buffer, header, generator, packageName,
+		generateImports(imports),
+	)
+
+	buffer.Write(
+		body.Bytes(),
+	)
+
+	source, e = format.Source(
+		buffer.Bytes(),
+	)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func generateEnumeration(buffer *bytes.Buffer,
+	enumeration sourceEnumeration,
+) {
+	// Name constants after their types and enumerated names
+	// (e.g. ProtocolTCP), and print and parse values by those names,
+	// falling back on numbers for values not enumerated.
+	// Of several names for a value, the first is printed.
+
+	var (
+		constant   string
+		constants  []string
+		enumerated binary.EnumeratedValue
+		name       = enumeration.typeName
+		printed    = make(map[uint64]bool)
+	)
+
+	buffer.WriteString("\nconst (\n")
+
+	for _, enumerated = range enumeration.enumeration {
+		fmt.Fprintf(buffer, "\t%s%s %s = %d\n",
+			name, enumerated.Name, name, enumerated.Value,
+		)
+	}
+
+	buffer.WriteString(")\n")
+
+	fmt.Fprintf(buffer, "\nfunc (v %s) String() string {\n"+
+		"\tswitch v {\n",
+		name,
+	)
+
+	for _, enumerated = range enumeration.enumeration {
+		if printed[enumerated.Value] {
+			continue
+		}
+
+		printed[enumerated.Value] = true
+
+		constant = name + enumerated.Name
+
+		constants = append(constants, constant)
+
+		fmt.Fprintf(buffer, "\tcase %s:\n"+
+			"\t\treturn %q\n",
+			constant, enumerated.Name,
+		)
+	}
+
+	fmt.Fprintf(buffer, "\t}\n"+
+		"\n"+
+		"\treturn fmt.Sprintf(\"%s(%%d)\", uint64(v))\n"+
+		"}\n",
+		name,
+	)
+
+	fmt.Fprintf(buffer, "\nfunc (v %s) MarshalText() ([]byte, error) {\n",
+		name,
+	)
+
+	if len(constants) > 0 {
+		fmt.Fprintf(buffer, "\tswitch v {\n"+
+			"\tcase %s:\n"+
+			"\t\treturn []byte(v.String()), nil\n"+
+			"\t}\n"+
+			"\n",
+			strings.Join(constants, ", "),
+		)
+	}
+
+	buffer.WriteString("\treturn strconv.AppendUint(nil, uint64(v), 10), nil\n" +
+		"}\n",
+	)
+
+	fmt.Fprintf(buffer, "\nfunc (v *%s) UnmarshalText(text []byte) error {\n"+
+		"\tvar (\n"+
+		"\t\te     error\n"+
+		"\t\tvalue uint64\n"+
+		"\t)\n"+
+		"\n"+
+		"\tswitch string(text) {\n",
+		name,
+	)
+
+	for _, enumerated = range enumeration.enumeration {
+		fmt.Fprintf(buffer, "\tcase %q:\n"+
+			"\t\t*v = %s%s\n"+
+			"\n"+
+			"\t\treturn nil\n",
+			enumerated.Name, name, enumerated.Name,
+		)
+	}
+
+	fmt.Fprintf(buffer, "\t}\n"+
+		"\n"+
+		"\tvalue, e = strconv.ParseUint(string(text), 10, %d)\n"+
+		"\tif e != nil {\n"+
+		"\t\treturn fmt.Errorf(\"%%q is not a %s\", text)\n"+
+		"\t}\n"+
+		"\n"+
+		"\t*v = %s(value)\n"+
+		"\n"+
+		"\treturn nil\n"+
+		"}\n",
+		enumeration.bitSize, name, name,
+	)
+
+	return
+}
+
+func generateImports(imports map[string]bool) (source string) {
+	var (
+		path string
+	)
+
+	for _, path = range []string{"strconv", "strings"} {
+		if imports[path] {
+			source += fmt.Sprintf("\t%q\n", path)
+		}
+	}
+
+	return
+}
+
+func generateFlags(buffer *bytes.Buffer, enumeration sourceEnumeration) {
+	// Name constants after their types and flags (e.g. IPFlagsDF),
+	// and print values as the flags set (e.g. DF|MF),
+	// followed by any other bits set.
+
+	var (
+		constants []string
+		flag      binary.EnumeratedValue
+		name      = enumeration.typeName
+	)
+
+	buffer.WriteString("\nconst (\n")
+
+	for _, flag = range enumeration.enumeration {
+		fmt.Fprintf(buffer, "\t%s%s %s = %#x\n",
+			name, flag.Name, name, flag.Value,
+		)
+
+		constants = append(constants, name+flag.Name)
+	}
+
+	buffer.WriteString(")\n")
+
+	fmt.Fprintf(buffer, "\nfunc (v %s) String() string {\n"+
+		"\tvar (\n"+
+		"\t\tnames []string\n"+
+		"\t)\n"+
+		"\n",
+		name,
+	)
+
+	for _, flag = range enumeration.enumeration {
+		fmt.Fprintf(buffer, "\tif v&%s%s != 0 {\n"+
+			"\t\tnames = append(names, %q)\n"+
+			"\t}\n"+
+			"\n",
+			name, flag.Name, flag.Name,
+		)
+	}
+
+	if len(constants) > 0 {
+		fmt.Fprintf(buffer, "\tv &^= %s\n"+
+			"\n",
+			strings.Join(constants, " | "),
+		)
+	}
+
+	buffer.WriteString("\tif v != 0 {\n" +
+		"\t\tnames = append(names, fmt.Sprintf(\"%#b\", uint64(v)))\n" +
+		"\t}\n" +
+		"\n" +
+		"\tif len(names) == 0 {\n" +
+		"\t\treturn \"0\"\n" +
+		"\t}\n" +
+		"\n" +
+		"\treturn strings.Join(names, \"|\")\n" +
+		"}\n",
+	)
+
+	return
+}
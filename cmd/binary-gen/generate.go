@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+
+	"github.com/encodingx/binary"
+)
+
+func generate(generator, packageName string, formats []sourceFormat) (
+	source []byte, e error,
+) {
+	const (
+		header = "" +
+			"// Code generated by %s; DO NOT EDIT.\n" +
+			"\n" +
+			"package %s\n" +
+			"\n" +
+			"import (\n" +
+			"\t\"fmt\"\n" +
+			")\n"
+	)
+
+	var (
+		buffer     bytes.Buffer
+		descriptor binary.FormatDescriptor
+		f          sourceFormat
+	)
+
+	fmt.Fprintf(&buffer, header, generator, packageName)
+
+	for _, f = range formats {
+		e = checkOptions(f.definition)
+		if e != nil {
+			return
+		}
+
+		// Validate the format as Marshal and Unmarshal would,
+		// and work out the offsets of its words and bit fields.
+
+		descriptor, e = f.definition.Describe()
+		if e != nil {
+			return
+		}
+
+		generateMethods(&buffer, f, descriptor)
+	}
+
+	source, e = format.Source(
+		buffer.Bytes(),
+	)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func generateMethods(buffer *bytes.Buffer, f sourceFormat,
+	descriptor binary.FormatDescriptor,
+) {
+	var (
+		bitField bitFieldLine
+		i        int
+		j        int
+		name     = descriptor.Name()
+		word     binary.WordDescriptor
+	)
+
+	fmt.Fprintf(buffer, "\nfunc (f *%s) BinaryFormatLength() int {\n"+
+		"\treturn %d\n"+
+		"}\n",
+		name, descriptor.LengthInBytes(),
+	)
+
+	fmt.Fprintf(buffer, "\nfunc (f *%s) MarshalBinary() ([]byte, error) {\n"+
+		"\treturn f.AppendBinary(\n"+
+		"\t\tmake([]byte, 0, %d),\n"+
+		"\t)\n"+
+		"}\n",
+		name, descriptor.LengthInBytes(),
+	)
+
+	fmt.Fprintf(buffer, "\nfunc (f *%s) AppendBinary(bytes []byte) "+
+		"([]byte, error) {\n"+
+		"\tvar (\n"+
+		"\t\tword uint64\n"+
+		"\t)\n",
+		name,
+	)
+
+	for i, word = range descriptor.Words() {
+		buffer.WriteString("\n\tword = 0\n")
+
+		for j = range word.BitFields() {
+			bitField = newBitFieldLine(f, word, i, j)
+
+			if bitField.boolean {
+				fmt.Fprintf(buffer, "\n\tif f.%s {\n"+
+					"\t\tword |= 1 << %d\n"+
+					"\t}\n\n",
+					bitField.path, bitField.shift,
+				)
+
+			} else {
+				fmt.Fprintf(buffer, "\tword |= uint64(f.%s) & %#x << %d\n",
+					bitField.path, bitField.mask, bitField.shift,
+				)
+			}
+		}
+
+		fmt.Fprintf(buffer, "\n\tbytes = append(bytes, %s)\n",
+			strings.Join(
+				wordBytes(word, "byte(word >> %[1]d)"),
+				", ",
+			),
+		)
+	}
+
+	buffer.WriteString("\n\treturn bytes, nil\n}\n")
+
+	fmt.Fprintf(buffer, "\nfunc (f *%s) UnmarshalBinary(bytes []byte) "+
+		"error {\n"+
+		"\tvar (\n"+
+		"\t\tword uint64\n"+
+		"\t)\n"+
+		"\n"+
+		"\tif len(bytes) != %d {\n"+
+		"\t\treturn fmt.Errorf(\"UnmarshalBinary error: \"+\n"+
+		"\t\t\t\"length of byte slice, %%d byte(s), \"+\n"+
+		"\t\t\t\"not equal to length of format %s, %d byte(s)\",\n"+
+		"\t\t\tlen(bytes),\n"+
+		"\t\t)\n"+
+		"\t}\n",
+		name, descriptor.LengthInBytes(),
+		name, descriptor.LengthInBytes(),
+	)
+
+	for i, word = range descriptor.Words() {
+		fmt.Fprintf(buffer, "\n\tword = %s\n\n",
+			strings.Join(
+				wordBytes(word, "uint64(bytes[%[2]d]) << %[1]d"),
+				" | ",
+			),
+		)
+
+		for j = range word.BitFields() {
+			bitField = newBitFieldLine(f, word, i, j)
+
+			if bitField.boolean {
+				fmt.Fprintf(buffer, "\tf.%s = word >> %d & 1 == 1\n",
+					bitField.path, bitField.shift,
+				)
+
+			} else {
+				fmt.Fprintf(buffer, "\tf.%s = %s(word >> %d & %#x)\n",
+					bitField.path, bitField.declaredType,
+					bitField.shift, bitField.mask,
+				)
+			}
+		}
+	}
+
+	buffer.WriteString("\n\treturn nil\n}\n")
+
+	return
+}
+
+type bitFieldLine struct {
+	path         string
+	declaredType string
+	boolean      bool
+	shift        uint
+	mask         uint64
+}
+
+func newBitFieldLine(f sourceFormat, word binary.WordDescriptor, i, j int) (
+	line bitFieldLine,
+) {
+	var (
+		bitField binary.BitFieldDescriptor
+	)
+
+	bitField = word.BitFields()[j]
+
+	line = bitFieldLine{
+		path:         f.definition.Words[i].Name + "." + bitField.Name(),
+		declaredType: f.declaredTypes[i][j],
+		boolean:      f.definition.Words[i].BitFields[j].Type == "bool",
+		shift: word.LengthInBits() -
+			bitField.BitOffset() - bitField.LengthInBits(),
+		mask: 1<<bitField.LengthInBits() - 1,
+	}
+
+	return
+}
+
+func wordBytes(word binary.WordDescriptor, expression string) (
+	expressions []string,
+) {
+	// Format an expression for each byte of a word, in the order of bytes,
+	// given the number of bits to shift and the index of the byte.
+
+	var (
+		i     int
+		index int
+		shift int
+	)
+
+	for i = 0; i < word.LengthInBytes(); i++ {
+		shift = 8 * (word.LengthInBytes() - 1 - i)
+
+		if word.ByteOrder() == binary.LittleEndian {
+			shift = 8 * i
+		}
+
+		index = word.ByteOffset() + i
+
+		expressions = append(expressions,
+			fmt.Sprintf(expression, shift, index),
+		)
+	}
+
+	return
+}
+
+func checkOptions(definition binary.FormatDefinition) (e error) {
+	// Refuse options that change encodings unknown to the generator,
+	// rather than generate methods that disagree with Marshal.
+
+	var (
+		bitField binary.BitFieldDefinition
+		option   string
+		word     binary.WordDefinition
+	)
+
+	for _, word = range definition.Words {
+		for _, option = range word.Options {
+			if !knownOption(option) {
+				e = fmt.Errorf("option %q of word %s is not supported",
+					option, word.Name,
+				)
+
+				return
+			}
+		}
+
+		for _, bitField = range word.BitFields {
+			for _, option = range bitField.Options {
+				if !knownOption(option) {
+					e = fmt.Errorf("option %q of bit field %s "+
+						"is not supported",
+						option, bitField.Name,
+					)
+
+					return
+				}
+			}
+		}
+	}
+
+	return
+}
+
+func knownOption(option string) bool {
+	var (
+		e      error
+		prefix string
+	)
+
+	switch option {
+	case "reserved", "bigendian", "littleendian", "msbfirst", "lsbfirst":
+		return true
+	}
+
+	for _, prefix = range []string{"min=", "max=", "enum="} {
+		if strings.HasPrefix(option, prefix) {
+			return true
+		}
+	}
+
+	// Offsets in tags of v1.1 (e.g. `bitfield:"4,28"`) are ignored.
+
+	_, e = strconv.ParseUint(option, 10, 64)
+
+	return e == nil
+}
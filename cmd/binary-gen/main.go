@@ -0,0 +1,142 @@
+package main
+
+// Command binary-gen generates methods MarshalBinary, UnmarshalBinary
+// and AppendBinary for format-structs, using shifts and masks
+// instead of reflection. Marshal and Unmarshal call them when present.
+//
+//	//go:generate go run github.com/encodingx/binary/cmd/binary-gen -type Format
+//
+// Given enumerated types registered with binary.RegisterEnumeration,
+// it generates constants and methods String, MarshalText and UnmarshalText
+// instead, and given flag types registered with binary.RegisterFlags,
+// constants and a method String.
+//
+//	//go:generate go run github.com/encodingx/binary/cmd/binary-gen -enum Protocol
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		e error
+	)
+
+	e = run(os.Args[1:])
+	if e != nil {
+		fmt.Fprintln(os.Stderr, "binary-gen:", e)
+
+		os.Exit(1)
+	}
+}
+
+func run(args []string) (e error) {
+	const (
+		generator        = "binary-gen"
+		enumOutputSuffix = "_enum.go"
+		outputSuffix     = "_binary.go"
+	)
+
+	var (
+		directory    string
+		enumNames    string
+		enumerations []sourceEnumeration
+		flags        *flag.FlagSet
+		formats      []sourceFormat
+		output       string
+		packageName  string
+		source       []byte
+		typeNames    string
+	)
+
+	flags = flag.NewFlagSet(generator, flag.ContinueOnError)
+
+	flags.StringVar(&typeNames, "type", "",
+		"comma-separated names of format-structs",
+	)
+
+	flags.StringVar(&enumNames, "enum", "",
+		"comma-separated names of enumerated types",
+	)
+
+	flags.StringVar(&output, "output", "",
+		"output file name (default <type>_binary.go in the package directory)",
+	)
+
+	e = flags.Parse(args)
+	if e != nil {
+		return
+	}
+
+	if (typeNames == "") == (enumNames == "") || flags.NArg() > 1 {
+		e = errors.New("usage: binary-gen " +
+			"-type Format[,Format...] | -enum Type[,Type...] " +
+			"[-output file] [package-directory]",
+		)
+
+		return
+	}
+
+	directory = flags.Arg(0)
+
+	if directory == "" {
+		directory = "."
+	}
+
+	if enumNames != "" {
+		packageName, enumerations, e = parseEnumerations(directory,
+			strings.Split(enumNames, ","),
+		)
+		if e != nil {
+			return
+		}
+
+		source, e = generateEnumerations(generator, packageName, enumerations)
+		if e != nil {
+			return
+		}
+
+		if output == "" {
+			output = filepath.Join(directory,
+				strings.ToLower(enumerations[0].typeName)+enumOutputSuffix,
+			)
+		}
+
+		e = os.WriteFile(output, source, 0o644)
+		if e != nil {
+			return
+		}
+
+		return
+	}
+
+	packageName, formats, e = parsePackage(directory,
+		strings.Split(typeNames, ","),
+	)
+	if e != nil {
+		return
+	}
+
+	source, e = generate(generator, packageName, formats)
+	if e != nil {
+		return
+	}
+
+	if output == "" {
+		output = filepath.Join(directory,
+			strings.ToLower(formats[0].definition.Name)+outputSuffix,
+		)
+	}
+
+	e = os.WriteFile(output, source, 0o644)
+	if e != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,550 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	const (
+		source = "" +
+			"package telemetry\n" +
+			"\n" +
+			"type Kind uint8\n" +
+			"\n" +
+			"type Telemetry struct {\n" +
+			"\tTelemetryWord0 `word:\"16,littleendian\"`\n" +
+			"\tCounter        TelemetryWord1 `word:\"64\"`\n" +
+			"}\n" +
+			"\n" +
+			"type TelemetryWord0 struct {\n" +
+			"\tVersion, Revision uint8 `bitfield:\"3,min=1\"`\n" +
+			"\tUrgent            bool  `bitfield:\"1\"`\n" +
+			"\tKind              Kind  `bitfield:\"9\"`\n" +
+			"}\n" +
+			"\n" +
+			"type TelemetryWord1 struct {\n" +
+			"\tSequence uint64 `bitfield:\"64\"`\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+		generated []byte
+		line      string
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "telemetry.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-type", "Telemetry", directory})
+
+	assert.NotNil(t, e)
+
+	assert.Contains(t,
+		e.Error(), "that has a bit field \"Kind\" "+
+			"of length 9 exceeding the size of type \"uint8\"",
+	)
+
+	e = os.WriteFile(
+		filepath.Join(directory, "telemetry.go"),
+		[]byte(
+			strings.Replace(source, "type Kind uint8", "type Kind uint16", 1),
+		),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-type", "Telemetry", directory})
+
+	assert.Nil(t, e)
+
+	generated, e = os.ReadFile(
+		filepath.Join(directory, "telemetry_binary.go"),
+	)
+
+	assert.Nil(t, e)
+
+	for _, line = range []string{
+		"// Code generated by binary-gen; DO NOT EDIT.",
+		"func (f *Telemetry) BinaryFormatLength() int {\n\treturn 10\n}",
+		"word |= uint64(f.TelemetryWord0.Version) & 0x7 << 13",
+		"word |= uint64(f.TelemetryWord0.Revision) & 0x7 << 10",
+		"if f.TelemetryWord0.Urgent {\n\t\tword |= 1 << 9\n\t}",
+		"bytes = append(bytes, byte(word>>0), byte(word>>8))",
+		"word = uint64(bytes[0])<<0 | uint64(bytes[1])<<8",
+		"f.TelemetryWord0.Kind = Kind(word >> 0 & 0x1ff)",
+		"f.Counter.Sequence = uint64(word >> 0 & 0xffffffffffffffff)",
+	} {
+		assert.Contains(t,
+			string(generated), line,
+		)
+	}
+}
+
+func TestRunEnum(t *testing.T) {
+	const (
+		source = "" +
+			"package telemetry\n" +
+			"\n" +
+			"import (\n" +
+			"\t\"github.com/encodingx/binary\"\n" +
+			")\n" +
+			"\n" +
+			"type Kind uint8\n" +
+			"\n" +
+			"func init() {\n" +
+			"\tbinary.RegisterEnumeration[Kind](\n" +
+			"\t\tbinary.EnumeratedValue{Name: \"Reading\", Value: 1},\n" +
+			"\t\tbinary.EnumeratedValue{\"Alarm\", 0x2},\n" +
+			"\t\tbinary.EnumeratedValue{Value: 2, Name: \"Alert\"},\n" +
+			"\t)\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+		generated []byte
+		line      string
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "telemetry.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-enum", "Kind", directory})
+
+	assert.Nil(t, e)
+
+	generated, e = os.ReadFile(
+		filepath.Join(directory, "kind_enum.go"),
+	)
+
+	assert.Nil(t, e)
+
+	for _, line = range []string{
+		"// Code generated by binary-gen; DO NOT EDIT.",
+		"\tKindReading Kind = 1\n\tKindAlarm   Kind = 2\n\tKindAlert   Kind = 2\n",
+		"\tcase KindReading:\n\t\treturn \"Reading\"\n" +
+			"\tcase KindAlarm:\n\t\treturn \"Alarm\"\n\t}\n",
+		"return fmt.Sprintf(\"Kind(%d)\", uint64(v))",
+		"\tcase KindReading, KindAlarm:\n\t\treturn []byte(v.String()), nil\n",
+		"\tcase \"Alert\":\n\t\t*v = KindAlert\n",
+		"value, e = strconv.ParseUint(string(text), 10, 8)",
+	} {
+		assert.Contains(t,
+			string(generated), line,
+		)
+	}
+
+	e = run([]string{"-enum", "Level", directory})
+
+	assert.EqualError(t,
+		e, "enumerated type Level is not an unsigned integer",
+	)
+
+	e = run([]string{"-type", "Telemetry", "-enum", "Kind", directory})
+
+	assert.NotNil(t, e)
+}
+
+func TestRunEnumGivenFlags(t *testing.T) {
+	const (
+		source = "" +
+			"package ip\n" +
+			"\n" +
+			"import (\n" +
+			"\t\"github.com/encodingx/binary\"\n" +
+			")\n" +
+			"\n" +
+			"type IPFlags uint8\n" +
+			"\n" +
+			"func init() {\n" +
+			"\tbinary.RegisterFlags[IPFlags](\n" +
+			"\t\tbinary.Flag{Name: \"DF\", Value: 0b010},\n" +
+			"\t\tbinary.Flag{Name: \"MF\", Value: 0b001},\n" +
+			"\t)\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+		generated []byte
+		line      string
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "ip.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-enum", "IPFlags", directory})
+
+	assert.Nil(t, e)
+
+	generated, e = os.ReadFile(
+		filepath.Join(directory, "ipflags_enum.go"),
+	)
+
+	assert.Nil(t, e)
+
+	for _, line = range []string{
+		"\t\"fmt\"\n\t\"strings\"\n)",
+		"\tIPFlagsDF IPFlags = 0x2\n\tIPFlagsMF IPFlags = 0x1\n",
+		"\tif v&IPFlagsDF != 0 {\n\t\tnames = append(names, \"DF\")\n\t}\n",
+		"\tv &^= IPFlagsDF | IPFlagsMF\n",
+		"\treturn strings.Join(names, \"|\")",
+	} {
+		assert.Contains(t,
+			string(generated), line,
+		)
+	}
+
+	e = os.WriteFile(
+		filepath.Join(directory, "ip.go"),
+		[]byte(
+			strings.Replace(source, "0b001", "0b101", 1),
+		),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-enum", "IPFlags", directory})
+
+	assert.EqualError(t,
+		e, "enumerated type IPFlags: flag 1: value 0x5 is not a single bit",
+	)
+}
+
+func TestRunShouldReturnErrorGivenUnsupportedOption(t *testing.T) {
+	const (
+		source = "" +
+			"package telemetry\n" +
+			"\n" +
+			"type Telemetry struct {\n" +
+			"\tTelemetryWord0 `word:\"8\"`\n" +
+			"}\n" +
+			"\n" +
+			"type TelemetryWord0 struct {\n" +
+			"\tKind uint8 `bitfield:\"8,frobnicated\"`\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "telemetry.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-type", "Telemetry", directory})
+
+	assert.EqualError(t,
+		e, "option \"frobnicated\" of bit field Kind is not supported",
+	)
+
+	e = run([]string{"-type", "Telemetry0", directory})
+
+	assert.EqualError(t,
+		e, "Telemetry0 is not a struct type declared in the package",
+	)
+}
+
+func TestRunShouldReturnErrorGivenBinaryMarshaler(t *testing.T) {
+	const (
+		source = "" +
+			"package telemetry\n" +
+			"\n" +
+			"type Telemetry struct {\n" +
+			"\tTelemetryWord0 `word:\"8\"`\n" +
+			"}\n" +
+			"\n" +
+			"type TelemetryWord0 struct {\n" +
+			"\tKind Kind `bitfield:\"8\"`\n" +
+			"}\n" +
+			"\n" +
+			"type Kind uint8\n" +
+			"\n" +
+			"func (k *Kind) MarshalBinary() ([]byte, error) {\n" +
+			"\treturn []byte{byte(*k)}, nil\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "telemetry.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-type", "Telemetry", directory})
+
+	assert.EqualError(t,
+		e, "word TelemetryWord0 of format Telemetry: "+
+			"bit field Kind delegates to MarshalBinary, "+
+			"which is left to reflection",
+	)
+}
+
+func TestRunShouldReturnErrorGivenNetworkType(t *testing.T) {
+	const (
+		source = "" +
+			"package telemetry\n" +
+			"\n" +
+			"import (\n" +
+			"\t\"net/netip\"\n" +
+			")\n" +
+			"\n" +
+			"type Telemetry struct {\n" +
+			"\tSource netip.Addr `word:\"32\"`\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "telemetry.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-type", "Telemetry", directory})
+
+	assert.EqualError(t,
+		e, "word Source of format Telemetry holds an address, port, "+
+			"time, duration or string, which is left to reflection",
+	)
+}
+
+func TestRunShouldReturnErrorGivenTimeType(t *testing.T) {
+	const (
+		source = "" +
+			"package telemetry\n" +
+			"\n" +
+			"import (\n" +
+			"\t\"time\"\n" +
+			")\n" +
+			"\n" +
+			"type Telemetry struct {\n" +
+			"\tTelemetryWord0 `word:\"32\"`\n" +
+			"}\n" +
+			"\n" +
+			"type TelemetryWord0 struct {\n" +
+			"\tTimeout time.Duration `bitfield:\"16,resolution=1ms\"`\n" +
+			"\tReserved uint16 `bitfield:\"16,reserved\"`\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "telemetry.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-type", "Telemetry", directory})
+
+	assert.EqualError(t,
+		e, "word TelemetryWord0 of format Telemetry: "+
+			"bit field Timeout holds an address, port, "+
+			"time, duration or string, which is left to reflection",
+	)
+}
+
+func TestRunShouldReturnErrorGivenSignedBitField(t *testing.T) {
+	const (
+		source = "" +
+			"package telemetry\n" +
+			"\n" +
+			"type Telemetry struct {\n" +
+			"\tTelemetryWord0 `word:\"8\"`\n" +
+			"}\n" +
+			"\n" +
+			"type TelemetryWord0 struct {\n" +
+			"\tOffset int8 `bitfield:\"8,signmagnitude\"`\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "telemetry.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-type", "Telemetry", directory})
+
+	assert.EqualError(t,
+		e, "word TelemetryWord0 of format Telemetry: "+
+			"bit field Offset is of unsupported type int8",
+	)
+}
+
+func TestRunShouldReturnErrorGivenString(t *testing.T) {
+	const (
+		source = "" +
+			"package telemetry\n" +
+			"\n" +
+			"type Telemetry struct {\n" +
+			"\tName string `word:\"64\"`\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "telemetry.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-type", "Telemetry", directory})
+
+	assert.EqualError(t,
+		e, "word Name of format Telemetry holds an address, port, "+
+			"time, duration or string, which is left to reflection",
+	)
+}
+
+func TestRunShouldReturnErrorGivenVarint(t *testing.T) {
+	const (
+		source = "" +
+			"package telemetry\n" +
+			"\n" +
+			"type Telemetry struct {\n" +
+			"\tLength uint64 `word:\"64,uleb128\"`\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "telemetry.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-type", "Telemetry", directory})
+
+	assert.EqualError(t,
+		e, "word Length of format Telemetry holds a variable-length "+
+			"integer, which is left to reflection",
+	)
+}
+
+func TestRunShouldReturnErrorGivenBitStream(t *testing.T) {
+	const (
+		source = "" +
+			"package video\n" +
+			"\n" +
+			"type Header struct {\n" +
+			"\tCodeNum uint8 `bitfield:\"4,ue\"`\n" +
+			"}\n" +
+			"\n" +
+			"type SequenceParameterSet struct {\n" +
+			"\tHeader `word:\"0,bitstream\"`\n" +
+			"}\n"
+	)
+
+	var (
+		directory string
+		e         error
+	)
+
+	directory = t.TempDir()
+
+	e = os.WriteFile(
+		filepath.Join(directory, "video.go"),
+		[]byte(source),
+		0o644,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"-type", "SequenceParameterSet", directory})
+
+	assert.EqualError(t,
+		e, "word Header of format SequenceParameterSet is a bit stream, "+
+			"which is left to reflection",
+	)
+}
@@ -0,0 +1,495 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/encodingx/binary"
+)
+
+type sourceFormat struct {
+	definition binary.FormatDefinition
+
+	// Types of bit fields as declared, by word,
+	// which differ from those in the definition if they are named types.
+
+	declaredTypes [][]string
+}
+
+var (
+	underlyingTypes = map[string]string{
+		"bool":   "bool",
+		"byte":   "uint8",
+		"uint":   "uint",
+		"uint8":  "uint8",
+		"uint16": "uint16",
+		"uint32": "uint32",
+		"uint64": "uint64",
+	}
+
+	typedTypes = map[string]bool{
+		"net.HardwareAddr": true,
+		"netip.Addr":       true,
+		"netip.AddrPort":   true,
+		"time.Duration":    true,
+		"time.Time":        true,
+		"string":           true,
+	}
+
+	varintEncodings = map[string]bool{
+		"uleb128": true,
+		"sleb128": true,
+		"zigzag":  true,
+		"quic":    true,
+		"mqtt":    true,
+	}
+)
+
+func parsePackage(directory string, typeNames []string) (
+	packageName string, formats []sourceFormat, e error,
+) {
+	// Find type declarations in the package without type-checking it,
+	// which would fail before methods are first generated
+	// if the package already calls them.
+
+	var (
+		f          sourceFormat
+		file       *ast.File
+		marshalers = make(map[string]bool)
+		name       string
+		pkg        *ast.Package
+		typeExprs  = make(map[string]ast.Expr)
+	)
+
+	packageName, pkg, e = loadPackage(directory)
+	if e != nil {
+		return
+	}
+
+	for _, file = range pkg.Files {
+		ast.Inspect(file,
+			func(node ast.Node) bool {
+				var (
+					function *ast.FuncDecl
+					spec     *ast.TypeSpec
+					ok       bool
+				)
+
+				spec, ok = node.(*ast.TypeSpec)
+				if ok {
+					typeExprs[spec.Name.Name] = spec.Type
+				}
+
+				function, ok = node.(*ast.FuncDecl)
+				if ok && function.Recv != nil &&
+					function.Name.Name == "MarshalBinary" {
+					marshalers[receiverTypeName(function)] = true
+				}
+
+				return true
+			},
+		)
+	}
+
+	for _, name = range typeNames {
+		f, e = parseFormat(name, typeExprs, marshalers)
+		if e != nil {
+			return
+		}
+
+		formats = append(formats, f)
+	}
+
+	return
+}
+
+func loadPackage(directory string) (
+	packageName string, pkg *ast.Package, e error,
+) {
+	var (
+		fileSet  = token.NewFileSet()
+		packages map[string]*ast.Package
+	)
+
+	packages, e = parser.ParseDir(fileSet, directory,
+		func(info fs.FileInfo) bool {
+			return !strings.HasSuffix(info.Name(), "_test.go")
+		},
+		0,
+	)
+	if e != nil {
+		return
+	}
+
+	if len(packages) != 1 {
+		e = fmt.Errorf("found %d packages in %s, expected 1",
+			len(packages), directory,
+		)
+
+		return
+	}
+
+	for packageName, pkg = range packages {
+		return
+	}
+
+	return
+}
+
+func parseFormat(name string, typeExprs map[string]ast.Expr,
+	marshalers map[string]bool,
+) (
+	f sourceFormat, e error,
+) {
+	var (
+		declaredTypes []string
+		field         *ast.Field
+		formatStruct  *ast.StructType
+		word          binary.WordDefinition
+		wordStruct    *ast.StructType
+	)
+
+	f.definition.Name = name
+
+	formatStruct, e = lookUpStruct(name, typeExprs)
+	if e != nil {
+		return
+	}
+
+	for _, field = range formatStruct.Fields.List {
+		word = binary.WordDefinition{
+			Name: fieldName(field),
+		}
+
+		if len(field.Names) > 1 {
+			e = fmt.Errorf("words %s of format %s should be declared "+
+				"one per field",
+				typeName(field.Type), name,
+			)
+
+			return
+		}
+
+		word.Length, word.Options, e = parseTag(field, "word")
+		if e != nil {
+			e = fmt.Errorf("word %s of format %s: %w", word.Name, name, e)
+
+			return
+		}
+
+		if isVarintWord(word.Options) {
+			e = fmt.Errorf("word %s of format %s holds a variable-length "+
+				"integer, which is left to reflection",
+				word.Name, name,
+			)
+
+			return
+		}
+
+		if isBitStreamWord(word.Options) {
+			e = fmt.Errorf("word %s of format %s is a bit stream, "+
+				"which is left to reflection",
+				word.Name, name,
+			)
+
+			return
+		}
+
+		if marshalers[typeName(field.Type)] {
+			e = fmt.Errorf("word %s of format %s delegates to MarshalBinary, "+
+				"which is left to reflection",
+				word.Name, name,
+			)
+
+			return
+		}
+
+		if isTypedType(field.Type) {
+			e = fmt.Errorf("word %s of format %s holds an address, port, "+
+				"time, duration or string, which is left to reflection",
+				word.Name, name,
+			)
+
+			return
+		}
+
+		wordStruct, e = lookUpStruct(
+			typeName(field.Type),
+			typeExprs,
+		)
+		if e != nil {
+			e = fmt.Errorf("word %s of format %s: %w", word.Name, name, e)
+
+			return
+		}
+
+		word.BitFields, declaredTypes, e = parseBitFields(wordStruct,
+			typeExprs, marshalers,
+		)
+		if e != nil {
+			e = fmt.Errorf("word %s of format %s: %w", word.Name, name, e)
+
+			return
+		}
+
+		f.definition.Words = append(f.definition.Words, word)
+
+		f.declaredTypes = append(f.declaredTypes, declaredTypes)
+	}
+
+	return
+}
+
+func parseBitFields(wordStruct *ast.StructType,
+	typeExprs map[string]ast.Expr, marshalers map[string]bool,
+) (
+	bitFields []binary.BitFieldDefinition, declaredTypes []string, e error,
+) {
+	var (
+		bitField     binary.BitFieldDefinition
+		declaredType string
+		field        *ast.Field
+		ident        *ast.Ident
+		ok           bool
+	)
+
+	for _, field = range wordStruct.Fields.List {
+		if len(field.Names) == 0 {
+			e = fmt.Errorf("embedded bit field %s is not supported",
+				typeName(field.Type),
+			)
+
+			return
+		}
+
+		bitField = binary.BitFieldDefinition{}
+
+		bitField.Length, bitField.Options, e = parseTag(field, "bitfield")
+		if e != nil {
+			e = fmt.Errorf("bit field %s: %w", field.Names[0].Name, e)
+
+			return
+		}
+
+		declaredType = typeName(field.Type)
+
+		if marshalers[declaredType] {
+			e = fmt.Errorf("bit field %s delegates to MarshalBinary, "+
+				"which is left to reflection",
+				field.Names[0].Name,
+			)
+
+			return
+		}
+
+		if isTypedType(field.Type) {
+			e = fmt.Errorf("bit field %s holds an address, port, "+
+				"time, duration or string, which is left to reflection",
+				field.Names[0].Name,
+			)
+
+			return
+		}
+
+		bitField.Type, ok = underlyingType(declaredType, typeExprs)
+		if !ok {
+			e = fmt.Errorf("bit field %s is of unsupported type %s",
+				field.Names[0].Name, declaredType,
+			)
+
+			return
+		}
+
+		for _, ident = range field.Names {
+			bitField.Name = ident.Name
+
+			bitFields = append(bitFields, bitField)
+
+			declaredTypes = append(declaredTypes, declaredType)
+		}
+	}
+
+	return
+}
+
+func lookUpStruct(name string, typeExprs map[string]ast.Expr) (
+	structType *ast.StructType, e error,
+) {
+	var (
+		ok bool
+	)
+
+	structType, ok = typeExprs[name].(*ast.StructType)
+	if !ok {
+		e = fmt.Errorf("%s is not a struct type declared in the package",
+			name,
+		)
+
+		return
+	}
+
+	return
+}
+
+func underlyingType(name string, typeExprs map[string]ast.Expr) (
+	underlying string, ok bool,
+) {
+	// Follow type definitions (e.g. type Protocol uint8)
+	// down to a type supported for bit fields.
+
+	var (
+		i     int
+		ident *ast.Ident
+	)
+
+	for i = 0; i < len(typeExprs)+1; i++ {
+		underlying, ok = underlyingTypes[name]
+		if ok {
+			return
+		}
+
+		ident, ok = typeExprs[name].(*ast.Ident)
+		if !ok {
+			return
+		}
+
+		name = ident.Name
+	}
+
+	ok = false
+
+	return
+}
+
+func parseTag(field *ast.Field, key string) (
+	length uint, options []string, e error,
+) {
+	const (
+		lengthBase      = 10
+		lengthBitSize   = 64
+		optionSeparator = ","
+	)
+
+	var (
+		elements []string
+		length64 uint64
+		tag      string
+	)
+
+	if field.Tag != nil {
+		tag, e = strconv.Unquote(field.Tag.Value)
+		if e != nil {
+			return
+		}
+	}
+
+	elements = strings.Split(
+		reflect.StructTag(tag).Get(key),
+		optionSeparator,
+	)
+
+	length64, e = strconv.ParseUint(elements[0], lengthBase, lengthBitSize)
+	if e != nil {
+		e = fmt.Errorf("malformed or missing tag %q", key)
+
+		return
+	}
+
+	length = uint(length64)
+
+	if len(elements) > 1 {
+		options = elements[1:]
+	}
+
+	return
+}
+
+func receiverTypeName(function *ast.FuncDecl) string {
+	var (
+		expr ast.Expr
+		star *ast.StarExpr
+		ok   bool
+	)
+
+	expr = function.Recv.List[0].Type
+
+	star, ok = expr.(*ast.StarExpr)
+	if ok {
+		expr = star.X
+	}
+
+	return typeName(expr)
+}
+
+func isVarintWord(options []string) bool {
+	// Variable-length integers move the words following them,
+	// which generated methods place at fixed offsets.
+
+	var (
+		option string
+	)
+
+	for _, option = range options {
+		if varintEncodings[option] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isBitStreamWord(options []string) bool {
+	// Bit streams place bit fields at offsets depending on their values.
+
+	const (
+		bitStreamOption = "bitstream"
+	)
+
+	var (
+		option string
+	)
+
+	for _, option = range options {
+		if option == bitStreamOption {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTypedType(expr ast.Expr) bool {
+	// Addresses, ports, arrays of bytes, times, durations and strings
+	// are converted by package binary, not by generated methods.
+
+	var (
+		array *ast.ArrayType
+		ok    bool
+	)
+
+	array, ok = expr.(*ast.ArrayType)
+	if ok {
+		return array.Len != nil
+	}
+
+	return typedTypes[typeName(expr)]
+}
+
+func fieldName(field *ast.Field) string {
+	if len(field.Names) > 0 {
+		return field.Names[0].Name
+	}
+
+	return typeName(field.Type)
+}
+
+func typeName(expr ast.Expr) string {
+	return types.ExprString(expr)
+}
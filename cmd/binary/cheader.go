@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+
+	"github.com/encodingx/binary/internal/csource"
+)
+
+func runCHeader(args []string, stdout io.Writer) (e error) {
+	const (
+		generator = "binary cheader"
+	)
+
+	var (
+		flags  *flag.FlagSet
+		format recordFormat
+		schema string
+		source []byte
+	)
+
+	flags = flag.NewFlagSet("cheader", flag.ContinueOnError)
+
+	flags.SetOutput(io.Discard)
+
+	flags.StringVar(&schema, "schema", "",
+		"schema file defining the format",
+	)
+
+	e = flags.Parse(args)
+	if e != nil {
+		return
+	}
+
+	if flags.NArg() != 1 {
+		e = errors.New("usage: binary cheader [-schema file] <format>")
+
+		return
+	}
+
+	format, e = lookUpFormat(
+		flags.Arg(0), schema,
+	)
+	if e != nil {
+		return
+	}
+
+	source, e = csource.Generate(generator,
+		format.describe(),
+	)
+	if e != nil {
+		return
+	}
+
+	_, e = stdout.Write(source)
+	if e != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+)
+
+func runDecode(args []string, stdout io.Writer) (e error) {
+	const (
+		outputJSON = "json"
+		outputText = "text"
+	)
+
+	var (
+		count    int
+		decoded  interface{}
+		dump     string
+		encoder  *json.Encoder
+		flags    *flag.FlagSet
+		format   recordFormat
+		hexInput bool
+		input    []byte
+		length   int
+		offset   int
+		output   string
+		record   int
+		schema   string
+	)
+
+	flags = flag.NewFlagSet("decode", flag.ContinueOnError)
+
+	flags.SetOutput(io.Discard)
+
+	flags.BoolVar(&hexInput, "hex", false,
+		"read input as hexadecimal text instead of raw bytes",
+	)
+
+	flags.IntVar(&offset, "offset", 0,
+		"number of bytes of input to skip before the first record",
+	)
+
+	flags.IntVar(&count, "count", 0,
+		"maximum number of records to decode, or zero for all",
+	)
+
+	flags.StringVar(&output, "output", outputText,
+		"output as annotated \"text\" or \"json\"",
+	)
+
+	flags.StringVar(&schema, "schema", "",
+		"schema file defining the format",
+	)
+
+	e = flags.Parse(args)
+	if e != nil {
+		return
+	}
+
+	if flags.NArg() < 1 || flags.NArg() > 2 ||
+		output != outputText && output != outputJSON {
+		e = errors.New("usage: binary decode [-hex] [-offset bytes] " +
+			"[-count records] [-output text|json] [-schema file] " +
+			"<format> [file]",
+		)
+
+		return
+	}
+
+	format, e = lookUpFormat(
+		flags.Arg(0), schema,
+	)
+	if e != nil {
+		return
+	}
+
+	input, e = readInput(
+		flags.Arg(1),
+	)
+	if e != nil {
+		return
+	}
+
+	if hexInput {
+		input, e = decodeHex(input)
+		if e != nil {
+			return
+		}
+	}
+
+	if offset < 0 || offset > len(input) {
+		e = fmt.Errorf("offset %d is outside input of %d byte(s)",
+			offset, len(input),
+		)
+
+		return
+	}
+
+	length = format.describe().LengthInBytes()
+
+	encoder = json.NewEncoder(stdout)
+
+	encoder.SetIndent("", "  ")
+
+	for record = 0; offset < len(input); record++ {
+		if count > 0 && record == count {
+			break
+		}
+
+		if len(input)-offset < length {
+			e = fmt.Errorf("record %d at offset %d is truncated "+
+				"to %d of %d byte(s)",
+				record, offset, len(input)-offset, length,
+			)
+
+			return
+		}
+
+		switch output {
+		case outputText:
+			dump, e = format.dump(input[offset : offset+length])
+			if e != nil {
+				return
+			}
+
+			fmt.Fprintf(stdout, "# record %d at offset %d\n%s",
+				record, offset, dump,
+			)
+
+		case outputJSON:
+			decoded, e = format.decode(input[offset : offset+length])
+			if e != nil {
+				return
+			}
+
+			e = encoder.Encode(decoded)
+			if e != nil {
+				return
+			}
+		}
+
+		offset += length
+	}
+
+	return
+}
+
+func runEncode(args []string, stdout io.Writer) (e error) {
+	var (
+		bytes     []byte
+		decoder   *json.Decoder
+		file      io.ReadCloser
+		flags     *flag.FlagSet
+		format    recordFormat
+		hexOutput bool
+		schema    string
+	)
+
+	flags = flag.NewFlagSet("encode", flag.ContinueOnError)
+
+	flags.SetOutput(io.Discard)
+
+	flags.BoolVar(&hexOutput, "hex", false,
+		"write output as hexadecimal text instead of raw bytes",
+	)
+
+	flags.StringVar(&schema, "schema", "",
+		"schema file defining the format",
+	)
+
+	e = flags.Parse(args)
+	if e != nil {
+		return
+	}
+
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		e = errors.New("usage: binary encode [-hex] [-schema file] " +
+			"<format> [file]",
+		)
+
+		return
+	}
+
+	format, e = lookUpFormat(
+		flags.Arg(0), schema,
+	)
+	if e != nil {
+		return
+	}
+
+	file, e = openInput(
+		flags.Arg(1),
+	)
+	if e != nil {
+		return
+	}
+
+	defer file.Close()
+
+	// Encode a stream of JSON objects, one record each,
+	// keeping numbers exact for bit fields of up to 64 bits.
+
+	decoder = json.NewDecoder(file)
+
+	decoder.DisallowUnknownFields()
+
+	decoder.UseNumber()
+
+	for decoder.More() {
+		bytes, e = format.encode(decoder)
+		if e != nil {
+			return
+		}
+
+		if hexOutput {
+			_, e = fmt.Fprintln(stdout,
+				hex.EncodeToString(bytes),
+			)
+
+		} else {
+			_, e = stdout.Write(bytes)
+		}
+
+		if e != nil {
+			return
+		}
+	}
+
+	return
+}
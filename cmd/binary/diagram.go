@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+
+	"github.com/encodingx/binary"
+)
+
+func runDiagram(args []string, stdout io.Writer) (e error) {
+	var (
+		flags  *flag.FlagSet
+		format recordFormat
+		schema string
+		width  uint
+	)
+
+	flags = flag.NewFlagSet("diagram", flag.ContinueOnError)
+
+	flags.SetOutput(io.Discard)
+
+	flags.UintVar(&width, "width", binary.DiagramRowWidth,
+		"number of bits in each row of the diagram",
+	)
+
+	flags.StringVar(&schema, "schema", "",
+		"schema file defining the format",
+	)
+
+	e = flags.Parse(args)
+	if e != nil {
+		return
+	}
+
+	if flags.NArg() != 1 {
+		e = errors.New("usage: binary diagram [-width bits] " +
+			"[-schema file] <format>",
+		)
+
+		return
+	}
+
+	format, e = lookUpFormat(
+		flags.Arg(0), schema,
+	)
+	if e != nil {
+		return
+	}
+
+	_, e = io.WriteString(stdout,
+		format.describe().Diagram(width),
+	)
+	if e != nil {
+		return
+	}
+
+	return
+}
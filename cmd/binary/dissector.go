@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/encodingx/binary/internal/luasource"
+)
+
+func runDissector(args []string, stdout io.Writer) (e error) {
+	const (
+		generator = "binary dissector"
+	)
+
+	var (
+		flags     *flag.FlagSet
+		format    recordFormat
+		port      uint
+		protoName string
+		schema    string
+		source    []byte
+		transport string
+	)
+
+	flags = flag.NewFlagSet("dissector", flag.ContinueOnError)
+
+	flags.SetOutput(io.Discard)
+
+	flags.StringVar(&schema, "schema", "",
+		"schema file defining the format",
+	)
+
+	flags.StringVar(&protoName, "name", "",
+		"name of the protocol, by default that of the format in lower case",
+	)
+
+	flags.UintVar(&port, "port", 0,
+		"port on which to register the dissector, if any",
+	)
+
+	flags.StringVar(&transport, "transport", "udp",
+		"transport of the port, udp or tcp",
+	)
+
+	e = flags.Parse(args)
+	if e != nil {
+		return
+	}
+
+	if flags.NArg() != 1 {
+		e = errors.New("usage: binary dissector [-schema file] [-name name] " +
+			"[-port number] [-transport udp|tcp] <format>",
+		)
+
+		return
+	}
+
+	if transport != "udp" && transport != "tcp" {
+		e = fmt.Errorf("unknown transport %q", transport)
+
+		return
+	}
+
+	format, e = lookUpFormat(
+		flags.Arg(0), schema,
+	)
+	if e != nil {
+		return
+	}
+
+	source, e = luasource.Generate(generator,
+		format.describe(), protoName, transport, port,
+	)
+	if e != nil {
+		return
+	}
+
+	_, e = stdout.Write(source)
+	if e != nil {
+		return
+	}
+
+	return
+}
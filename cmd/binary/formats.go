@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/encodingx/binary"
+	"github.com/encodingx/binary/pkg/rfc791"
+)
+
+var (
+	// Format-structs known to the command by name,
+	// each given as a pointer to a zero value
+
+	formats = map[string]interface{}{
+		"rfc791": &rfc791.RFC791InternetHeaderFormatWithoutOptions{},
+	}
+)
+
+type recordFormat interface {
+	// Formats are either format-structs known to the command
+	// or dynamic formats defined in schema files.
+
+	describe() binary.FormatDescriptor
+	dump(bytes []byte) (string, error)
+	decode(bytes []byte) (interface{}, error)
+	encode(decoder *json.Decoder) ([]byte, error)
+}
+
+type structFormat struct {
+	reflection reflect.Type
+	descriptor binary.FormatDescriptor
+}
+
+type dynamicFormat struct {
+	format binary.DynamicFormat
+}
+
+func lookUpFormat(name, schemaPath string) (format recordFormat, e error) {
+	var (
+		definition  binary.FormatDefinition
+		definitions []binary.FormatDefinition
+		pointer     interface{}
+		ok          bool
+		schema      []byte
+		dynamic     dynamicFormat
+		static      structFormat
+	)
+
+	if schemaPath != "" {
+		schema, e = readInput(schemaPath)
+		if e != nil {
+			return
+		}
+
+		definitions, e = binary.ParseSchema(schema)
+		if e != nil {
+			return
+		}
+
+		for _, definition = range definitions {
+			if definition.Name == name {
+				dynamic.format, e = binary.NewDynamicFormat(definition)
+				if e != nil {
+					return
+				}
+
+				format = dynamic
+
+				return
+			}
+		}
+
+		e = fmt.Errorf("unknown format %q in schema %s", name, schemaPath)
+
+		return
+	}
+
+	pointer, ok = formats[name]
+	if !ok {
+		e = fmt.Errorf("unknown format %q", name)
+
+		return
+	}
+
+	static.reflection = reflect.TypeOf(pointer).Elem()
+
+	static.descriptor, e = binary.Describe(pointer)
+	if e != nil {
+		return
+	}
+
+	format = static
+
+	return
+}
+
+func (f structFormat) describe() binary.FormatDescriptor {
+	return f.descriptor
+}
+
+func (f structFormat) dump(bytes []byte) (string, error) {
+	return binary.DumpBytes(bytes,
+		f.new(),
+	)
+}
+
+func (f structFormat) decode(bytes []byte) (pointer interface{}, e error) {
+	pointer = f.new()
+
+	e = binary.Unmarshal(bytes, pointer)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (f structFormat) encode(decoder *json.Decoder) (bytes []byte, e error) {
+	var (
+		pointer interface{}
+	)
+
+	pointer = f.new()
+
+	e = decoder.Decode(pointer)
+	if e != nil {
+		return
+	}
+
+	bytes, e = binary.Marshal(pointer)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (f structFormat) new() interface{} {
+	// Return a fresh variable so that callers may decode into it.
+
+	return reflect.New(f.reflection).Interface()
+}
+
+func (f dynamicFormat) describe() binary.FormatDescriptor {
+	return f.format.Describe()
+}
+
+func (f dynamicFormat) dump(bytes []byte) (string, error) {
+	return f.format.DumpBytes(bytes)
+}
+
+func (f dynamicFormat) decode(bytes []byte) (decoded interface{}, e error) {
+	// Print enumerated values by name, as do dumps,
+	// since encode accepts names in their place.
+
+	var (
+		bitField   binary.BitFieldDescriptor
+		enumerated binary.EnumeratedValue
+		i          int
+		name       string
+		names      = make(map[string]map[uint64]string)
+		ok         bool
+		record     binary.Record
+		value      reflect.Value
+		word       binary.WordDescriptor
+	)
+
+	record, e = f.format.UnmarshalRecord(bytes)
+	if e != nil {
+		return
+	}
+
+	for _, word = range f.format.Describe().Words() {
+		for _, bitField = range word.BitFields() {
+			for _, enumerated = range bitField.Enumeration() {
+				if names[bitField.Name()] == nil {
+					names[bitField.Name()] = make(map[uint64]string)
+				}
+
+				names[bitField.Name()][enumerated.Value] = enumerated.Name
+			}
+		}
+	}
+
+	for i = range record {
+		value = reflect.ValueOf(record[i].Value)
+
+		switch value.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+			reflect.Uint64:
+			name, ok = names[record[i].Name][value.Uint()]
+			if ok {
+				record[i].Value = name
+			}
+		}
+	}
+
+	decoded = record
+
+	return
+}
+
+func (f dynamicFormat) encode(decoder *json.Decoder) (bytes []byte, e error) {
+	var (
+		values map[string]interface{}
+	)
+
+	e = decoder.Decode(&values)
+	if e != nil {
+		return
+	}
+
+	bytes, e = f.format.MarshalMap(values)
+	if e != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/encodingx/binary"
+	"github.com/encodingx/binary/internal/diagrams"
+	"github.com/encodingx/binary/internal/gosource"
+)
+
+type typeHints map[string]string
+
+func (h typeHints) String() string {
+	return fmt.Sprint(map[string]string(h))
+}
+
+func (h typeHints) Set(value string) (e error) {
+	var (
+		i int
+	)
+
+	i = strings.IndexByte(value, '=')
+	if i < 0 {
+		e = fmt.Errorf("type hint %q is not of the form Name=type", value)
+
+		return
+	}
+
+	h[value[:i]] = value[i+1:]
+
+	return
+}
+
+func runFromDiagram(args []string, stdout io.Writer) (e error) {
+	const (
+		generator = "binary fromdiagram"
+	)
+
+	var (
+		diagram     []byte
+		flags       *flag.FlagSet
+		format      binary.FormatDefinition
+		hints       = make(typeHints)
+		name        string
+		packageName string
+		source      []byte
+	)
+
+	flags = flag.NewFlagSet("fromdiagram", flag.ContinueOnError)
+
+	flags.SetOutput(io.Discard)
+
+	flags.StringVar(&name, "name", "",
+		"name of the format-struct to generate",
+	)
+
+	flags.StringVar(&packageName, "package", "main",
+		"name of the package of the generated source",
+	)
+
+	flags.Var(hints, "type",
+		"type of a bit field, as Name=type (repeatable)",
+	)
+
+	e = flags.Parse(args)
+	if e != nil {
+		return
+	}
+
+	if name == "" || flags.NArg() > 1 {
+		e = errors.New("usage: binary fromdiagram -name Format " +
+			"[-package name] [-type Name=type ...] [diagram-file]",
+		)
+
+		return
+	}
+
+	diagram, e = readInput(
+		flags.Arg(0),
+	)
+	if e != nil {
+		return
+	}
+
+	format, e = diagrams.Parse(name,
+		string(diagram), hints,
+	)
+	if e != nil {
+		return
+	}
+
+	source, e = gosource.Generate(generator, packageName, format)
+	if e != nil {
+		return
+	}
+
+	_, e = stdout.Write(source)
+	if e != nil {
+		return
+	}
+
+	return
+}
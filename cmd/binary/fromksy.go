@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+
+	"github.com/encodingx/binary"
+	"github.com/encodingx/binary/internal/gosource"
+	"github.com/encodingx/binary/internal/kaitai"
+)
+
+func runFromKSY(args []string, stdout io.Writer) (e error) {
+	const (
+		generator = "binary fromksy"
+	)
+
+	var (
+		flags       *flag.FlagSet
+		format      binary.FormatDefinition
+		ksyBytes    []byte
+		packageName string
+		source      []byte
+	)
+
+	flags = flag.NewFlagSet("fromksy", flag.ContinueOnError)
+
+	flags.SetOutput(io.Discard)
+
+	flags.StringVar(&packageName, "package", "main",
+		"name of the package of the generated source",
+	)
+
+	e = flags.Parse(args)
+	if e != nil {
+		return
+	}
+
+	if flags.NArg() > 1 {
+		e = errors.New("usage: binary fromksy [-package name] [ksy-file]")
+
+		return
+	}
+
+	ksyBytes, e = readInput(
+		flags.Arg(0),
+	)
+	if e != nil {
+		return
+	}
+
+	format, e = kaitai.Import(ksyBytes)
+	if e != nil {
+		return
+	}
+
+	source, e = gosource.Generate(generator, packageName, format)
+	if e != nil {
+		return
+	}
+
+	_, e = stdout.Write(source)
+	if e != nil {
+		return
+	}
+
+	return
+}
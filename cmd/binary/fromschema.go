@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+
+	"github.com/encodingx/binary"
+	"github.com/encodingx/binary/internal/gosource"
+)
+
+func runFromSchema(args []string, stdout io.Writer) (e error) {
+	const (
+		generator = "binary fromschema"
+	)
+
+	var (
+		definitions []binary.FormatDefinition
+		flags       *flag.FlagSet
+		packageName string
+		schema      []byte
+		source      []byte
+	)
+
+	flags = flag.NewFlagSet("fromschema", flag.ContinueOnError)
+
+	flags.SetOutput(io.Discard)
+
+	flags.StringVar(&packageName, "package", "main",
+		"name of the package of the generated source",
+	)
+
+	e = flags.Parse(args)
+	if e != nil {
+		return
+	}
+
+	if flags.NArg() > 1 {
+		e = errors.New("usage: binary fromschema [-package name] " +
+			"[schema-file]",
+		)
+
+		return
+	}
+
+	schema, e = readInput(
+		flags.Arg(0),
+	)
+	if e != nil {
+		return
+	}
+
+	definitions, e = binary.ParseSchema(schema)
+	if e != nil {
+		return
+	}
+
+	source, e = gosource.Generate(generator, packageName, definitions...)
+	if e != nil {
+		return
+	}
+
+	_, e = stdout.Write(source)
+	if e != nil {
+		return
+	}
+
+	return
+}
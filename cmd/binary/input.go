@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+func readInput(path string) (input []byte, e error) {
+	var (
+		file io.ReadCloser
+	)
+
+	file, e = openInput(path)
+	if e != nil {
+		return
+	}
+
+	defer file.Close()
+
+	input, e = io.ReadAll(file)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func openInput(path string) (file io.ReadCloser, e error) {
+	// Open the named file, or standard input if none is named.
+
+	if path == "" || path == "-" {
+		file = io.NopCloser(os.Stdin)
+
+		return
+	}
+
+	file, e = os.Open(path)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func decodeHex(text []byte) (bytes []byte, e error) {
+	// Decode hexadecimal text, ignoring white space between digits.
+
+	bytes, e = hex.DecodeString(
+		strings.Map(
+			func(r rune) rune {
+				if unicode.IsSpace(r) {
+					return -1
+				}
+
+				return r
+			},
+			string(text),
+		),
+	)
+	if e != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(args []string, stdout io.Writer) error
+}
+
+var (
+	commands = []command{
+		{"diagram", "render an RFC-style bit diagram of a format", runDiagram},
+		{"decode", "decode binary records into annotated text or JSON",
+			runDecode,
+		},
+		{"encode", "encode JSON objects into binary records", runEncode},
+		{"fromdiagram", "generate Go format-structs from a bit diagram",
+			runFromDiagram,
+		},
+		{"fromschema", "generate Go format-structs from a schema file",
+			runFromSchema,
+		},
+		{"fromksy", "generate Go format-structs from a Kaitai Struct file",
+			runFromKSY,
+		},
+		{"toksy", "export a format as a Kaitai Struct file", runToKSY},
+		{"cheader", "generate a C header with accessors for a format",
+			runCHeader,
+		},
+		{"dissector", "generate a Wireshark dissector in Lua for a format",
+			runDissector,
+		},
+	}
+)
+
+func main() {
+	var (
+		e error
+	)
+
+	e = run(os.Args[1:], os.Stdout)
+	if e != nil {
+		fmt.Fprintln(os.Stderr, e)
+
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) (e error) {
+	var (
+		c command
+	)
+
+	if len(args) == 0 {
+		e = errors.New(usage())
+
+		return
+	}
+
+	for _, c = range commands {
+		if c.name == args[0] {
+			e = c.run(args[1:], stdout)
+
+			return
+		}
+	}
+
+	e = fmt.Errorf("unknown command %q\n%s", args[0], usage())
+
+	return
+}
+
+func usage() string {
+	var (
+		builder strings.Builder
+		c       command
+		name    string
+		names   []string
+	)
+
+	builder.WriteString("usage: binary <command> [arguments]\n\ncommands:\n")
+
+	for _, c = range commands {
+		fmt.Fprintf(&builder, "  %-12s %s\n", c.name, c.description)
+	}
+
+	builder.WriteString("\nformats:\n")
+
+	for name = range formats {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name = range names {
+		fmt.Fprintf(&builder, "  %s\n", name)
+	}
+
+	return builder.String()
+}
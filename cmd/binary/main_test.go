@@ -0,0 +1,547 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/encodingx/binary"
+	"github.com/encodingx/binary/pkg/rfc791"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDiagram(t *testing.T) {
+	var (
+		diagram string
+		e       error
+		stdout  bytes.Buffer
+	)
+
+	diagram, e = binary.Diagram(
+		&rfc791.RFC791InternetHeaderFormatWithoutOptions{}, 16,
+	)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"diagram", "-width", "16", "rfc791"}, &stdout)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		diagram, stdout.String(),
+	)
+}
+
+func TestRunShouldReturnErrorGivenUnknownCommand(t *testing.T) {
+	var (
+		e      error
+		stdout bytes.Buffer
+	)
+
+	e = run([]string{"frobnicate"}, &stdout)
+
+	assert.Contains(t,
+		e.Error(), "unknown command \"frobnicate\"",
+	)
+}
+
+func TestRunShouldReturnErrorGivenUnknownFormat(t *testing.T) {
+	var (
+		e      error
+		stdout bytes.Buffer
+	)
+
+	e = run([]string{"diagram", "rfc9999"}, &stdout)
+
+	assert.EqualError(t,
+		e, "unknown format \"rfc9999\"",
+	)
+}
+
+func TestRunFromDiagram(t *testing.T) {
+	const (
+		diagram = "" +
+			"+-+-+-+-+-+-+-+-+\n" +
+			"|Version|  IHL  |\n" +
+			"+-+-+-+-+-+-+-+-+\n"
+
+		source = "" +
+			"// Code generated by binary fromdiagram; DO NOT EDIT.\n" +
+			"\n" +
+			"package header\n" +
+			"\n" +
+			"type Header struct {\n" +
+			"\tHeaderWord0 `word:\"8\"`\n" +
+			"}\n" +
+			"\n" +
+			"type HeaderWord0 struct {\n" +
+			"\tVersion uint8 `bitfield:\"4\"`\n" +
+			"\tIHL     uint8 `bitfield:\"4\"`\n" +
+			"}\n"
+	)
+
+	var (
+		e      error
+		path   string
+		stdout bytes.Buffer
+	)
+
+	path = filepath.Join(t.TempDir(), "header.txt")
+
+	e = os.WriteFile(path, []byte(diagram), 0o644)
+
+	assert.Nil(t, e)
+
+	e = run(
+		[]string{
+			"fromdiagram", "-name", "Header", "-package", "header", path,
+		},
+		&stdout,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		source, stdout.String(),
+	)
+}
+
+func TestRunFromDiagramShouldDeclareBitFieldsLongerThan64Bits(
+	t *testing.T,
+) {
+	const (
+		diagram = "" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|                                                               |\n" +
+			"+                                                               +\n" +
+			"|                                                               |\n" +
+			"+                        Source Address                         +\n" +
+			"|                                                               |\n" +
+			"+                                                               +\n" +
+			"|                                                               |\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|                                                               |\n" +
+			"+                                                               +\n" +
+			"|                                                               |\n" +
+			"+                      Destination Address                      +\n" +
+			"|                                                               |\n" +
+			"+                                                               +\n" +
+			"|                                                               |\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n"
+
+		source = "" +
+			"// Code generated by binary fromdiagram; DO NOT EDIT.\n" +
+			"\n" +
+			"package header\n" +
+			"\n" +
+			"import (\n" +
+			"\t\"net/netip\"\n" +
+			")\n" +
+			"\n" +
+			"type Header struct {\n" +
+			"\tSourceAddress      [16]byte   `word:\"128\"`\n" +
+			"\tDestinationAddress netip.Addr `word:\"128\"`\n" +
+			"}\n"
+	)
+
+	var (
+		e      error
+		path   string
+		stdout bytes.Buffer
+	)
+
+	path = filepath.Join(t.TempDir(), "header.txt")
+
+	e = os.WriteFile(path, []byte(diagram), 0o644)
+
+	assert.Nil(t, e)
+
+	e = run(
+		[]string{
+			"fromdiagram", "-name", "Header", "-package", "header",
+			"-type", "SourceAddress=[16]byte", path,
+		},
+		&stdout,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		source, stdout.String(),
+	)
+}
+
+func TestRunDecode(t *testing.T) {
+	const (
+		input = "" +
+			"ffff " +
+			"45e8ffff 00005fff 01060000 aaccf0ff 55330f00\n" +
+			"45e8ffff 00005fff 01060000 aaccf0ff 55330f00\n"
+	)
+
+	var (
+		dump   string
+		e      error
+		path   string
+		stdout bytes.Buffer
+	)
+
+	dump, e = binary.DumpBytes(internetHeaderBytes,
+		&rfc791.RFC791InternetHeaderFormatWithoutOptions{},
+	)
+
+	assert.Nil(t, e)
+
+	path = filepath.Join(t.TempDir(), "headers.hex")
+
+	e = os.WriteFile(path, []byte(input), 0o644)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"decode", "-hex", "-offset", "2", "rfc791", path},
+		&stdout,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		"# record 0 at offset 2\n"+dump+"# record 1 at offset 22\n"+dump,
+		stdout.String(),
+	)
+
+	stdout.Reset()
+
+	e = run(
+		[]string{
+			"decode", "-hex", "-offset", "2", "-count", "1", "rfc791", path,
+		},
+		&stdout,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		"# record 0 at offset 2\n"+dump,
+		stdout.String(),
+	)
+
+	e = run([]string{"decode", "-hex", "rfc791", path}, &stdout)
+
+	assert.EqualError(t,
+		e, "record 2 at offset 40 is truncated to 2 of 20 byte(s)",
+	)
+}
+
+func TestRunDecodeAndEncodeJSON(t *testing.T) {
+	var (
+		e       error
+		json    bytes.Buffer
+		path    string
+		records bytes.Buffer
+		stdout  bytes.Buffer
+	)
+
+	records.Write(internetHeaderBytes)
+	records.Write(internetHeaderBytes)
+
+	path = filepath.Join(t.TempDir(), "headers.bin")
+
+	e = os.WriteFile(path, records.Bytes(), 0o644)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"decode", "-output", "json", "rfc791", path}, &json)
+
+	assert.Nil(t, e)
+
+	assert.Contains(t,
+		json.String(), "\"TotalLength\": 65535",
+	)
+
+	path = filepath.Join(t.TempDir(), "headers.json")
+
+	e = os.WriteFile(path, json.Bytes(), 0o644)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"encode", "rfc791", path}, &stdout)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		records.Bytes(), stdout.Bytes(),
+	)
+}
+
+func TestRunFromSchema(t *testing.T) {
+	const (
+		source = "" +
+			"// Code generated by binary fromschema; DO NOT EDIT.\n" +
+			"\n" +
+			"package telemetry\n" +
+			"\n" +
+			"type Telemetry struct {\n" +
+			"\tTelemetryWord0 `word:\"16,littleendian\"`\n" +
+			"}\n" +
+			"\n" +
+			"type TelemetryWord0 struct {\n" +
+			"\tVersion uint8 `bitfield:\"8,min=1\"`\n" +
+			"\tKind    uint8 `bitfield:\"8,enum=Reading:1|Alarm:2\"`\n" +
+			"}\n" +
+			"\n" +
+			"const (\n" +
+			"\tTelemetryKindReading = 1\n" +
+			"\tTelemetryKindAlarm   = 2\n" +
+			")\n"
+	)
+
+	var (
+		e      error
+		stdout bytes.Buffer
+	)
+
+	e = run(
+		[]string{
+			"fromschema", "-package", "telemetry", writeTelemetrySchema(t),
+		},
+		&stdout,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		source, stdout.String(),
+	)
+}
+
+func TestRunDecodeAndEncodeWithSchema(t *testing.T) {
+	var (
+		e      error
+		json   bytes.Buffer
+		path   string
+		schema string
+		stdout bytes.Buffer
+	)
+
+	schema = writeTelemetrySchema(t)
+
+	path = filepath.Join(t.TempDir(), "telemetry.bin")
+
+	e = os.WriteFile(path, []byte{0x02, 0x04}, 0o644)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"decode", "-schema", schema, "Telemetry", path},
+		&stdout,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Contains(t,
+		stdout.String(), "Kind     Alarm(2)",
+	)
+
+	e = run(
+		[]string{
+			"decode", "-output", "json", "-schema", schema, "Telemetry", path,
+		},
+		&json,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		"{\n  \"Version\": 4,\n  \"Kind\": \"Alarm\"\n}\n", json.String(),
+	)
+
+	path = filepath.Join(t.TempDir(), "telemetry.json")
+
+	e = os.WriteFile(path, json.Bytes(), 0o644)
+
+	assert.Nil(t, e)
+
+	stdout.Reset()
+
+	e = run([]string{"encode", "-schema", schema, "Telemetry", path},
+		&stdout,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0x02, 0x04}, stdout.Bytes(),
+	)
+
+	e = run([]string{"diagram", "-schema", schema, "Telemetry0"}, &stdout)
+
+	assert.EqualError(t,
+		e, "unknown format \"Telemetry0\" in schema "+schema,
+	)
+}
+
+func TestRunCHeader(t *testing.T) {
+	var (
+		e      error
+		line   string
+		stdout bytes.Buffer
+	)
+
+	e = run(
+		[]string{"cheader", "-schema", writeTelemetrySchema(t), "Telemetry"},
+		&stdout,
+	)
+
+	assert.Nil(t, e)
+
+	for _, line = range []string{
+		"#ifndef TELEMETRY_H\n#define TELEMETRY_H\n",
+		"#define TELEMETRY_LENGTH 2\n",
+		"#define TELEMETRY_WORD0_OFFSET 0\n",
+		"static inline uint16_t telemetry_word0_load(const uint8_t *bytes)\n" +
+			"{\n" +
+			"\treturn (uint16_t)bytes[TELEMETRY_WORD0_OFFSET + 0] << 0 |\n" +
+			"\t\t(uint16_t)bytes[TELEMETRY_WORD0_OFFSET + 1] << 8;\n" +
+			"}\n",
+		"#define TELEMETRY_VERSION_SHIFT 8\n",
+		"#define TELEMETRY_VERSION_MASK UINT64_C(0xff)\n",
+		"#define TELEMETRY_KIND_GET(word) \\\n" +
+			"\t((uint8_t)(((uint64_t)(word) >> TELEMETRY_KIND_SHIFT) & " +
+			"TELEMETRY_KIND_MASK))\n",
+		"#define TELEMETRY_KIND_ALARM 2\n",
+	} {
+		assert.Contains(t,
+			stdout.String(), line,
+		)
+	}
+}
+
+func TestRunDissector(t *testing.T) {
+	const (
+		dissector = "" +
+			"-- Code generated by binary dissector; DO NOT EDIT.\n" +
+			"\n" +
+			"local proto = Proto(\"telemetry\", \"Telemetry\")\n" +
+			"\n" +
+			"local f_version = ProtoField.uint16(\"telemetry.version\", " +
+			"\"Version\", base.DEC, nil, 0xff00)\n" +
+			"local f_kind = ProtoField.uint16(\"telemetry.kind\", " +
+			"\"Kind\", base.DEC, {[1] = \"Reading\", [2] = \"Alarm\"}, 0xff)\n" +
+			"\n" +
+			"proto.fields = {\n" +
+			"\tf_version,\n" +
+			"\tf_kind,\n" +
+			"}\n" +
+			"\n" +
+			"function proto.dissector(buffer, pinfo, tree)\n" +
+			"\tif buffer:len() < 2 then\n" +
+			"\t\treturn 0\n" +
+			"\tend\n" +
+			"\n" +
+			"\tpinfo.cols.protocol = proto.name\n" +
+			"\n" +
+			"\tlocal subtree = tree:add(proto, buffer(0, 2))\n" +
+			"\n" +
+			"\tsubtree:add_le(f_version, buffer(0, 2))\n" +
+			"\tsubtree:add_le(f_kind, buffer(0, 2))\n" +
+			"\n" +
+			"\treturn 2\n" +
+			"end\n" +
+			"\n" +
+			"DissectorTable.get(\"udp.port\"):add(9000, proto)\n"
+	)
+
+	var (
+		e      error
+		stdout bytes.Buffer
+	)
+
+	e = run(
+		[]string{
+			"dissector", "-port", "9000",
+			"-schema", writeTelemetrySchema(t), "Telemetry",
+		},
+		&stdout,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		dissector, stdout.String(),
+	)
+
+	e = run([]string{"dissector", "-transport", "sctp", "rfc791"}, &stdout)
+
+	assert.EqualError(t,
+		e, "unknown transport \"sctp\"",
+	)
+}
+
+func TestRunToKSYAndFromKSY(t *testing.T) {
+	var (
+		e      error
+		ksy    bytes.Buffer
+		path   string
+		stdout bytes.Buffer
+	)
+
+	e = run([]string{"toksy", "rfc791"}, &ksy)
+
+	assert.Nil(t, e)
+
+	assert.Contains(t,
+		ksy.String(), "  - id: ihl\n    -orig-id: IHL\n    type: b4\n",
+	)
+
+	path = filepath.Join(t.TempDir(), "rfc791.ksy")
+
+	e = os.WriteFile(path, ksy.Bytes(), 0o644)
+
+	assert.Nil(t, e)
+
+	e = run([]string{"fromksy", "-package", "ip", path}, &stdout)
+
+	assert.Nil(t, e)
+
+	assert.Contains(t,
+		stdout.String(), "\tIHL         uint8  `bitfield:\"4,min=5\"`\n",
+	)
+}
+
+func writeTelemetrySchema(t *testing.T) (path string) {
+	const (
+		schema = "" +
+			"formats:\n" +
+			"  - name: Telemetry\n" +
+			"    words:\n" +
+			"      - length: 16\n" +
+			"        byteorder: little\n" +
+			"        bitfields:\n" +
+			"          - {name: Version, length: 8, min: 1}\n" +
+			"          - name: Kind\n" +
+			"            length: 8\n" +
+			"            enum:\n" +
+			"              - {name: Reading, value: 1}\n" +
+			"              - {name: Alarm, value: 2}\n"
+	)
+
+	var (
+		e error
+	)
+
+	path = filepath.Join(t.TempDir(), "telemetry.yaml")
+
+	e = os.WriteFile(path, []byte(schema), 0o644)
+
+	assert.Nil(t, e)
+
+	return
+}
+
+var (
+	internetHeaderBytes = []byte{
+		0x45, 0xe8, 0xff, 0xff, 0x00, 0x00, 0x5f, 0xff,
+		0x01, 0x06, 0x00, 0x00, 0xaa, 0xcc, 0xf0, 0xff,
+		0x55, 0x33, 0x0f, 0x00,
+	}
+)
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+
+	"github.com/encodingx/binary/internal/kaitai"
+)
+
+func runToKSY(args []string, stdout io.Writer) (e error) {
+	var (
+		flags    *flag.FlagSet
+		format   recordFormat
+		ksyBytes []byte
+		schema   string
+	)
+
+	flags = flag.NewFlagSet("toksy", flag.ContinueOnError)
+
+	flags.SetOutput(io.Discard)
+
+	flags.StringVar(&schema, "schema", "",
+		"schema file defining the format",
+	)
+
+	e = flags.Parse(args)
+	if e != nil {
+		return
+	}
+
+	if flags.NArg() != 1 {
+		e = errors.New("usage: binary toksy [-schema file] <format>")
+
+		return
+	}
+
+	format, e = lookUpFormat(
+		flags.Arg(0), schema,
+	)
+	if e != nil {
+		return
+	}
+
+	ksyBytes, e = kaitai.Export(
+		format.describe(),
+	)
+	if e != nil {
+		return
+	}
+
+	_, e = stdout.Write(ksyBytes)
+	if e != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,337 @@
+package binary
+
+import (
+	"fmt"
+	"go/token"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/encodingx/binary/internal/codecs/metadata"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+// Definitions describe formats independently of Go struct types,
+// for formats learnt at run time or read from other notations.
+
+type FormatDefinition struct {
+	Name  string
+	Words []WordDefinition
+}
+
+type WordDefinition struct {
+	Name      string
+	Length    uint
+	Options   []string
+	BitFields []BitFieldDefinition
+}
+
+type BitFieldDefinition struct {
+	Name    string
+	Length  uint
+	Type    string
+	Options []string
+}
+
+const (
+	wordLengthUpperLimit = 64
+)
+
+var (
+	bitFieldTypes = map[string]reflect.Type{
+		"bool":   reflect.TypeOf(false),
+		"uint":   reflect.TypeOf(uint(0)),
+		"uint8":  reflect.TypeOf(uint8(0)),
+		"uint16": reflect.TypeOf(uint16(0)),
+		"uint32": reflect.TypeOf(uint32(0)),
+		"uint64": reflect.TypeOf(uint64(0)),
+		"int":    reflect.TypeOf(int(0)),
+		"int8":   reflect.TypeOf(int8(0)),
+		"int16":  reflect.TypeOf(int16(0)),
+		"int32":  reflect.TypeOf(int32(0)),
+		"int64":  reflect.TypeOf(int64(0)),
+		"string": reflect.TypeOf(""),
+
+		"netip.Addr":       reflect.TypeOf(netip.Addr{}),
+		"netip.AddrPort":   reflect.TypeOf(netip.AddrPort{}),
+		"net.HardwareAddr": reflect.TypeOf(net.HardwareAddr{}),
+	}
+)
+
+func DefaultBitFieldType(length uint) string {
+	// The smallest type able to hold every value of a bit field
+
+	switch {
+	case length == 1:
+		return "bool"
+
+	case length <= 8:
+		return "uint8"
+
+	case length <= 16:
+		return "uint16"
+
+	case length <= 32:
+		return "uint32"
+
+	default:
+		return "uint64"
+	}
+}
+
+func (d FormatDefinition) Validate() (e error) {
+	// Validate the definition as if it were a format-struct
+	// passed to Marshal or Unmarshal.
+
+	const (
+		functionName = "Validate"
+	)
+
+	defer func() {
+		const (
+			validateError = "Validate error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(validateError, e)
+		}
+
+		return
+	}()
+
+	_, _, e = d.formatMetadata()
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (d FormatDefinition) Describe() (descriptor FormatDescriptor, e error) {
+	const (
+		functionName = "Describe"
+	)
+
+	var (
+		format metadata.FormatMetadata
+	)
+
+	defer func() {
+		const (
+			describeError = "Describe error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(describeError, e)
+		}
+
+		return
+	}()
+
+	format, _, e = d.formatMetadata()
+	if e != nil {
+		return
+	}
+
+	descriptor = newFormatDescriptor(format)
+
+	return
+}
+
+func (d FormatDefinition) formatMetadata() (
+	format metadata.FormatMetadata, reflection reflect.Type, e error,
+) {
+	// Build a struct type equivalent to the format-struct
+	// that would be declared for the definition,
+	// so that it is validated and encoded exactly as one would be.
+
+	var (
+		bitField       BitFieldDefinition
+		bitFieldFields []reflect.StructField
+		bitFieldNames  map[string]bool
+		bitFieldType   reflect.Type
+		ok             bool
+		word           WordDefinition
+		wordFields     []reflect.StructField
+		wordNames      = make(map[string]bool)
+	)
+
+	defer func() {
+		if e != nil {
+			e.(validation.FormatError).SetFormatName(d.Name)
+		}
+	}()
+
+	for _, word = range d.Words {
+		if wordNames[word.Name] || !isExportedIdentifier(word.Name) {
+			e = validation.NewWordWithInvalidNameError()
+
+			e.(validation.WordError).SetWordName(word.Name)
+
+			return
+		}
+
+		wordNames[word.Name] = true
+
+		bitFieldFields = nil
+		bitFieldNames = make(map[string]bool)
+
+		for _, bitField = range word.BitFields {
+			if bitFieldNames[bitField.Name] ||
+				!isExportedIdentifier(bitField.Name) {
+				e = validation.NewBitFieldWithInvalidNameError()
+
+			} else if bitFieldType, ok = definitionBitFieldType(
+				bitField.Type,
+			); !ok {
+				e = validation.NewBitFieldOfUnsupportedTypeError(
+					bitField.Type,
+				)
+			}
+
+			if e != nil {
+				e.(validation.BitFieldError).SetWordName(word.Name)
+
+				e.(validation.BitFieldError).SetBitFieldName(bitField.Name)
+
+				return
+			}
+
+			bitFieldNames[bitField.Name] = true
+
+			bitFieldFields = append(bitFieldFields,
+				reflect.StructField{
+					Name: bitField.Name,
+					Type: bitFieldType,
+					Tag:  bitField.Tag(),
+				},
+			)
+		}
+
+		if word.LongField() {
+			wordFields = append(wordFields,
+				reflect.StructField{
+					Name: word.Name,
+					Type: bitFieldType,
+					Tag:  word.Tag(),
+				},
+			)
+
+			continue
+		}
+
+		wordFields = append(wordFields,
+			reflect.StructField{
+				Name: word.Name,
+				Type: reflect.StructOf(bitFieldFields),
+				Tag:  word.Tag(),
+			},
+		)
+	}
+
+	reflection = reflect.StructOf(wordFields)
+
+	format, e = metadata.NewNamedFormatMetadataFromTypeReflection(d.Name,
+		reflection,
+	)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (d WordDefinition) Tag() reflect.StructTag {
+	// Words declared as their bit fields take their options.
+
+	if d.LongField() {
+		return definitionTag("word", d.Length,
+			append(
+				append([]string(nil), d.Options...),
+				d.BitFields[0].Options...,
+			),
+		)
+	}
+
+	return definitionTag("word", d.Length, d.Options)
+}
+
+func (d WordDefinition) LongField() bool {
+	// Whether the word is longer than 64 bits
+	// and of one bit field of its name and length,
+	// and so declared as a field of the type of the bit field
+	// (e.g. netip.Addr or string), as in format-structs
+
+	return d.Length > wordLengthUpperLimit &&
+		len(d.BitFields) == 1 &&
+		d.BitFields[0].Name == d.Name &&
+		d.BitFields[0].Length == d.Length
+}
+
+func definitionBitFieldType(name string) (
+	reflectionType reflect.Type, ok bool,
+) {
+	// Arrays of bytes (e.g. "[16]byte") are of any length.
+
+	var (
+		e      error
+		length int
+	)
+
+	reflectionType, ok = bitFieldTypes[name]
+	if ok {
+		return
+	}
+
+	if !strings.HasPrefix(name, "[") || !strings.HasSuffix(name, "]byte") {
+		return
+	}
+
+	length, e = strconv.Atoi(
+		strings.TrimSuffix(
+			strings.TrimPrefix(name, "["), "]byte",
+		),
+	)
+	if e != nil || length <= 0 {
+		return
+	}
+
+	reflectionType, ok = reflect.ArrayOf(length, reflect.TypeOf(byte(0))), true
+
+	return
+}
+
+func (d BitFieldDefinition) Tag() reflect.StructTag {
+	return definitionTag("bitfield", d.Length, d.Options)
+}
+
+func definitionTag(key string, length uint, options []string) (
+	tag reflect.StructTag,
+) {
+	var (
+		option string
+		value  string
+	)
+
+	value = fmt.Sprint(length)
+
+	for _, option = range options {
+		value += "," + option
+	}
+
+	tag = reflect.StructTag(
+		fmt.Sprintf("%s:%q", key, value),
+	)
+
+	return
+}
+
+func isExportedIdentifier(name string) bool {
+	return token.IsIdentifier(name) && token.IsExported(name)
+}
@@ -0,0 +1,284 @@
+package binary
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/encodingx/binary/internal/codecs/metadata"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+func Describe(iface interface{}) (descriptor FormatDescriptor, e error) {
+	const (
+		functionName = "Describe"
+	)
+
+	var (
+		format metadata.FormatMetadata
+	)
+
+	defer func() {
+		const (
+			describeError = "Describe error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(describeError, e)
+		}
+
+		return
+	}()
+
+	format, e = defaultCodec.FormatMetadata(iface)
+	if e != nil {
+		return
+	}
+
+	descriptor = newFormatDescriptor(format)
+
+	return
+}
+
+func (f Format[T]) Describe() FormatDescriptor {
+	return newFormatDescriptor(f.format)
+}
+
+type FormatDescriptor struct {
+	name          string
+	lengthInBytes int
+	variable      bool
+	words         []WordDescriptor
+}
+
+func newFormatDescriptor(format metadata.FormatMetadata) (
+	descriptor FormatDescriptor,
+) {
+	var (
+		i    int
+		word metadata.WordMetadata
+	)
+
+	descriptor = FormatDescriptor{
+		name:          format.Name(),
+		lengthInBytes: format.LengthInBytes(),
+		variable:      format.Variable(),
+		words: make([]WordDescriptor,
+			len(format.Words()),
+		),
+	}
+
+	for i, word = range format.Words() {
+		descriptor.words[i] = newWordDescriptor(word)
+	}
+
+	return
+}
+
+func (d FormatDescriptor) Name() string {
+	return d.name
+}
+
+func (d FormatDescriptor) LengthInBytes() int {
+	// Greatest length of the format if it is variable in length
+
+	return d.lengthInBytes
+}
+
+func (d FormatDescriptor) Variable() bool {
+	// Whether the format has words holding variable-length integers
+	// or bit streams,
+	// following which byte offsets are the greatest they can be
+
+	return d.variable
+}
+
+func (d FormatDescriptor) Words() (words []WordDescriptor) {
+	words = make([]WordDescriptor, len(d.words))
+
+	copy(words, d.words)
+
+	return
+}
+
+type WordDescriptor struct {
+	name          string
+	lengthInBits  uint
+	lengthInBytes int
+	byteOffset    int
+	byteOrder     ByteOrder
+	lsbFirst      bool
+	varint        string
+	bitStream     bool
+	options       []string
+	bitFields     []BitFieldDescriptor
+}
+
+func newWordDescriptor(word metadata.WordMetadata) (
+	descriptor WordDescriptor,
+) {
+	var (
+		bitField   metadata.BitFieldMetadata
+		enumerated metadata.EnumeratedValue
+		i          int
+	)
+
+	descriptor = WordDescriptor{
+		name:          word.Name(),
+		lengthInBits:  word.LengthInBits(),
+		lengthInBytes: word.LengthInBytes(),
+		byteOffset:    word.ByteOffset(),
+		byteOrder:     BigEndian,
+		lsbFirst:      word.LSBFirst(),
+		varint:        word.Varint(),
+		bitStream:     word.BitStream(),
+		options:       word.Options(),
+		bitFields: make([]BitFieldDescriptor,
+			len(word.BitFields()),
+		),
+	}
+
+	if word.LittleEndian() {
+		descriptor.byteOrder = LittleEndian
+	}
+
+	for i, bitField = range word.BitFields() {
+		descriptor.bitFields[i] = BitFieldDescriptor{
+			name:         bitField.Name(),
+			reflection:   bitField.Type(),
+			lengthInBits: bitField.Length(),
+			bitOffset: word.LengthInBits() -
+				bitField.Offset() - bitField.Length(),
+			options: bitField.Options(),
+		}
+
+		for _, enumerated = range bitField.Enumeration() {
+			descriptor.bitFields[i].enumeration = append(
+				descriptor.bitFields[i].enumeration,
+				EnumeratedValue{enumerated.Name, enumerated.Value},
+			)
+		}
+	}
+
+	return
+}
+
+func (d WordDescriptor) Name() string {
+	return d.name
+}
+
+func (d WordDescriptor) LengthInBits() uint {
+	return d.lengthInBits
+}
+
+func (d WordDescriptor) LengthInBytes() int {
+	// Greatest length of the encoding of a variable-length integer
+	// or of a bit stream
+
+	return d.lengthInBytes
+}
+
+func (d WordDescriptor) ByteOffset() int {
+	// Number of bytes preceding the word in the format
+
+	return d.byteOffset
+}
+
+func (d WordDescriptor) ByteOrder() ByteOrder {
+	return d.byteOrder
+}
+
+func (d WordDescriptor) Varint() string {
+	// Encoding of the variable-length integer held by the word
+	// (e.g. "uleb128"), or an empty string
+
+	return d.varint
+}
+
+func (d WordDescriptor) BitStream() bool {
+	// Whether bit fields follow one another at any bit position,
+	// bit offsets being the greatest they can be
+	// following Exp-Golomb codes
+
+	return d.bitStream
+}
+
+func (d WordDescriptor) LSBFirst() bool {
+	// Whether bit fields are allocated from the least significant bit,
+	// so that the first declared has the greatest bit offset
+
+	return d.lsbFirst
+}
+
+func (d WordDescriptor) Options() (options []string) {
+	options = make([]string, len(d.options))
+
+	copy(options, d.options)
+
+	return
+}
+
+func (d WordDescriptor) BitFields() (bitFields []BitFieldDescriptor) {
+	bitFields = make([]BitFieldDescriptor, len(d.bitFields))
+
+	copy(bitFields, d.bitFields)
+
+	return
+}
+
+type BitFieldDescriptor struct {
+	name         string
+	reflection   reflect.Type
+	lengthInBits uint
+	bitOffset    uint
+	options      []string
+	enumeration  []EnumeratedValue
+}
+
+type EnumeratedValue struct {
+	Name  string
+	Value uint64
+}
+
+func (d BitFieldDescriptor) Name() string {
+	return d.name
+}
+
+func (d BitFieldDescriptor) Type() reflect.Type {
+	return d.reflection
+}
+
+func (d BitFieldDescriptor) LengthInBits() uint {
+	return d.lengthInBits
+}
+
+func (d BitFieldDescriptor) BitOffset() uint {
+	// Number of bits preceding the bit field in the word,
+	// counting from the most significant bit as RFC diagrams do
+
+	return d.bitOffset
+}
+
+func (d BitFieldDescriptor) Options() (options []string) {
+	options = make([]string, len(d.options))
+
+	copy(options, d.options)
+
+	return
+}
+
+func (d BitFieldDescriptor) Enumeration() (enumeration []EnumeratedValue) {
+	// Values allowed by the option "enum=NAME:N|NAME:N", if given,
+	// or else registered for the type of the bit field
+
+	if d.enumeration == nil {
+		return
+	}
+
+	enumeration = make([]EnumeratedValue, len(d.enumeration))
+
+	copy(enumeration, d.enumeration)
+
+	return
+}
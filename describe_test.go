@@ -0,0 +1,299 @@
+package binary
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/encodingx/binary/pkg/rfc791"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	var (
+		bitFields  []BitFieldDescriptor
+		descriptor FormatDescriptor
+		e          error
+		words      []WordDescriptor
+	)
+
+	descriptor, e = Describe(&internetHeaderStruct)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		"rfc791.RFC791InternetHeaderFormatWithoutOptions",
+		descriptor.Name(),
+	)
+
+	assert.Equal(t,
+		20, descriptor.LengthInBytes(),
+	)
+
+	words = descriptor.Words()
+
+	assert.Equal(t,
+		5, len(words),
+	)
+
+	assert.Equal(t,
+		"RFC791InternetHeaderFormatWord1", words[1].Name(),
+	)
+
+	assert.Equal(t,
+		uint(32), words[1].LengthInBits(),
+	)
+
+	assert.Equal(t,
+		4, words[1].LengthInBytes(),
+	)
+
+	assert.Equal(t,
+		4, words[1].ByteOffset(),
+	)
+
+	bitFields = words[1].BitFields()
+
+	assert.Equal(t,
+		5, len(bitFields),
+	)
+
+	assert.Equal(t,
+		"FragmentOffset", bitFields[4].Name(),
+	)
+
+	assert.Equal(t,
+		reflect.TypeOf(uint16(0)), bitFields[4].Type(),
+	)
+
+	assert.Equal(t,
+		uint(13), bitFields[4].LengthInBits(),
+	)
+
+	assert.Equal(t,
+		uint(19), bitFields[4].BitOffset(),
+	)
+
+	assert.Empty(t,
+		bitFields[4].Options(),
+	)
+}
+
+func TestDescribeOptions(t *testing.T) {
+	var (
+		descriptor FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = Describe(&internetHeaderStructV1p1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]string{"28"},
+		descriptor.Words()[0].BitFields()[0].Options(),
+	)
+}
+
+func TestDescribeVarints(t *testing.T) {
+	// Lengths and offsets of variable-length formats are the greatest.
+
+	type (
+		Word struct {
+			Kind uint8 `bitfield:"8"`
+		}
+
+		Format struct {
+			Length uint16 `word:"14,quic"`
+			Word   `word:"8"`
+		}
+	)
+
+	var (
+		descriptor FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = Describe(&Format{})
+
+	assert.Nil(t, e)
+
+	assert.True(t,
+		descriptor.Variable(),
+	)
+
+	assert.Equal(t,
+		3, descriptor.LengthInBytes(),
+	)
+
+	assert.Equal(t,
+		"quic", descriptor.Words()[0].Varint(),
+	)
+
+	assert.Equal(t,
+		2, descriptor.Words()[0].LengthInBytes(),
+	)
+
+	assert.Equal(t,
+		2, descriptor.Words()[1].ByteOffset(),
+	)
+}
+
+func TestDescribeBitStreams(t *testing.T) {
+	// Lengths and offsets of bit streams are the greatest,
+	// as if each Exp-Golomb code were of its greatest length.
+
+	type (
+		Header struct {
+			Flag    bool  `bitfield:"1"`
+			CodeNum uint8 `bitfield:"4,ue"`
+			Delta   int8  `bitfield:"4,se"`
+		}
+
+		Word struct {
+			Kind uint8 `bitfield:"8"`
+		}
+
+		Format struct {
+			Header `word:"0,bitstream"`
+			Word   `word:"8"`
+		}
+	)
+
+	var (
+		descriptor FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = Describe(&Format{})
+
+	assert.Nil(t, e)
+
+	assert.True(t,
+		descriptor.Variable(),
+	)
+
+	assert.True(t,
+		descriptor.Words()[0].BitStream(),
+	)
+
+	assert.Equal(t,
+		3, descriptor.Words()[0].LengthInBytes(),
+	)
+
+	assert.Equal(t,
+		uint(10), descriptor.Words()[0].BitFields()[2].BitOffset(),
+	)
+
+	assert.Equal(t,
+		3, descriptor.Words()[1].ByteOffset(),
+	)
+}
+
+func TestDescribeShouldBeImmutable(t *testing.T) {
+	var (
+		descriptor FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = Describe(&internetHeaderStruct)
+
+	assert.Nil(t, e)
+
+	descriptor.Words()[0] = WordDescriptor{}
+
+	assert.Equal(t,
+		"RFC791InternetHeaderFormatWord0", descriptor.Words()[0].Name(),
+	)
+}
+
+func TestFormatOfDescribe(t *testing.T) {
+	var (
+		descriptor FormatDescriptor
+		e          error
+		format     Format[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+	)
+
+	descriptor, e = Describe(&internetHeaderStruct)
+
+	assert.Nil(t, e)
+
+	format, e = FormatOf[rfc791.RFC791InternetHeaderFormatWithoutOptions]()
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		descriptor, format.Describe(),
+	)
+}
+
+func TestDescribeShouldReturnErrorGivenNonPointer(t *testing.T) {
+	const (
+		errorMessage = "Describe error: " +
+			"Argument to Describe should be a pointer to a format-struct. " +
+			"Argument to Describe is not a pointer."
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Describe(internetHeaderStruct)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	_, e = Describe(nil)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestFormatDefinitionDescribe(t *testing.T) {
+	var (
+		bitFields   []BitFieldDescriptor
+		definitions []FormatDefinition
+		descriptor  FormatDescriptor
+		e           error
+		words       []WordDescriptor
+	)
+
+	definitions, e = ParseSchema(
+		[]byte(telemetrySchema),
+	)
+
+	assert.Nil(t, e)
+
+	descriptor, e = definitions[0].Describe()
+
+	assert.Nil(t, e)
+
+	words = descriptor.Words()
+
+	assert.Equal(t,
+		LittleEndian, words[0].ByteOrder(),
+	)
+
+	assert.Equal(t,
+		[]string{"littleendian"}, words[0].Options(),
+	)
+
+	assert.Equal(t,
+		BigEndian, words[1].ByteOrder(),
+	)
+
+	bitFields = words[0].BitFields()
+
+	assert.Equal(t,
+		[]EnumeratedValue{
+			{"Reading", 1},
+			{"Alarm", 2},
+		},
+		bitFields[3].Enumeration(),
+	)
+
+	assert.Nil(t,
+		bitFields[0].Enumeration(),
+	)
+}
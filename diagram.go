@@ -0,0 +1,219 @@
+package binary
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/encodingx/binary/internal/codecs/metadata"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+const (
+	DiagramRowWidth = 32
+)
+
+func Diagram(iface interface{}, rowWidth uint) (diagram string, e error) {
+	const (
+		functionName = "Diagram"
+	)
+
+	var (
+		format metadata.FormatMetadata
+	)
+
+	defer func() {
+		const (
+			diagramError = "Diagram error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(diagramError, e)
+		}
+
+		return
+	}()
+
+	format, e = defaultCodec.FormatMetadata(iface)
+	if e != nil {
+		return
+	}
+
+	diagram = newFormatDescriptor(format).Diagram(rowWidth)
+
+	return
+}
+
+func (d FormatDescriptor) Diagram(rowWidth uint) string {
+	// Render the format in the style of the header diagrams in RFCs,
+	// where each tick mark represents one bit position
+	// and a row width of zero defaults to the customary 32 bits.
+
+	type segment struct {
+		name      string
+		length    uint
+		continued bool
+	}
+
+	var (
+		bitField  BitFieldDescriptor
+		bitsInRow uint
+		builder   strings.Builder
+		continues []bool
+		i         uint
+		j         int
+		remaining uint
+		row       []segment
+		rows      [][]segment
+		s         segment
+		split     uint
+		tens      strings.Builder
+		word      WordDescriptor
+	)
+
+	if rowWidth == 0 {
+		rowWidth = DiagramRowWidth
+	}
+
+	for _, word = range d.words {
+		for _, bitField = range word.bitFieldsInBitOrder() {
+			for remaining = bitField.lengthInBits; remaining > 0; {
+				split = rowWidth - bitsInRow
+
+				if remaining < split {
+					split = remaining
+				}
+
+				row = append(row,
+					segment{bitField.name, split,
+						remaining < bitField.lengthInBits,
+					},
+				)
+
+				bitsInRow += split
+				remaining -= split
+
+				if bitsInRow == rowWidth {
+					rows = append(rows, row)
+
+					row = nil
+					bitsInRow = 0
+				}
+			}
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	for i = 0; i < rowWidth; i++ {
+		if i%10 == 0 {
+			fmt.Fprintf(&tens, " %d", i/10%10)
+
+		} else {
+			tens.WriteString("  ")
+		}
+	}
+
+	builder.WriteString(
+		strings.TrimRight(tens.String(), " ") + "\n",
+	)
+
+	for i = 0; i < rowWidth; i++ {
+		fmt.Fprintf(&builder, " %d", i%10)
+	}
+
+	builder.WriteString("\n")
+
+	builder.WriteString(
+		diagramBorder(rowWidth),
+	)
+
+	// Bit fields spanning whole rows (e.g. IPv6 addresses) are continued
+	// below partial borders and unlabelled, as in RFC 8200.
+
+	continues = make([]bool, len(rows))
+
+	for j = 1; j < len(rows); j++ {
+		continues[j] = len(rows[j]) == 1 && rows[j][0].continued &&
+			rows[j][0].length == rowWidth &&
+			len(rows[j-1]) == 1 && rows[j-1][0].length == rowWidth
+	}
+
+	for j, row = range rows {
+		bitsInRow = 0
+
+		for _, s = range row {
+			builder.WriteString("|")
+
+			if continues[j] {
+				s.name = ""
+			}
+
+			builder.WriteString(
+				diagramLabel(s.name, 2*s.length-1),
+			)
+
+			bitsInRow += s.length
+		}
+
+		builder.WriteString("|\n")
+
+		if j+1 < len(rows) && continues[j+1] {
+			builder.WriteString(
+				"+" + strings.Repeat(" ", int(2*rowWidth-1)) + "+\n",
+			)
+
+			continue
+		}
+
+		builder.WriteString(
+			diagramBorder(bitsInRow),
+		)
+	}
+
+	return builder.String()
+}
+
+func (d WordDescriptor) bitFieldsInBitOrder() (
+	bitFields []BitFieldDescriptor,
+) {
+	// Diagrams run from the most significant bit,
+	// which is the last declared bit field if allocated LSB-first.
+
+	var (
+		i int
+	)
+
+	if !d.lsbFirst {
+		return d.bitFields
+	}
+
+	for i = len(d.bitFields) - 1; i >= 0; i-- {
+		bitFields = append(bitFields, d.bitFields[i])
+	}
+
+	return
+}
+
+func diagramBorder(width uint) string {
+	return strings.Repeat("+-", int(width)) + "+\n"
+}
+
+func diagramLabel(name string, width uint) string {
+	var (
+		left  int
+		right int
+	)
+
+	if uint(len(name)) > width {
+		name = name[:width]
+	}
+
+	left = (int(width) - len(name)) / 2
+	right = int(width) - len(name) - left
+
+	return strings.Repeat(" ", left) + name + strings.Repeat(" ", right)
+}
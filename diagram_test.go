@@ -0,0 +1,177 @@
+package binary
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagram(t *testing.T) {
+	const (
+		expectedDiagram = "" +
+			" 0                   1                   2                   3\n" +
+			" 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|Version|  IHL  |Prece|D|T|R|Res|          TotalLength          |\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|        Identification         |F|F|F|     FragmentOffset      |\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|  TimeToLive   |   Protocol    |        HeaderChecksum         |\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|SourceAddressOc|SourceAddressOc|SourceAddressOc|SourceAddressOc|\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|DestinationAddr|DestinationAddr|DestinationAddr|DestinationAddr|\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n"
+	)
+
+	var (
+		diagram string
+		e       error
+	)
+
+	diagram, e = Diagram(&internetHeaderStruct, DiagramRowWidth)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDiagram, diagram,
+	)
+}
+
+func TestDiagramShouldSplitBitFieldsAcrossRows(t *testing.T) {
+	const (
+		expectedDiagram = "" +
+			" 0\n" +
+			" 0 1 2 3 4 5 6 7 8 9\n" +
+			"+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|  A  |      B      |\n" +
+			"+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|     B     |\n" +
+			"+-+-+-+-+-+-+\n"
+	)
+
+	type (
+		Word struct {
+			A uint8  `bitfield:"3"`
+			B uint16 `bitfield:"13"`
+		}
+
+		Format struct {
+			Word `word:"16"`
+		}
+	)
+
+	var (
+		diagram string
+		e       error
+	)
+
+	diagram, e = Diagram(&Format{}, 10)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDiagram, diagram,
+	)
+}
+
+func TestDiagramShouldDrawLSBFirstWordsFromMostSignificantBit(
+	t *testing.T,
+) {
+	const (
+		expectedDiagram = "" +
+			" 0                   1\n" +
+			" 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|         Count         |Mode |E|\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n"
+	)
+
+	type (
+		Word struct {
+			E     bool   `bitfield:"1"`
+			Mode  uint8  `bitfield:"3"`
+			Count uint16 `bitfield:"12"`
+		}
+
+		Format struct {
+			Word `word:"16,lsbfirst"`
+		}
+	)
+
+	var (
+		diagram string
+		e       error
+	)
+
+	diagram, e = Diagram(&Format{}, 16)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDiagram, diagram,
+	)
+}
+
+func TestDiagramShouldReturnErrorGivenNonPointer(t *testing.T) {
+	const (
+		errorMessage = "Diagram error: " +
+			"Argument to Diagram should be a pointer to a format-struct. " +
+			"Argument to Diagram is not a pointer."
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Diagram(internetHeaderStruct, DiagramRowWidth)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestDiagramShouldContinueBitFieldsSpanningRows(t *testing.T) {
+	const (
+		expectedDiagram = "" +
+			" 0                   1                   2                   3\n" +
+			" 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|                             Addr                              |\n" +
+			"+                                                               +\n" +
+			"|                                                               |\n" +
+			"+                                                               +\n" +
+			"|                                                               |\n" +
+			"+                                                               +\n" +
+			"|                                                               |\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n" +
+			"|             Port              |           Reserved            |\n" +
+			"+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+\n"
+	)
+
+	type (
+		Word struct {
+			Port     uint16 `bitfield:"16"`
+			Reserved uint16 `bitfield:"16"`
+		}
+
+		Format struct {
+			Addr netip.Addr `word:"128"`
+			Word `word:"32"`
+		}
+	)
+
+	var (
+		diagram string
+		e       error
+	)
+
+	diagram, e = Diagram(&Format{}, DiagramRowWidth)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDiagram, diagram,
+	)
+}
@@ -0,0 +1,287 @@
+package binary
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+	"reflect"
+
+	"github.com/encodingx/binary/internal/codecs"
+	"github.com/encodingx/binary/internal/codecs/metadata"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+type Difference struct {
+	Word       string
+	ByteOffset int
+	BitField   string
+	BitOffset  uint
+	Length     uint
+	A          interface{}
+	B          interface{}
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s.%s %s: %s != %s",
+		d.Word, d.BitField, dumpBitRange(d.BitOffset, d.Length),
+		differenceValue(d.A), differenceValue(d.B),
+	)
+}
+
+func differenceValue(value interface{}) string {
+	// Strings are quoted, as in dumps.
+
+	switch value.(type) {
+	case string:
+		return fmt.Sprintf("%q", value)
+	}
+
+	return fmt.Sprint(value)
+}
+
+func Diff(iface interface{}, a, b []byte) (
+	differences []Difference, e error,
+) {
+	const (
+		functionName = "Diff"
+	)
+
+	var (
+		format metadata.FormatMetadata
+		wordsA []metadata.WordMetadata
+		wordsB []metadata.WordMetadata
+	)
+
+	defer func() {
+		const (
+			diffError = "Diff error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(diffError, e)
+		}
+
+		return
+	}()
+
+	format, e = defaultCodec.FormatMetadata(iface)
+	if e != nil {
+		return
+	}
+
+	wordsA, e = format.Layout(a)
+	if e != nil {
+		return
+	}
+
+	wordsB, e = format.Layout(b)
+	if e != nil {
+		return
+	}
+
+	differences = diffFormat(wordsA, wordsB, a, b)
+
+	return
+}
+
+func DiffStructs(a, b interface{}) (differences []Difference, e error) {
+	const (
+		functionName = "DiffStructs"
+	)
+
+	var (
+		bytesA []byte
+		bytesB []byte
+		format metadata.FormatMetadata
+		wordsA []metadata.WordMetadata
+		wordsB []metadata.WordMetadata
+	)
+
+	defer func() {
+		const (
+			diffStructsError = "DiffStructs error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(diffStructsError, e)
+		}
+
+		return
+	}()
+
+	_, e = defaultCodec.FormatMetadata(b)
+	if e != nil {
+		return
+	}
+
+	format, e = defaultCodec.FormatMetadata(a)
+	if e != nil {
+		return
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		e = validation.NewPointersToDifferentTypesError(
+			reflect.TypeOf(a).Elem().String(),
+			reflect.TypeOf(b).Elem().String(),
+		)
+
+		return
+	}
+
+	bytesA, e = codecs.NewOperationFromFormatMetadata(format,
+		reflect.ValueOf(a).Elem(),
+	).Marshal()
+	if e != nil {
+		return
+	}
+
+	bytesB, e = codecs.NewOperationFromFormatMetadata(format,
+		reflect.ValueOf(b).Elem(),
+	).Marshal()
+	if e != nil {
+		return
+	}
+
+	wordsA, e = format.Layout(bytesA)
+	if e != nil {
+		return
+	}
+
+	wordsB, e = format.Layout(bytesB)
+	if e != nil {
+		return
+	}
+
+	differences = diffFormat(wordsA, wordsB, bytesA, bytesB)
+
+	return
+}
+
+func diffFormat(wordsA, wordsB []metadata.WordMetadata, a, b []byte) (
+	differences []Difference,
+) {
+	// Words are laid out in each byte slice,
+	// and are placed at their byte offsets in the first,
+	// as are their bit fields at their bit positions.
+
+	var (
+		bitField metadata.BitFieldMetadata
+		i        int
+		j        int
+		valuesA  []metadata.BitFieldValue
+		valuesB  []metadata.BitFieldValue
+		word     metadata.WordMetadata
+	)
+
+	for i, word = range wordsA {
+		valuesA = word.Values(a)
+		valuesB = wordsB[i].Values(b)
+
+		for j, bitField = range word.BitFields() {
+			if valuesA[j].Bytes != nil {
+				if bytes.Equal(valuesA[j].Bytes, valuesB[j].Bytes) {
+					continue
+				}
+
+				differences = append(differences,
+					Difference{
+						Word:       word.Name(),
+						ByteOffset: word.ByteOffset(),
+						BitField:   bitField.Name(),
+						BitOffset:  valuesA[j].Position,
+						Length:     valuesA[j].Length,
+						A:          decodeBytes(bitField, valuesA[j].Bytes),
+						B:          decodeBytes(bitField, valuesB[j].Bytes),
+					},
+				)
+
+				continue
+			}
+
+			if valuesA[j].Value == valuesB[j].Value {
+				continue
+			}
+
+			if len(bitField.Flags()) > 0 {
+				differences = append(differences,
+					diffFlags(word, bitField, valuesA[j], valuesB[j].Value)...,
+				)
+
+				continue
+			}
+
+			differences = append(differences,
+				Difference{
+					Word:       word.Name(),
+					ByteOffset: word.ByteOffset(),
+					BitField:   bitField.Name(),
+					BitOffset:  valuesA[j].Position,
+					Length:     valuesA[j].Length,
+					A:          decodeValue(bitField, valuesA[j].Value),
+					B:          decodeValue(bitField, valuesB[j].Value),
+				},
+			)
+		}
+	}
+
+	return
+}
+
+func diffFlags(word metadata.WordMetadata, bitField metadata.BitFieldMetadata,
+	a metadata.BitFieldValue, valueB uint64,
+) (
+	differences []Difference,
+) {
+	// Compare flags one by one (e.g. Flags.DF),
+	// and bits that are not flags as the whole bit field.
+
+	var (
+		bitOffset = a.Position
+		flag      metadata.EnumeratedValue
+		mask      uint64
+		valueA    = a.Value
+	)
+
+	for _, flag = range bitField.Flags() {
+		mask |= flag.Value
+
+		if (valueA^valueB)&flag.Value == 0 {
+			continue
+		}
+
+		differences = append(differences,
+			Difference{
+				Word:       word.Name(),
+				ByteOffset: word.ByteOffset(),
+				BitField:   bitField.Name() + "." + flag.Name,
+				BitOffset: bitOffset + a.Length - 1 -
+					uint(bits.TrailingZeros64(flag.Value)),
+				Length: 1,
+				A:      valueA&flag.Value != 0,
+				B:      valueB&flag.Value != 0,
+			},
+		)
+	}
+
+	if (valueA^valueB)&^mask == 0 {
+		return
+	}
+
+	differences = append(differences,
+		Difference{
+			Word:       word.Name(),
+			ByteOffset: word.ByteOffset(),
+			BitField:   bitField.Name(),
+			BitOffset:  bitOffset,
+			Length:     a.Length,
+			A:          valueA,
+			B:          valueB,
+		},
+	)
+
+	return
+}
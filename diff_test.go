@@ -0,0 +1,337 @@
+package binary
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/encodingx/binary/pkg/rfc791"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	var (
+		b           []byte
+		differences []Difference
+		e           error
+	)
+
+	b = append([]byte{}, internetHeaderBytes...)
+
+	b[0] = 0b01000110 // IHL
+	b[5] = 0b00000001 // Identification
+	b[6] = 0b00011111 // FlagsBit1
+
+	differences, e = Diff(&internetHeaderStruct1, internetHeaderBytes, b)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]Difference{
+			{
+				Word:       "RFC791InternetHeaderFormatWord0",
+				ByteOffset: 0,
+				BitField:   "IHL",
+				BitOffset:  4,
+				Length:     4,
+				A:          uint64(5),
+				B:          uint64(6),
+			},
+			{
+				Word:       "RFC791InternetHeaderFormatWord1",
+				ByteOffset: 4,
+				BitField:   "Identification",
+				BitOffset:  0,
+				Length:     16,
+				A:          uint64(0),
+				B:          uint64(1),
+			},
+			{
+				Word:       "RFC791InternetHeaderFormatWord1",
+				ByteOffset: 4,
+				BitField:   "FlagsBit1",
+				BitOffset:  17,
+				Length:     1,
+				A:          true,
+				B:          false,
+			},
+		},
+		differences,
+	)
+
+	assert.Equal(t,
+		"RFC791InternetHeaderFormatWord1.FlagsBit1 [17]: true != false",
+		differences[2].String(),
+	)
+
+	differences, e = Diff(&internetHeaderStruct1,
+		internetHeaderBytes, internetHeaderBytes,
+	)
+
+	assert.Nil(t, e)
+
+	assert.Empty(t, differences)
+}
+
+func TestDiffStructs(t *testing.T) {
+	var (
+		b           rfc791.RFC791InternetHeaderFormatWithoutOptions
+		differences []Difference
+		e           error
+	)
+
+	b = internetHeaderStruct
+
+	b.TimeToLive = 64
+
+	differences, e = DiffStructs(&internetHeaderStruct, &b)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		1, len(differences),
+	)
+
+	assert.Equal(t,
+		"RFC791InternetHeaderFormatWord2.TimeToLive [0-7]: 1 != 64",
+		differences[0].String(),
+	)
+}
+
+func TestDiffStructsShouldQuoteStrings(t *testing.T) {
+	type (
+		Word struct {
+			Code string `bitfield:"24,trim"`
+			Kind uint8  `bitfield:"8"`
+		}
+
+		Format struct {
+			Word `word:"32"`
+		}
+	)
+
+	var (
+		differences []Difference
+		e           error
+	)
+
+	differences, e = DiffStructs(
+		&Format{
+			Word{"A1", 0},
+		},
+		&Format{
+			Word{"A2", 0},
+		},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]Difference{
+			{
+				Word:       "Word",
+				ByteOffset: 0,
+				BitField:   "Code",
+				BitOffset:  0,
+				Length:     24,
+				A:          "A1",
+				B:          "A2",
+			},
+		},
+		differences,
+	)
+
+	assert.Equal(t,
+		`Word.Code [0-23]: "A1" != "A2"`,
+		differences[0].String(),
+	)
+}
+
+func TestDiffShouldLayOutVarints(t *testing.T) {
+	// Words following variable-length integers
+	// are placed at their byte offsets in the first byte slice.
+
+	type (
+		Word struct {
+			Kind uint8 `bitfield:"8"`
+		}
+
+		Format struct {
+			Length uint16 `word:"14,quic"`
+			Delta  int8   `word:"4,zigzag"`
+			Word   `word:"8"`
+		}
+	)
+
+	var (
+		differences []Difference
+		e           error
+	)
+
+	differences, e = Diff(&Format{},
+		[]byte{0x7b, 0xbd, 0x03, 0x2a},
+		[]byte{0x05, 0x03, 0x2b},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]Difference{
+			{
+				Word:       "Length",
+				ByteOffset: 0,
+				BitField:   "Length",
+				BitOffset:  0,
+				Length:     14,
+				A:          uint64(15293),
+				B:          uint64(5),
+			},
+			{
+				Word:       "Word",
+				ByteOffset: 3,
+				BitField:   "Kind",
+				BitOffset:  0,
+				Length:     8,
+				A:          uint64(42),
+				B:          uint64(43),
+			},
+		},
+		differences,
+	)
+}
+
+func TestDiffShouldLayOutBitStreams(t *testing.T) {
+	// Bit fields of bit streams are placed
+	// at their bit positions in the first byte slice.
+
+	type (
+		Header struct {
+			Flag    bool  `bitfield:"1"`
+			CodeNum uint8 `bitfield:"4,ue"`
+			Delta   int8  `bitfield:"4,se"`
+		}
+
+		Word struct {
+			Kind uint8 `bitfield:"8"`
+		}
+
+		Format struct {
+			Header `word:"0,bitstream"`
+			Word   `word:"8"`
+		}
+	)
+
+	var (
+		differences []Difference
+		e           error
+	)
+
+	differences, e = Diff(&Format{},
+		[]byte{0x91, 0x80, 0x2a},
+		[]byte{0xd8, 0x2b},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]Difference{
+			{
+				Word:       "Header",
+				ByteOffset: 0,
+				BitField:   "CodeNum",
+				BitOffset:  1,
+				Length:     5,
+				A:          uint64(3),
+				B:          uint64(0),
+			},
+			{
+				Word:       "Word",
+				ByteOffset: 2,
+				BitField:   "Kind",
+				BitOffset:  0,
+				Length:     8,
+				A:          uint64(42),
+				B:          uint64(43),
+			},
+		},
+		differences,
+	)
+}
+
+func TestDiffShouldReturnErrorGivenLengthOfByteSliceNotEqualToFormatLength(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "Diff error: " +
+			"A byte slice into which a format-struct would be unmarshalled " +
+			"should be of length equal to the sum of lengths of words " +
+			"in the format represented by the struct. " +
+			"Argument to Diff points to a format-struct " +
+			"\"rfc791.RFC791InternetHeaderFormatWithoutOptions\" " +
+			"of length 20 byte(s) " +
+			"not equal to the length of the byte slice, 1 byte(s)."
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Diff(&internetHeaderStruct1, internetHeaderBytes, make([]byte, 1))
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestDiffStructsShouldReturnErrorGivenPointersToDifferentTypes(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "DiffStructs error: " +
+			"Arguments to DiffStructs should be pointers to format-structs " +
+			"of the same type. " +
+			"Arguments to DiffStructs point to variables of different types " +
+			"\"rfc791.RFC791InternetHeaderFormatWithoutOptions\" and " +
+			"\"v1p1.RFC791InternetHeaderFormatWithoutOptions\"."
+	)
+
+	var (
+		e error
+	)
+
+	_, e = DiffStructs(&internetHeaderStruct, &internetHeaderStructV1p1)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestDiffStructsShouldCompareBitFieldsLongerThan64Bits(t *testing.T) {
+	type (
+		Format struct {
+			Addr netip.Addr `word:"128"`
+		}
+	)
+
+	var (
+		differences []Difference
+		e           error
+	)
+
+	differences, e = DiffStructs(
+		&Format{
+			netip.MustParseAddr("2001:db8::1"),
+		},
+		&Format{
+			netip.MustParseAddr("2001:db8::2"),
+		},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		`Addr.Addr [0-127]: 2001:db8::1 != 2001:db8::2`,
+		differences[0].String(),
+	)
+
+	assert.Len(t, differences, 1)
+}
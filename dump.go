@@ -0,0 +1,351 @@
+package binary
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/encodingx/binary/internal/codecs"
+	"github.com/encodingx/binary/internal/codecs/metadata"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+func Dump(iface interface{}) (dump string, e error) {
+	const (
+		functionName = "Dump"
+	)
+
+	var (
+		bytes  []byte
+		format metadata.FormatMetadata
+		words  []metadata.WordMetadata
+	)
+
+	defer func() {
+		const (
+			dumpError = "Dump error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(dumpError, e)
+		}
+
+		return
+	}()
+
+	format, e = defaultCodec.FormatMetadata(iface)
+	if e != nil {
+		return
+	}
+
+	bytes, e = codecs.NewOperationFromFormatMetadata(format,
+		reflect.ValueOf(iface).Elem(),
+	).Marshal()
+	if e != nil {
+		return
+	}
+
+	words, e = format.Layout(bytes)
+	if e != nil {
+		return
+	}
+
+	dump = dumpFormat(format, words, bytes)
+
+	return
+}
+
+func DumpBytes(bytes []byte, iface interface{}) (dump string, e error) {
+	const (
+		functionName = "DumpBytes"
+	)
+
+	var (
+		format metadata.FormatMetadata
+		words  []metadata.WordMetadata
+	)
+
+	defer func() {
+		const (
+			dumpBytesError = "DumpBytes error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(dumpBytesError, e)
+		}
+
+		return
+	}()
+
+	format, e = defaultCodec.FormatMetadata(iface)
+	if e != nil {
+		return
+	}
+
+	words, e = format.Layout(bytes)
+	if e != nil {
+		return
+	}
+
+	dump = dumpFormat(format, words, bytes)
+
+	return
+}
+
+func dumpFormat(format metadata.FormatMetadata, words []metadata.WordMetadata,
+	bytes []byte,
+) string {
+	// Print each word with its byte offset, raw hexadecimal and binary,
+	// followed by each of its bit fields with bit range and decoded value.
+	// Bit ranges count from the most significant bit of a word.
+
+	var (
+		bitField  metadata.BitFieldMetadata
+		builder   strings.Builder
+		i         int
+		values    []metadata.BitFieldValue
+		violation string
+		word      metadata.WordMetadata
+		wordBytes []byte
+		writer    *tabwriter.Writer
+	)
+
+	fmt.Fprintf(&builder, "%s (%d byte(s))\n",
+		format.Name(), len(bytes),
+	)
+
+	writer = tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+
+	for _, word = range words {
+		wordBytes = bytes[word.ByteOffset() : word.ByteOffset()+
+			word.LengthInBytes()]
+
+		fmt.Fprintf(writer, "%04x  %s  % x  %s\n",
+			word.ByteOffset(), word.Name(), wordBytes,
+			strings.Trim(
+				fmt.Sprintf("%08b", wordBytes), "[]",
+			),
+		)
+
+		values = word.Values(bytes)
+
+		for i, bitField = range word.BitFields() {
+			if values[i].Bytes != nil {
+				fmt.Fprintf(writer, "      %s\t%s\t%s\n",
+					dumpBitRange(values[i].Position, values[i].Length),
+					bitField.Name(),
+					dumpBytes(bitField, values[i].Bytes),
+				)
+
+				continue
+			}
+
+			violation = dumpViolation(bitField, values[i].Value)
+
+			fmt.Fprintf(writer, "      %s\t%s\t%s",
+				dumpBitRange(values[i].Position, values[i].Length),
+				bitField.Name(),
+				dumpValue(bitField, values[i].Value),
+			)
+
+			if violation != "" {
+				fmt.Fprintf(writer, "\t%s", violation)
+			}
+
+			fmt.Fprintln(writer)
+		}
+	}
+
+	writer.Flush()
+
+	return builder.String()
+}
+
+func dumpBitRange(position, length uint) string {
+	if length == 1 {
+		return fmt.Sprintf("[%d]", position)
+	}
+
+	return fmt.Sprintf("[%d-%d]", position, position+length-1)
+}
+
+func dumpValue(bitField metadata.BitFieldMetadata, value uint64) string {
+	var (
+		decoded interface{}
+		name    string
+		number  uint64
+		ok      bool
+	)
+
+	number, ok = bitField.Decode(value)
+	if !ok {
+		return fmt.Sprintf("%#x", value)
+	}
+
+	name, ok = bitField.EnumeratedName(number)
+	if ok {
+		return fmt.Sprintf("%s(%d)", name, number)
+	}
+
+	if len(bitField.Flags()) > 0 {
+		return dumpFlags(bitField, value)
+	}
+
+	decoded = decodeValue(bitField, value)
+
+	// Strings are quoted, showing padding and unprintable bytes.
+
+	switch decoded.(type) {
+	case string:
+		return fmt.Sprintf("%q", decoded)
+	}
+
+	return fmt.Sprint(decoded)
+}
+
+func dumpBytes(bitField metadata.BitFieldMetadata, bytes []byte) string {
+	// Print bit fields longer than 64 bits (e.g. IPv6 addresses)
+	// as their type prints, or in hexadecimal.
+
+	var (
+		decoded interface{}
+	)
+
+	decoded = decodeBytes(bitField, bytes)
+
+	switch decoded.(type) {
+	case string:
+		return fmt.Sprintf("%q", decoded)
+
+	case []byte:
+		return fmt.Sprintf("% x", decoded)
+	}
+
+	return fmt.Sprint(decoded)
+}
+
+func dumpFlags(bitField metadata.BitFieldMetadata, value uint64) string {
+	// Print the flags set by name (e.g. DF|MF(3)),
+	// and bits set that are not flags as a number.
+
+	var (
+		names      []string
+		undeclared uint64
+	)
+
+	names, undeclared = bitField.FlagNames(value)
+
+	if undeclared != 0 {
+		names = append(names,
+			fmt.Sprintf("%#b", undeclared),
+		)
+	}
+
+	if len(names) == 0 {
+		return fmt.Sprint(value)
+	}
+
+	return fmt.Sprintf("%s(%d)", strings.Join(names, "|"), value)
+}
+
+func decodeValue(bitField metadata.BitFieldMetadata, value uint64) (
+	decoded interface{},
+) {
+	var (
+		number uint64
+		ok     bool
+	)
+
+	decoded, ok = bitField.TypedValue(value)
+	if ok {
+		return
+	}
+
+	decoded, ok = bitField.SignedValue(value)
+	if ok {
+		return
+	}
+
+	// Numbers coded as BCD or Gray code are decoded,
+	// and values that are not valid BCD are left as they are.
+
+	number, ok = bitField.Decode(value)
+	if ok {
+		value = number
+	}
+
+	if bitField.Type().Kind() == reflect.Bool {
+		decoded = value == 1
+
+		return
+	}
+
+	decoded = value
+
+	return
+}
+
+func decodeBytes(bitField metadata.BitFieldMetadata, bytes []byte) (
+	decoded interface{},
+) {
+	var (
+		ok bool
+	)
+
+	decoded, ok = bitField.TypedBytesValue(bytes)
+	if ok {
+		return
+	}
+
+	decoded = bytes
+
+	return
+}
+
+func dumpViolation(bitField metadata.BitFieldMetadata, value uint64) string {
+	var (
+		maximum    uint64
+		minimum    uint64
+		number     uint64
+		ok         bool
+		undeclared uint64
+	)
+
+	if bitField.Reserved() && value != 0 {
+		return "! reserved, should be zero"
+	}
+
+	number, ok = bitField.Decode(value)
+	if !ok {
+		return "! not binary-coded decimal"
+	}
+
+	value = number
+
+	minimum, ok = bitField.Minimum()
+	if ok && value < minimum {
+		return fmt.Sprintf("! less than minimum %d", minimum)
+	}
+
+	maximum, ok = bitField.Maximum()
+	if ok && value > maximum {
+		return fmt.Sprintf("! greater than maximum %d", maximum)
+	}
+
+	_, ok = bitField.EnumeratedName(value)
+	if !ok && len(bitField.Enumeration()) > 0 {
+		return "! not an enumerated value"
+	}
+
+	_, undeclared = bitField.FlagNames(value)
+	if undeclared != 0 && len(bitField.Flags()) > 0 {
+		return "! undeclared flags set"
+	}
+
+	return ""
+}
@@ -0,0 +1,443 @@
+package binary
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDump(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"rfc791.RFC791InternetHeaderFormatWithoutOptions (20 byte(s))\n" +
+			"0000  RFC791InternetHeaderFormatWord0  45 e8 ff ff  " +
+			"01000101 11101000 11111111 11111111\n" +
+			"      [0-3]    Version      4\n" +
+			"      [4-7]    IHL          5\n" +
+			"      [8-10]   Precedence   7\n" +
+			"      [11]     Delay        false\n" +
+			"      [12]     Throughput   true\n" +
+			"      [13]     Reliability  false\n" +
+			"      [14-15]  Reserved     0\n" +
+			"      [16-31]  TotalLength  65535\n" +
+			"0004  RFC791InternetHeaderFormatWord1  00 00 5f ff  " +
+			"00000000 00000000 01011111 11111111\n" +
+			"      [0-15]   Identification     0\n" +
+			"      [16]     FlagsBit0Reserved  false\n" +
+			"      [17]     FlagsBit1          true\n" +
+			"      [18]     FlagsBit2          false\n" +
+			"      [19-31]  FragmentOffset     8191\n" +
+			"0008  RFC791InternetHeaderFormatWord2  01 06 00 00  " +
+			"00000001 00000110 00000000 00000000\n" +
+			"      [0-7]    TimeToLive      1\n" +
+			"      [8-15]   Protocol        6\n" +
+			"      [16-31]  HeaderChecksum  0\n" +
+			"000c  RFC791InternetHeaderFormatWord3  aa cc f0 ff  " +
+			"10101010 11001100 11110000 11111111\n" +
+			"      [0-7]    SourceAddressOctet0  170\n" +
+			"      [8-15]   SourceAddressOctet1  204\n" +
+			"      [16-23]  SourceAddressOctet2  240\n" +
+			"      [24-31]  SourceAddressOctet3  255\n" +
+			"0010  RFC791InternetHeaderFormatWord4  55 33 0f 00  " +
+			"01010101 00110011 00001111 00000000\n" +
+			"      [0-7]    DestinationAddressOctet0  85\n" +
+			"      [8-15]   DestinationAddressOctet1  51\n" +
+			"      [16-23]  DestinationAddressOctet2  15\n" +
+			"      [24-31]  DestinationAddressOctet3  0\n"
+	)
+
+	var (
+		dump string
+		e    error
+	)
+
+	dump, e = Dump(&internetHeaderStruct)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+
+	dump, e = DumpBytes(internetHeaderBytes, &internetHeaderStruct1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+}
+
+func TestDumpBytesShouldFlagViolations(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"binary.Format (2 byte(s))\n" +
+			"0000  Word  e3 0f  11100011 00001111\n" +
+			"      [0-3]   Version   14  ! greater than maximum 9\n" +
+			"      [4-7]   IHL       3   ! less than minimum 5\n" +
+			"      [8]     Reserved  false\n" +
+			"      [9-15]  Zero      15  ! reserved, should be zero\n"
+	)
+
+	type (
+		Word struct {
+			Version  uint8 `bitfield:"4,max=9"`
+			IHL      uint8 `bitfield:"4,min=5"`
+			Reserved bool  `bitfield:"1,reserved"`
+			Zero     uint8 `bitfield:"7,reserved"`
+		}
+
+		Format struct {
+			Word `word:"16"`
+		}
+	)
+
+	var (
+		dump string
+		e    error
+	)
+
+	dump, e = DumpBytes([]byte{0xe3, 0x0f}, &Format{})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+}
+
+func TestDumpBytesShouldNameEnumeratedValues(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"binary.Format (2 byte(s))\n" +
+			"0000  Word  02 07  00000010 00000111\n" +
+			"      [0-7]   Kind   Alarm(2)\n" +
+			"      [8-15]  Cause  7  ! not an enumerated value\n"
+	)
+
+	type (
+		Word struct {
+			Kind  uint8 `bitfield:"8,enum=Reading:1|Alarm:2"`
+			Cause uint8 `bitfield:"8,enum=Power:1"`
+		}
+
+		Format struct {
+			Word `word:"16"`
+		}
+	)
+
+	var (
+		dump string
+		e    error
+	)
+
+	dump, e = DumpBytes([]byte{0x02, 0x07}, &Format{})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+}
+
+func TestDumpBytesShouldDecodeCodedNumbers(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"binary.Format (3 byte(s))\n" +
+			"0000  Word  59 5a ac  01011001 01011010 10101100\n" +
+			"      [0-7]    Seconds   59\n" +
+			"      [8-15]   Minutes   0x5a  ! not binary-coded decimal\n" +
+			"      [16-23]  Position  200   ! greater than maximum 199\n"
+	)
+
+	type (
+		Word struct {
+			Seconds  uint8 `bitfield:"8,bcd"`
+			Minutes  uint8 `bitfield:"8,bcd"`
+			Position uint8 `bitfield:"8,gray,max=199"`
+		}
+
+		Format struct {
+			Word `word:"24"`
+		}
+	)
+
+	var (
+		dump string
+		e    error
+	)
+
+	dump, e = DumpBytes([]byte{0x59, 0x5a, 0xac}, &Format{})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+}
+
+func TestDumpBytesShouldQuoteStrings(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"binary.Format (12 byte(s))\n" +
+			"0000  Name  52 45 41 44 4d 45 2e 6d 64 00 00 00  " +
+			"01010010 01000101 01000001 01000100 " +
+			"01001101 01000101 00101110 01101101 " +
+			"01100100 00000000 00000000 00000000\n" +
+			"      [0-95]  Name  \"README.md\"\n"
+	)
+
+	type (
+		Format struct {
+			Name string `word:"96,trim"`
+		}
+	)
+
+	var (
+		dump string
+		e    error
+	)
+
+	dump, e = DumpBytes([]byte("README.md\x00\x00\x00"), &Format{})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+}
+
+func TestDumpBytesShouldLayOutVarints(t *testing.T) {
+	// Bit ranges count bits of the integers held, not of their encodings.
+
+	const (
+		expectedDump = "" +
+			"binary.Format (4 byte(s))\n" +
+			"0000  Length  7b bd  01111011 10111101\n" +
+			"      [0-13]  Length  15293\n" +
+			"0002  Delta  03  00000011\n" +
+			"      [0-3]  Delta  -2\n" +
+			"0003  Word  2a  00101010\n" +
+			"      [0-7]  Kind  42\n"
+	)
+
+	type (
+		Word struct {
+			Kind uint8 `bitfield:"8"`
+		}
+
+		Format struct {
+			Length uint16 `word:"14,quic"`
+			Delta  int8   `word:"4,zigzag"`
+			Word   `word:"8"`
+		}
+	)
+
+	var (
+		dump string
+		e    error
+	)
+
+	dump, e = DumpBytes([]byte{0x7b, 0xbd, 0x03, 0x2a}, &Format{})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+}
+
+func TestDumpBytesShouldLayOutBitStreams(t *testing.T) {
+	// Bit ranges of Exp-Golomb codes span their codes.
+
+	const (
+		expectedDump = "" +
+			"binary.Format (3 byte(s))\n" +
+			"0000  Header  91 80  10010001 10000000\n" +
+			"      [0]    Flag     true\n" +
+			"      [1-5]  CodeNum  3\n" +
+			"      [6-8]  Delta    -1\n" +
+			"0002  Word  2a  00101010\n" +
+			"      [0-7]  Kind  42\n"
+	)
+
+	type (
+		Header struct {
+			Flag    bool  `bitfield:"1"`
+			CodeNum uint8 `bitfield:"4,ue"`
+			Delta   int8  `bitfield:"4,se"`
+		}
+
+		Word struct {
+			Kind uint8 `bitfield:"8"`
+		}
+
+		Format struct {
+			Header `word:"0,bitstream"`
+			Word   `word:"8"`
+		}
+	)
+
+	var (
+		dump string
+		e    error
+	)
+
+	dump, e = DumpBytes([]byte{0x91, 0x80, 0x2a}, &Format{})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+}
+
+func TestDumpBytesShouldReturnErrorGivenLengthOfByteSliceNotEqualToFormatLength(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "DumpBytes error: " +
+			"A byte slice into which a format-struct would be unmarshalled " +
+			"should be of length equal to the sum of lengths of words " +
+			"in the format represented by the struct. " +
+			"Argument to DumpBytes points to a format-struct " +
+			"\"rfc791.RFC791InternetHeaderFormatWithoutOptions\" " +
+			"of length 20 byte(s) " +
+			"not equal to the length of the byte slice, 1 byte(s)."
+	)
+
+	var (
+		e error
+	)
+
+	_, e = DumpBytes(make([]byte, 1), &internetHeaderStruct1)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenBitFieldWithMalformedConstraint(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field is represented " +
+			"by an exported field of a word-struct " +
+			"tagged with a key \"bitfield\" and a value " +
+			"indicating the length of the bit field in number of bits " +
+			"(e.g. `bitfield:\"1\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Word struct {
+			BitField uint `bitfield:"32,min=five"`
+		}
+
+		Format struct {
+			Word `word:"32"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenBitFieldWithMalformedEnumeration(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field is represented " +
+			"by an exported field of a word-struct " +
+			"tagged with a key \"bitfield\" and a value " +
+			"indicating the length of the bit field in number of bits " +
+			"(e.g. `bitfield:\"1\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Word struct {
+			BitField uint `bitfield:"32,enum=Reading|Alarm:2"`
+		}
+
+		Format struct {
+			Word `word:"32"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestDumpShouldPrintAddresses(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"000c  Source  aa cc f0 ff  10101010 11001100 11110000 11111111\n" +
+			"      [0-31]  Source  170.204.240.255\n" +
+			"0010  Destination  55 33 0f 00  " +
+			"01010101 00110011 00001111 00000000\n" +
+			"      [0-31]  Destination  85.51.15.0\n"
+	)
+
+	var (
+		dump string
+		e    error
+	)
+
+	dump, e = Dump(&internetHeaderStructNetip)
+
+	assert.Nil(t, e)
+
+	assert.True(t,
+		strings.HasSuffix(dump, expectedDump),
+	)
+}
+
+func TestDumpShouldPrintBitFieldsLongerThan64Bits(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"      [0-127]  Addr  2001:db8::1\n" +
+			"0010  Origin  00 00 00 00 0a 00 00 00 00 00  " +
+			"00000000 00000000 00000000 00000000 00001010 " +
+			"00000000 00000000 00000000 00000000 00000000\n" +
+			"      [0-79]  Origin  1970-01-01 00:00:10 +0000 UTC\n"
+	)
+
+	type (
+		Format struct {
+			Addr   netip.Addr `word:"128"`
+			Origin time.Time  `word:"80,ptp,littleendian"`
+		}
+	)
+
+	var (
+		dump string
+		e    error
+	)
+
+	dump, e = Dump(
+		&Format{
+			Addr:   netip.MustParseAddr("2001:db8::1"),
+			Origin: time.Unix(10, 0).UTC(),
+		},
+	)
+
+	assert.Nil(t, e)
+
+	assert.True(t,
+		strings.HasSuffix(dump, expectedDump), dump,
+	)
+}
@@ -0,0 +1,674 @@
+package binary
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/encodingx/binary/internal/codecs"
+	"github.com/encodingx/binary/internal/codecs/metadata"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+type DynamicFormat struct {
+	format     metadata.FormatMetadata
+	reflection reflect.Type
+	bitFields  map[string]dynamicBitFieldIndex
+}
+
+type dynamicBitFieldIndex struct {
+	word      int
+	bitField  int
+	wordField bool
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf(
+		(*encoding.TextUnmarshaler)(nil),
+	).Elem()
+)
+
+type Record []RecordField
+
+type RecordField struct {
+	Name  string
+	Value interface{}
+}
+
+func NewDynamicFormat(definition FormatDefinition) (
+	format DynamicFormat, e error,
+) {
+	const (
+		functionName = "NewDynamicFormat"
+	)
+
+	var (
+		bitField BitFieldDefinition
+		i        int
+		j        int
+		ok       bool
+		word     WordDefinition
+	)
+
+	defer func() {
+		const (
+			newDynamicFormatError = "NewDynamicFormat error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(newDynamicFormatError, e)
+		}
+
+		return
+	}()
+
+	format.format, format.reflection, e = definition.formatMetadata()
+	if e != nil {
+		return
+	}
+
+	// Values are keyed by bit field name alone,
+	// so names should be unique in the format and not only in each word.
+
+	format.bitFields = make(map[string]dynamicBitFieldIndex)
+
+	for i, word = range definition.Words {
+		for j, bitField = range word.BitFields {
+			_, ok = format.bitFields[bitField.Name]
+			if ok {
+				e = validation.NewBitFieldWithInvalidNameError()
+
+				e.(validation.BitFieldError).SetFormatName(definition.Name)
+
+				e.(validation.BitFieldError).SetWordName(word.Name)
+
+				e.(validation.BitFieldError).SetBitFieldName(bitField.Name)
+
+				return
+			}
+
+			format.bitFields[bitField.Name] = dynamicBitFieldIndex{i, j,
+				word.LongField(),
+			}
+		}
+	}
+
+	return
+}
+
+func (f DynamicFormat) Len() int {
+	return f.format.LengthInBytes()
+}
+
+func (f DynamicFormat) Describe() FormatDescriptor {
+	return newFormatDescriptor(f.format)
+}
+
+func (f DynamicFormat) DumpBytes(bytes []byte) (dump string, e error) {
+	const (
+		functionName = "DumpBytes"
+	)
+
+	var (
+		words []metadata.WordMetadata
+	)
+
+	defer func() {
+		const (
+			dumpBytesError = "DumpBytes error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(dumpBytesError, e)
+		}
+
+		return
+	}()
+
+	words, e = f.format.Layout(bytes)
+	if e != nil {
+		return
+	}
+
+	dump = dumpFormat(f.format, words, bytes)
+
+	return
+}
+
+func (f DynamicFormat) MarshalMap(values map[string]interface{}) (
+	bytes []byte, e error,
+) {
+	const (
+		functionName = "MarshalMap"
+	)
+
+	var (
+		name       string
+		reflection reflect.Value
+		value      interface{}
+	)
+
+	defer func() {
+		const (
+			marshalMapError = "MarshalMap error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(marshalMapError, e)
+		}
+
+		return
+	}()
+
+	reflection = reflect.New(f.reflection).Elem()
+
+	for name, value = range values {
+		e = f.setBitField(reflection, name, value)
+		if e != nil {
+			return
+		}
+	}
+
+	bytes, e = codecs.NewOperationFromFormatMetadata(f.format,
+		reflection,
+	).Marshal()
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (f DynamicFormat) UnmarshalMap(bytes []byte) (
+	values map[string]interface{}, e error,
+) {
+	const (
+		functionName = "UnmarshalMap"
+	)
+
+	defer func() {
+		const (
+			unmarshalMapError = "UnmarshalMap error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(unmarshalMapError, e)
+		}
+
+		return
+	}()
+
+	values = make(map[string]interface{})
+
+	e = f.unmarshal(bytes,
+		func(name string, value interface{}) {
+			values[name] = value
+		},
+	)
+	if e != nil {
+		values = nil
+
+		return
+	}
+
+	return
+}
+
+func (f DynamicFormat) MarshalRecord(record Record) (bytes []byte, e error) {
+	const (
+		functionName = "MarshalRecord"
+	)
+
+	var (
+		field      RecordField
+		reflection reflect.Value
+	)
+
+	defer func() {
+		const (
+			marshalRecordError = "MarshalRecord error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(marshalRecordError, e)
+		}
+
+		return
+	}()
+
+	reflection = reflect.New(f.reflection).Elem()
+
+	for _, field = range record {
+		e = f.setBitField(reflection, field.Name, field.Value)
+		if e != nil {
+			return
+		}
+	}
+
+	bytes, e = codecs.NewOperationFromFormatMetadata(f.format,
+		reflection,
+	).Marshal()
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (f DynamicFormat) UnmarshalRecord(bytes []byte) (
+	record Record, e error,
+) {
+	const (
+		functionName = "UnmarshalRecord"
+	)
+
+	defer func() {
+		const (
+			unmarshalRecordError = "UnmarshalRecord error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(unmarshalRecordError, e)
+		}
+
+		return
+	}()
+
+	e = f.unmarshal(bytes,
+		func(name string, value interface{}) {
+			record = append(record,
+				RecordField{name, value},
+			)
+		},
+	)
+	if e != nil {
+		record = nil
+
+		return
+	}
+
+	return
+}
+
+func (r Record) MarshalJSON() (bytes []byte, e error) {
+	// Encode a record as a JSON object that keeps the order of its fields.
+
+	var (
+		buffer  strings.Builder
+		element []byte
+		field   RecordField
+		i       int
+	)
+
+	buffer.WriteString("{")
+
+	for i, field = range r {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+
+		element, e = json.Marshal(field.Name)
+		if e != nil {
+			return
+		}
+
+		buffer.Write(element)
+
+		buffer.WriteString(":")
+
+		element, e = json.Marshal(field.Value)
+		if e != nil {
+			return
+		}
+
+		buffer.Write(element)
+	}
+
+	buffer.WriteString("}")
+
+	bytes = []byte(
+		buffer.String(),
+	)
+
+	return
+}
+
+func (f DynamicFormat) unmarshal(bytes []byte,
+	yield func(name string, value interface{}),
+) (
+	e error,
+) {
+	// Decode the bytes and yield the value of each bit field
+	// in the order of definition.
+
+	var (
+		bitField   metadata.BitFieldMetadata
+		reflection reflect.Value
+		word       metadata.WordMetadata
+	)
+
+	reflection = reflect.New(f.reflection).Elem()
+
+	e = codecs.NewOperationFromFormatMetadata(f.format,
+		reflection,
+	).Unmarshal(bytes)
+	if e != nil {
+		return
+	}
+
+	for _, word = range f.format.Words() {
+		for _, bitField = range word.BitFields() {
+			yield(bitField.Name(),
+				dynamicField(reflection,
+					f.bitFields[bitField.Name()],
+				).Interface(),
+			)
+		}
+	}
+
+	return
+}
+
+func (f DynamicFormat) setBitField(reflection reflect.Value,
+	name string, value interface{},
+) (
+	e error,
+) {
+	var (
+		bitField  metadata.BitFieldMetadata
+		converted reflect.Value
+		field     reflect.Value
+		index     dynamicBitFieldIndex
+		ok        bool
+		word      metadata.WordMetadata
+	)
+
+	index, ok = f.bitFields[name]
+	if !ok {
+		e = validation.NewValueForUnknownBitFieldError(name)
+
+		e.(validation.FormatError).SetFormatName(
+			f.format.Name(),
+		)
+
+		return
+	}
+
+	word = f.format.Words()[index.word]
+
+	bitField = word.BitFields()[index.bitField]
+
+	field = dynamicField(reflection, index)
+
+	value = enumeratedValue(bitField, value)
+
+	converted, ok = convertBitFieldValue(value,
+		field.Type(), bitField.Length(),
+	)
+	if !ok {
+		e = validation.NewBitFieldValueOfIncompatibleTypeError(
+			field.Type().String(),
+			fmt.Sprintf("%v of type \"%T\"", value, value),
+		)
+
+		e.(validation.BitFieldError).SetFormatName(
+			f.format.Name(),
+		)
+
+		e.(validation.BitFieldError).SetWordName(
+			word.Name(),
+		)
+
+		e.(validation.BitFieldError).SetBitFieldName(name)
+
+		return
+	}
+
+	field.Set(converted)
+
+	return
+}
+
+func dynamicField(reflection reflect.Value, index dynamicBitFieldIndex) (
+	field reflect.Value,
+) {
+	// Words declared as their bit fields (e.g. netip.Addr of 128 bits)
+	// are their own bit fields.
+
+	field = reflection.Field(index.word)
+
+	if index.wordField {
+		return
+	}
+
+	field = field.Field(index.bitField)
+
+	return
+}
+
+func enumeratedValue(bitField metadata.BitFieldMetadata, value interface{}) (
+	converted interface{},
+) {
+	// Accept the names of enumerated values in place of those values,
+	// as printed in dumps and records.
+
+	var (
+		enumerated metadata.EnumeratedValue
+		name       string
+		ok         bool
+	)
+
+	converted = value
+
+	name, ok = value.(string)
+	if !ok {
+		return
+	}
+
+	for _, enumerated = range bitField.Enumeration() {
+		if enumerated.Name == name {
+			converted = enumerated.Value
+
+			return
+		}
+	}
+
+	return
+}
+
+func convertBitFieldValue(value interface{}, reflection reflect.Type,
+	length uint,
+) (
+	converted reflect.Value, ok bool,
+) {
+	// Accept booleans for boolean bit fields, strings for strings,
+	// values of their types or text for others such as netip.Addr,
+	// and for others any integer, or integral float or number
+	// as decoded from JSON, that fits in the length of the bit field.
+
+	const (
+		numberBase    = 10
+		numberBitSize = 64
+	)
+
+	var (
+		float   float64
+		integer uint64
+		number  json.Number
+		e       error
+		valueOf reflect.Value
+	)
+
+	switch reflection.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		converted, ok = convertSignedBitFieldValue(value, reflection)
+
+		return
+	}
+
+	number, ok = value.(json.Number)
+	if ok {
+		value, e = strconv.ParseUint(number.String(),
+			numberBase, numberBitSize,
+		)
+		if e != nil {
+			ok = false
+
+			return
+		}
+	}
+
+	valueOf = reflect.ValueOf(value)
+
+	if !valueOf.IsValid() {
+		return
+	}
+
+	if valueOf.Type() == reflection {
+		converted, ok = valueOf, true
+
+		return
+	}
+
+	if valueOf.Kind() == reflect.String &&
+		reflect.PtrTo(reflection).Implements(textUnmarshalerType) {
+		converted = reflect.New(reflection)
+
+		e = converted.Interface().(encoding.TextUnmarshaler).UnmarshalText(
+			[]byte(
+				valueOf.String(),
+			),
+		)
+
+		converted, ok = converted.Elem(), e == nil
+
+		return
+	}
+
+	if reflection.Kind() == reflect.Bool {
+		ok = valueOf.Kind() == reflect.Bool
+
+		if ok {
+			converted = valueOf.Convert(reflection)
+		}
+
+		return
+	}
+
+	if reflection.Kind() == reflect.String {
+		ok = valueOf.Kind() == reflect.String
+
+		if ok {
+			converted = valueOf.Convert(reflection)
+		}
+
+		return
+	}
+
+	switch valueOf.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		integer = valueOf.Uint()
+		ok = true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		integer = uint64(valueOf.Int())
+		ok = valueOf.Int() >= 0
+
+	case reflect.Float32, reflect.Float64:
+		float = valueOf.Float()
+		integer = uint64(float)
+		ok = float >= 0 && float == math.Trunc(float) && float < math.MaxUint64
+	}
+
+	ok = ok && integer>>length == 0
+
+	if ok {
+		converted = reflect.ValueOf(integer).Convert(reflection)
+	}
+
+	return
+}
+
+func convertSignedBitFieldValue(value interface{}, reflection reflect.Type) (
+	converted reflect.Value, ok bool,
+) {
+	// Accept any integer, or integral float or number as decoded from JSON,
+	// that fits in 64 bits, leaving it to Marshal
+	// to refuse those not fitting the bit field in its encoding.
+
+	const (
+		numberBase    = 10
+		numberBitSize = 64
+	)
+
+	var (
+		e       error
+		float   float64
+		integer int64
+		number  json.Number
+		valueOf reflect.Value
+	)
+
+	number, ok = value.(json.Number)
+	if ok {
+		value, e = strconv.ParseInt(number.String(),
+			numberBase, numberBitSize,
+		)
+		if e != nil {
+			ok = false
+
+			return
+		}
+	}
+
+	valueOf = reflect.ValueOf(value)
+
+	if !valueOf.IsValid() {
+		return
+	}
+
+	switch valueOf.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		integer = int64(valueOf.Uint())
+		ok = valueOf.Uint() <= math.MaxInt64
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		integer = valueOf.Int()
+		ok = true
+
+	case reflect.Float32, reflect.Float64:
+		float = valueOf.Float()
+		integer = int64(float)
+		ok = float == math.Trunc(float) &&
+			float >= math.MinInt64 && float < math.MaxInt64
+	}
+
+	ok = ok && !reflect.Zero(reflection).OverflowInt(integer)
+
+	if ok {
+		converted = reflect.ValueOf(integer).Convert(reflection)
+	}
+
+	return
+}
@@ -0,0 +1,525 @@
+package binary
+
+import (
+	"encoding/json"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	exampleDefinition = FormatDefinition{
+		Name: "Example",
+		Words: []WordDefinition{
+			{
+				Name:   "ExampleWord0",
+				Length: 16,
+				BitFields: []BitFieldDefinition{
+					{Name: "Version", Length: 4, Type: "uint8"},
+					{Name: "Urgent", Length: 1, Type: "bool"},
+					{Name: "Reserved", Length: 3, Type: "uint8",
+						Options: []string{"reserved"},
+					},
+					{Name: "Kind", Length: 8, Type: "uint8"},
+				},
+			},
+			{
+				Name:   "ExampleWord1",
+				Length: 32,
+				BitFields: []BitFieldDefinition{
+					{Name: "Sequence", Length: 32, Type: "uint32"},
+				},
+			},
+		},
+	}
+
+	exampleBytes = []byte{
+		0x48, 0x2a, 0x00, 0x00, 0x01, 0x00,
+	}
+)
+
+func TestDynamicFormatMarshalMap(t *testing.T) {
+	var (
+		bytes  []byte
+		e      error
+		format DynamicFormat
+	)
+
+	format, e = NewDynamicFormat(exampleDefinition)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		6, format.Len(),
+	)
+
+	// Values decoded from JSON are float64, and missing values are zero.
+
+	bytes, e = format.MarshalMap(
+		map[string]interface{}{
+			"Version":  4,
+			"Urgent":   true,
+			"Kind":     float64(42),
+			"Sequence": uint32(256),
+		},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		exampleBytes, bytes,
+	)
+}
+
+func TestDynamicFormatUnmarshalMap(t *testing.T) {
+	var (
+		e      error
+		format DynamicFormat
+		values map[string]interface{}
+	)
+
+	format, e = NewDynamicFormat(exampleDefinition)
+
+	assert.Nil(t, e)
+
+	values, e = format.UnmarshalMap(exampleBytes)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		map[string]interface{}{
+			"Version":  uint8(4),
+			"Urgent":   true,
+			"Reserved": uint8(0),
+			"Kind":     uint8(42),
+			"Sequence": uint32(256),
+		},
+		values,
+	)
+
+	_, e = format.UnmarshalMap(exampleBytes[:4])
+
+	assert.NotNil(t, e)
+}
+
+func TestDynamicFormatRecord(t *testing.T) {
+	var (
+		bytes  []byte
+		e      error
+		format DynamicFormat
+		record Record
+	)
+
+	format, e = NewDynamicFormat(exampleDefinition)
+
+	assert.Nil(t, e)
+
+	record, e = format.UnmarshalRecord(exampleBytes)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		Record{
+			{"Version", uint8(4)},
+			{"Urgent", true},
+			{"Reserved", uint8(0)},
+			{"Kind", uint8(42)},
+			{"Sequence", uint32(256)},
+		},
+		record,
+	)
+
+	bytes, e = format.MarshalRecord(record)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		exampleBytes, bytes,
+	)
+}
+
+func TestDynamicFormatMarshalMapErrors(t *testing.T) {
+	var (
+		e      error
+		format DynamicFormat
+		value  interface{}
+	)
+
+	format, e = NewDynamicFormat(exampleDefinition)
+
+	assert.Nil(t, e)
+
+	_, e = format.MarshalMap(
+		map[string]interface{}{
+			"Checksum": 0,
+		},
+	)
+
+	assert.Equal(t,
+		"MarshalMap error: "+
+			"Values should be given only for bit fields in a format. "+
+			"Argument to MarshalMap gives a value for a bit field "+
+			"\"Checksum\" not in format \"Example\".",
+		e.Error(),
+	)
+
+	_, e = format.MarshalMap(
+		map[string]interface{}{
+			"Version": 16,
+		},
+	)
+
+	assert.Equal(t,
+		"MarshalMap error: "+
+			"A value given for a bit field "+
+			"should be convertible to the type of the bit field "+
+			"without loss. "+
+			"Argument to MarshalMap points to a format \"Example\" "+
+			"nesting a word \"ExampleWord0\" "+
+			"that has a bit field \"Version\" "+
+			"of type \"uint8\" given a value 16 of type \"int\".",
+		e.Error(),
+	)
+
+	for _, value = range []interface{}{-1, 1.5, "4", true, nil} {
+		_, e = format.MarshalMap(
+			map[string]interface{}{
+				"Version": value,
+			},
+		)
+
+		assert.NotNil(t, e)
+	}
+
+	_, e = format.MarshalMap(
+		map[string]interface{}{
+			"Urgent": 1,
+		},
+	)
+
+	assert.NotNil(t, e)
+}
+
+func TestDynamicFormatMarshalMapGivenEnumeratedNames(t *testing.T) {
+	var (
+		bytes      []byte
+		definition = FormatDefinition{
+			Name: "Telemetry",
+			Words: []WordDefinition{
+				{
+					Name:   "TelemetryWord0",
+					Length: 8,
+					BitFields: []BitFieldDefinition{
+						{Name: "Kind", Length: 8, Type: "uint8",
+							Options: []string{"enum=Reading:1|Alarm:2"},
+						},
+					},
+				},
+			},
+		}
+		e      error
+		format DynamicFormat
+	)
+
+	format, e = NewDynamicFormat(definition)
+
+	assert.Nil(t, e)
+
+	bytes, e = format.MarshalMap(
+		map[string]interface{}{
+			"Kind": "Alarm",
+		},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0x02}, bytes,
+	)
+
+	_, e = format.MarshalMap(
+		map[string]interface{}{
+			"Kind": "Alert",
+		},
+	)
+
+	assert.NotNil(t, e)
+}
+
+func TestDynamicFormatSignedIntegers(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"Sensor (2 byte(s))\n" +
+			"0000  SensorWord0  85 fb  10000101 11111011\n" +
+			"      [0-7]   Offset       -5\n" +
+			"      [8-15]  Temperature  -5\n"
+	)
+
+	var (
+		bytes      []byte
+		definition = FormatDefinition{
+			Name: "Sensor",
+			Words: []WordDefinition{
+				{
+					Name:   "SensorWord0",
+					Length: 16,
+					BitFields: []BitFieldDefinition{
+						{Name: "Offset", Length: 8, Type: "int8",
+							Options: []string{"signmagnitude"},
+						},
+						{Name: "Temperature", Length: 8, Type: "int8"},
+					},
+				},
+			},
+		}
+		dump   string
+		e      error
+		format DynamicFormat
+		values map[string]interface{}
+	)
+
+	format, e = NewDynamicFormat(definition)
+
+	assert.Nil(t, e)
+
+	bytes, e = format.MarshalMap(
+		map[string]interface{}{
+			"Offset":      json.Number("-5"),
+			"Temperature": -5.0,
+		},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0x85, 0xfb}, bytes,
+	)
+
+	values, e = format.UnmarshalMap(bytes)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		map[string]interface{}{
+			"Offset":      int8(-5),
+			"Temperature": int8(-5),
+		},
+		values,
+	)
+
+	dump, e = format.DumpBytes(bytes)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+
+	_, e = format.MarshalMap(
+		map[string]interface{}{
+			"Offset": -128,
+		},
+	)
+
+	assert.NotNil(t, e)
+}
+
+func TestNewDynamicFormatErrors(t *testing.T) {
+	var (
+		definition FormatDefinition
+		e          error
+	)
+
+	definition = FormatDefinition{
+		Name: "Example",
+		Words: []WordDefinition{
+			exampleDefinition.Words[0],
+			exampleDefinition.Words[0],
+		},
+	}
+
+	definition.Words[1].Name = "ExampleWord1"
+
+	_, e = NewDynamicFormat(definition)
+
+	assert.Equal(t,
+		"NewDynamicFormat error: "+
+			"A bit field should be named by an exported identifier "+
+			"unique in its word, or in its format if defined at run time. "+
+			"Argument to NewDynamicFormat points to a format \"Example\" "+
+			"nesting a word \"ExampleWord1\" "+
+			"that has a bit field \"Version\" "+
+			"whose name is not a unique exported identifier.",
+		e.Error(),
+	)
+
+	definition = FormatDefinition{
+		Name: "Example",
+		Words: []WordDefinition{
+			exampleDefinition.Words[0],
+		},
+	}
+
+	definition.Words[0].Length = 24
+
+	_, e = NewDynamicFormat(definition)
+
+	assert.NotNil(t, e)
+}
+
+func TestDynamicFormatDumpBytes(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"Example (6 byte(s))\n" +
+			"0000  ExampleWord0  48 2a  01001000 00101010\n" +
+			"      [0-3]   Version   4\n" +
+			"      [4]     Urgent    true\n" +
+			"      [5-7]   Reserved  0\n" +
+			"      [8-15]  Kind      42\n" +
+			"0002  ExampleWord1  00 00 01 00  " +
+			"00000000 00000000 00000001 00000000\n" +
+			"      [0-31]  Sequence  256\n"
+	)
+
+	var (
+		dump   string
+		e      error
+		format DynamicFormat
+	)
+
+	format, e = NewDynamicFormat(exampleDefinition)
+
+	assert.Nil(t, e)
+
+	dump, e = format.DumpBytes(exampleBytes)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+
+	_, e = format.DumpBytes(exampleBytes[:1])
+
+	assert.NotNil(t, e)
+}
+
+func TestRecordJSON(t *testing.T) {
+	var (
+		bytes   []byte
+		decoder *json.Decoder
+		e       error
+		format  DynamicFormat
+		record  Record
+		values  map[string]interface{}
+	)
+
+	format, e = NewDynamicFormat(exampleDefinition)
+
+	assert.Nil(t, e)
+
+	record, e = format.UnmarshalRecord(exampleBytes)
+
+	assert.Nil(t, e)
+
+	bytes, e = json.Marshal(record)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		`{"Version":4,"Urgent":true,"Reserved":0,"Kind":42,"Sequence":256}`,
+		string(bytes),
+	)
+
+	decoder = json.NewDecoder(
+		strings.NewReader(
+			string(bytes),
+		),
+	)
+
+	decoder.UseNumber()
+
+	e = decoder.Decode(&values)
+
+	assert.Nil(t, e)
+
+	bytes, e = format.MarshalMap(values)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		exampleBytes, bytes,
+	)
+}
+
+func TestDynamicFormatNetworkTypes(t *testing.T) {
+	var (
+		bytes     []byte
+		e         error
+		format    DynamicFormat
+		textBytes []byte
+		values    map[string]interface{}
+	)
+
+	format, e = NewDynamicFormat(
+		FormatDefinition{
+			Name: "Neighbour",
+			Words: []WordDefinition{
+				{
+					Name:   "Addr",
+					Length: 128,
+					BitFields: []BitFieldDefinition{
+						{Name: "Addr", Length: 128, Type: "netip.Addr"},
+					},
+				},
+				{
+					Name:   "NeighbourWord1",
+					Length: 48,
+					BitFields: []BitFieldDefinition{
+						{Name: "Link", Length: 48, Type: "[6]byte"},
+					},
+				},
+			},
+		},
+	)
+
+	assert.Nil(t, e)
+
+	bytes, e = format.MarshalMap(
+		map[string]interface{}{
+			"Addr": netip.MustParseAddr("fe80::1"),
+			"Link": [6]byte{0x02, 0x00, 0x5e, 0x10, 0x00, 0x01},
+		},
+	)
+
+	assert.Nil(t, e)
+
+	values, e = format.UnmarshalMap(bytes)
+
+	assert.Nil(t, e)
+
+	// Addresses may also be given as text, as decoded from JSON.
+
+	textBytes, e = format.MarshalMap(
+		map[string]interface{}{
+			"Addr": "fe80::1",
+			"Link": [6]byte{0x02, 0x00, 0x5e, 0x10, 0x00, 0x01},
+		},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		bytes, textBytes,
+	)
+
+	assert.Equal(t,
+		map[string]interface{}{
+			"Addr": netip.MustParseAddr("fe80::1"),
+			"Link": [6]byte{0x02, 0x00, 0x5e, 0x10, 0x00, 0x01},
+		},
+		values,
+	)
+}
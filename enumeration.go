@@ -0,0 +1,66 @@
+package binary
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/encodingx/binary/internal/codecs/metadata"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+func RegisterEnumeration[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](
+	enumeration ...EnumeratedValue,
+) (
+	e error,
+) {
+	// Name the values of bit fields of type T,
+	// as would the option "enum=NAME:N|NAME:N" in the tag of each,
+	// so that dumps, descriptions and records print those names
+	// and the option "strict" rejects other values.
+	// Enumerations should be registered before first use of their types,
+	// e.g. in func init, and are validated with the formats using them.
+	// Registering for a type already used returns an error,
+	// since codecs keep the metadata of formats using it.
+
+	const (
+		functionName = "RegisterEnumeration"
+	)
+
+	var (
+		enumerated EnumeratedValue
+		values     []metadata.EnumeratedValue
+	)
+
+	defer func() {
+		const (
+			registerEnumerationError = "RegisterEnumeration error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(registerEnumerationError, e)
+		}
+
+		return
+	}()
+
+	for _, enumerated = range enumeration {
+		values = append(values,
+			metadata.EnumeratedValue{
+				Name:  enumerated.Name,
+				Value: enumerated.Value,
+			},
+		)
+	}
+
+	e = metadata.RegisterEnumeration(
+		reflect.TypeOf((*T)(nil)).Elem(),
+		values,
+	)
+	if e != nil {
+		return
+	}
+
+	return
+}
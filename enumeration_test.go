@@ -0,0 +1,226 @@
+package binary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterEnumeration(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"binary.Format (2 byte(s))\n" +
+			"0000  Word  02 07  00000010 00000111\n" +
+			"      [0-7]   Kind   Alarm(2)\n" +
+			"      [8-15]  Cause  7  ! not an enumerated value\n"
+	)
+
+	type (
+		Kind  uint8
+		Cause uint16
+
+		Word struct {
+			Kind  Kind  `bitfield:"8"`
+			Cause Cause `bitfield:"8,enum=Power:1"`
+		}
+
+		Format struct {
+			Word `word:"16"`
+		}
+	)
+
+	var (
+		descriptor FormatDescriptor
+		dump       string
+		e          error
+	)
+
+	RegisterEnumeration[Kind](
+		EnumeratedValue{"Reading", 1},
+		EnumeratedValue{"Alarm", 2},
+	)
+
+	// The option "enum=" takes precedence over registered enumerations.
+
+	RegisterEnumeration[Cause](
+		EnumeratedValue{"Overheat", 7},
+	)
+
+	dump, e = DumpBytes([]byte{0x02, 0x07}, &Format{})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+
+	descriptor, e = Describe(&Format{})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]EnumeratedValue{{"Reading", 1}, {"Alarm", 2}},
+		descriptor.Words()[0].BitFields()[0].Enumeration(),
+	)
+}
+
+func TestUnmarshalStrictEnumeration(t *testing.T) {
+	const (
+		errorMessage = "Unmarshal error: " +
+			"A bit field with the option \"strict\" " +
+			"should hold one of its enumerated values. " +
+			"Argument to Unmarshal points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"Kind\" " +
+			"holding a value 3 not enumerated."
+	)
+
+	type (
+		Kind uint8
+
+		Word struct {
+			Version uint8 `bitfield:"8"`
+			Kind    Kind  `bitfield:"8,strict"`
+		}
+
+		Format struct {
+			Word `word:"16"`
+		}
+	)
+
+	var (
+		e      error
+		format Format
+	)
+
+	RegisterEnumeration[Kind](
+		EnumeratedValue{"Reading", 1},
+		EnumeratedValue{"Alarm", 2},
+	)
+
+	e = Unmarshal([]byte{0x04, 0x02}, &format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		Kind(2), format.Kind,
+	)
+
+	e = Unmarshal([]byte{0x04, 0x03}, &format)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenBitFieldWithMalformedRegisteredEnumeration(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"Enumerated values registered for the type of a bit field " +
+			"should have unique names that are identifiers " +
+			"and values that fit the bit field. " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of type \"binary.Kind\" with enumerated values that do not: " +
+			"value 16 of Alarm overflowing 4 bit(s)."
+	)
+
+	type (
+		Kind uint8
+
+		Word struct {
+			BitField Kind  `bitfield:"4"`
+			Reserved uint8 `bitfield:"4"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	RegisterEnumeration[Kind](
+		EnumeratedValue{"Reading", 1},
+		EnumeratedValue{"Alarm", 16},
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenStrictBitFieldWithNoEnumeration(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"A bit field is represented " +
+			"by an exported field of a word-struct " +
+			"tagged with a key \"bitfield\" and a value " +
+			"indicating the length of the bit field in number of bits " +
+			"(e.g. `bitfield:\"1\"`). " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"with a malformed struct tag."
+	)
+
+	type (
+		Word struct {
+			BitField uint8 `bitfield:"8,strict"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenEnumerationRegisteredAfterUse(t *testing.T) {
+	// Codecs keep the metadata of formats,
+	// so names registered after first use of a type would be ignored.
+
+	const (
+		errorMessage = "RegisterEnumeration error: " +
+			"Type parameter to RegisterEnumeration should be registered " +
+			"before first use of the type (e.g. in func init). " +
+			"Type parameter \"binary.Kind\" to RegisterEnumeration " +
+			"is used already."
+	)
+
+	type (
+		Kind uint8
+
+		Word struct {
+			Kind Kind `bitfield:"8"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Marshal(&Format{})
+
+	assert.Nil(t, e)
+
+	e = RegisterEnumeration[Kind](
+		EnumeratedValue{"Reading", 1},
+	)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
@@ -0,0 +1,70 @@
+package binary
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/encodingx/binary/internal/codecs/metadata"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+type Flag struct {
+	Name  string
+	Value uint64
+}
+
+func RegisterFlags[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](
+	flags ...Flag,
+) (
+	e error,
+) {
+	// Name single bits of bit fields of type T as flags (e.g. DF:0b010),
+	// so that dumps and diffs show each flag of such bit fields,
+	// and the option "strict" rejects bits set that are not flags.
+	// Flags should be registered before first use of their types,
+	// e.g. in func init, and are validated with the formats using them.
+	// Registering for a type already used returns an error,
+	// since codecs keep the metadata of formats using it.
+
+	const (
+		functionName = "RegisterFlags"
+	)
+
+	var (
+		flag   Flag
+		values []metadata.EnumeratedValue
+	)
+
+	defer func() {
+		const (
+			registerFlagsError = "RegisterFlags error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(registerFlagsError, e)
+		}
+
+		return
+	}()
+
+	for _, flag = range flags {
+		values = append(values,
+			metadata.EnumeratedValue{
+				Name:  flag.Name,
+				Value: flag.Value,
+			},
+		)
+	}
+
+	e = metadata.RegisterFlags(
+		reflect.TypeOf((*T)(nil)).Elem(),
+		values,
+	)
+	if e != nil {
+		return
+	}
+
+	return
+}
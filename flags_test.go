@@ -0,0 +1,254 @@
+package binary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// Flags of RFC 791, of which bit 0 is reserved
+
+	testIPFlags uint8
+
+	testIPFlagsWord struct {
+		Identification uint16      `bitfield:"16"`
+		Flags          testIPFlags `bitfield:"3"`
+		FragmentOffset uint16      `bitfield:"13"`
+	}
+
+	testIPFlagsFormat struct {
+		testIPFlagsWord `word:"32"`
+	}
+)
+
+const (
+	testIPFlagsDF testIPFlags = 0b010
+	testIPFlagsMF testIPFlags = 0b001
+)
+
+func init() {
+	RegisterFlags[testIPFlags](
+		Flag{"DF", uint64(testIPFlagsDF)},
+		Flag{"MF", uint64(testIPFlagsMF)},
+	)
+}
+
+func TestMarshalAndUnmarshalFlags(t *testing.T) {
+	var (
+		bytes   []byte
+		e       error
+		format  testIPFlagsFormat
+		format1 testIPFlagsFormat
+	)
+
+	format.Flags = testIPFlagsDF | testIPFlagsMF
+
+	format.Flags &^= testIPFlagsMF
+
+	bytes, e = Marshal(&format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0x00, 0x00, 0x40, 0x00}, bytes,
+	)
+
+	e = Unmarshal(bytes, &format1)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		testIPFlagsDF, format1.Flags,
+	)
+}
+
+func TestDumpBytesShouldNameFlags(t *testing.T) {
+	const (
+		expectedDump = "" +
+			"binary.testIPFlagsFormat (4 byte(s))\n" +
+			"0000  testIPFlagsWord  00 01 e0 00  " +
+			"00000000 00000001 11100000 00000000\n" +
+			"      [0-15]   Identification  1\n" +
+			"      [16-18]  Flags           DF|MF|0b100(7)  " +
+			"! undeclared flags set\n" +
+			"      [19-31]  FragmentOffset  0\n"
+	)
+
+	var (
+		dump string
+		e    error
+	)
+
+	dump, e = DumpBytes([]byte{0x00, 0x01, 0xe0, 0x00}, &testIPFlagsFormat{})
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		expectedDump, dump,
+	)
+}
+
+func TestDiffShouldCompareFlags(t *testing.T) {
+	var (
+		differences []Difference
+		e           error
+	)
+
+	differences, e = Diff(&testIPFlagsFormat{},
+		[]byte{0x00, 0x00, 0x40, 0x00},
+		[]byte{0x00, 0x00, 0xa0, 0x00},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]Difference{
+			{
+				Word:       "testIPFlagsWord",
+				ByteOffset: 0,
+				BitField:   "Flags.DF",
+				BitOffset:  17,
+				Length:     1,
+				A:          true,
+				B:          false,
+			},
+			{
+				Word:       "testIPFlagsWord",
+				ByteOffset: 0,
+				BitField:   "Flags.MF",
+				BitOffset:  18,
+				Length:     1,
+				A:          false,
+				B:          true,
+			},
+			{
+				Word:       "testIPFlagsWord",
+				ByteOffset: 0,
+				BitField:   "Flags",
+				BitOffset:  16,
+				Length:     3,
+				A:          uint64(2),
+				B:          uint64(5),
+			},
+		},
+		differences,
+	)
+
+	assert.Equal(t,
+		"testIPFlagsWord.Flags.DF [17]: true != false",
+		differences[0].String(),
+	)
+}
+
+func TestUnmarshalStrictFlags(t *testing.T) {
+	const (
+		errorMessage = "Unmarshal error: " +
+			"A bit field of flags with the option \"strict\" " +
+			"should have no bits set other than its flags. " +
+			"Argument to Unmarshal points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"Flags\" " +
+			"with undeclared bits 0b100 set."
+	)
+
+	type (
+		Word struct {
+			Flags    testIPFlags `bitfield:"3,strict"`
+			Reserved uint8       `bitfield:"5"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	var (
+		e      error
+		format Format
+	)
+
+	e = Unmarshal([]byte{0b01100000}, &format)
+
+	assert.Nil(t, e)
+
+	e = Unmarshal([]byte{0b10100000}, &format)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestShouldReturnErrorGivenBitFieldWithMalformedFlags(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"Flags registered for the type of a bit field " +
+			"should have unique names that are identifiers " +
+			"and values that are distinct single bits of the bit field. " +
+			"Argument to %[1]s points to a format-struct \"binary.Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of type \"binary.Flags\" with flags that do not: " +
+			"value 0x3 of Both not a single bit."
+	)
+
+	type (
+		Flags uint8
+
+		Word struct {
+			BitField Flags `bitfield:"8"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	RegisterFlags[Flags](
+		Flag{"Low", 0b01},
+		Flag{"Both", 0b11},
+	)
+
+	testShouldReturnErrorGiven(t,
+		&Format{},
+		errorMessage,
+	)
+}
+
+func TestShouldReturnErrorGivenFlagsRegisteredAfterUse(t *testing.T) {
+	const (
+		errorMessage = "RegisterFlags error: " +
+			"Type parameter to RegisterFlags should be registered " +
+			"before first use of the type (e.g. in func init). " +
+			"Type parameter \"binary.Flags\" to RegisterFlags " +
+			"is used already."
+	)
+
+	type (
+		Flags uint8
+
+		Word struct {
+			BitField Flags `bitfield:"8"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Marshal(&Format{})
+
+	assert.Nil(t, e)
+
+	e = RegisterFlags[Flags](
+		Flag{"DF", 0b010},
+	)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
@@ -0,0 +1,141 @@
+package binary
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/encodingx/binary/internal/codecs"
+	"github.com/encodingx/binary/internal/codecs/metadata"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+type Format[T any] struct {
+	format metadata.FormatMetadata
+}
+
+func FormatOf[T any]() (format Format[T], e error) {
+	const (
+		functionName = "FormatOf"
+	)
+
+	defer func() {
+		const (
+			formatOfError = "FormatOf error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(formatOfError, e)
+		}
+
+		return
+	}()
+
+	format.format, e = codecs.NewFormatMetadataFromTypeParameter(
+		reflect.TypeOf((*T)(nil)).Elem(),
+	)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (f Format[T]) Marshal(pointer *T) (bytes []byte, e error) {
+	const (
+		functionName = "Marshal"
+	)
+
+	var (
+		generated GeneratedFormat
+		ok        bool
+	)
+
+	defer func() {
+		const (
+			marshalError = "Marshal error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(marshalError, e)
+		}
+
+		return
+	}()
+
+	if pointer == nil {
+		e = validation.NewNilPointerError()
+
+		return
+	}
+
+	generated, ok = generatedFormat(pointer)
+	if ok {
+		bytes, e = marshalGenerated(generated, f.format.Name())
+
+		return
+	}
+
+	bytes, e = codecs.NewOperationFromFormatMetadata(f.format,
+		reflect.ValueOf(pointer).Elem(),
+	).Marshal()
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (f Format[T]) Unmarshal(bytes []byte, pointer *T) (e error) {
+	const (
+		functionName = "Unmarshal"
+	)
+
+	var (
+		generated GeneratedFormat
+		ok        bool
+	)
+
+	defer func() {
+		const (
+			unmarshalError = "Unmarshal error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(unmarshalError, e)
+		}
+
+		return
+	}()
+
+	if pointer == nil {
+		e = validation.NewNilPointerError()
+
+		return
+	}
+
+	generated, ok = generatedFormat(pointer)
+	if ok {
+		e = unmarshalGenerated(generated, f.format.Name(), bytes)
+
+		return
+	}
+
+	e = codecs.NewOperationFromFormatMetadata(f.format,
+		reflect.ValueOf(pointer).Elem(),
+	).Unmarshal(bytes)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (f Format[T]) Len() int {
+	return f.format.LengthInBytes()
+}
@@ -0,0 +1,210 @@
+package binary
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/encodingx/binary/pkg/rfc791"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatOfMarshal(t *testing.T) {
+	var (
+		bytes  []byte
+		e      error
+		format Format[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+	)
+
+	format, e = FormatOf[rfc791.RFC791InternetHeaderFormatWithoutOptions]()
+
+	assert.Nil(t, e)
+
+	bytes, e = format.Marshal(&internetHeaderStruct)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		internetHeaderBytes, bytes,
+	)
+}
+
+func BenchmarkFormatOfMarshal(b *testing.B) {
+	var (
+		e      error
+		format Format[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+		i      int
+	)
+
+	format, e = FormatOf[rfc791.RFC791InternetHeaderFormatWithoutOptions]()
+	if e != nil {
+		b.Fatal(e)
+	}
+
+	for i = 0; i < b.N; i++ {
+		_, e = format.Marshal(&internetHeaderStruct)
+		if e != nil {
+			b.Error(e)
+		}
+	}
+}
+
+func TestFormatOfUnmarshal(t *testing.T) {
+	var (
+		e      error
+		format Format[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+		header rfc791.RFC791InternetHeaderFormatWithoutOptions
+	)
+
+	format, e = FormatOf[rfc791.RFC791InternetHeaderFormatWithoutOptions]()
+
+	assert.Nil(t, e)
+
+	e = format.Unmarshal(internetHeaderBytes, &header)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		internetHeaderStruct, header,
+	)
+}
+
+func BenchmarkFormatOfUnmarshal(b *testing.B) {
+	var (
+		e      error
+		format Format[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+		header rfc791.RFC791InternetHeaderFormatWithoutOptions
+		i      int
+	)
+
+	format, e = FormatOf[rfc791.RFC791InternetHeaderFormatWithoutOptions]()
+	if e != nil {
+		b.Fatal(e)
+	}
+
+	for i = 0; i < b.N; i++ {
+		e = format.Unmarshal(internetHeaderBytes, &header)
+		if e != nil {
+			b.Error(e)
+		}
+	}
+}
+
+func TestFormatOfLen(t *testing.T) {
+	const (
+		formatLengthInBytes = 20
+	)
+
+	var (
+		e      error
+		format Format[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+	)
+
+	format, e = FormatOf[rfc791.RFC791InternetHeaderFormatWithoutOptions]()
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		formatLengthInBytes, format.Len(),
+	)
+}
+
+func TestFormatOfShouldReturnErrorGivenTypeParameterNotStruct(t *testing.T) {
+	const (
+		errorMessage = "FormatOf error: " +
+			"Type parameter to FormatOf should be a format-struct. " +
+			"Type parameter \"map[string]int\" to FormatOf is not a struct."
+	)
+
+	var (
+		e error
+	)
+
+	_, e = FormatOf[map[string]int]()
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestFormatOfShouldReturnErrorGivenFormatWithNoWords(t *testing.T) {
+	const (
+		errorMessage = "FormatOf error: " +
+			"A format-struct should nest exported word-structs. " +
+			"Argument to FormatOf points to a format-struct \"binary.Format\" " +
+			"that has no words."
+	)
+
+	type (
+		Format struct{}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = FormatOf[Format]()
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestFormatOfShouldReturnErrorGivenNilPointer(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"Argument to %[1]s should be a pointer to a format-struct. " +
+			"Argument to %[1]s is a nil pointer."
+	)
+
+	var (
+		e      error
+		format Format[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+	)
+
+	format, e = FormatOf[rfc791.RFC791InternetHeaderFormatWithoutOptions]()
+
+	assert.Nil(t, e)
+
+	_, e = format.Marshal(nil)
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage, "Marshal"), e.Error(),
+	)
+
+	e = format.Unmarshal(internetHeaderBytes, nil)
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage, "Unmarshal"), e.Error(),
+	)
+}
+
+func TestFormatOfShouldReturnErrorGivenLengthOfByteSliceNotEqualToFormatLength(
+	t *testing.T,
+) {
+	const (
+		errorMessage = "Unmarshal error: " +
+			"A byte slice into which a format-struct would be unmarshalled " +
+			"should be of length equal to the sum of lengths of words " +
+			"in the format represented by the struct. " +
+			"Argument to Unmarshal points to a format-struct " +
+			"\"rfc791.RFC791InternetHeaderFormatWithoutOptions\" " +
+			"of length 20 byte(s) " +
+			"not equal to the length of the byte slice, 1 byte(s)."
+	)
+
+	var (
+		e      error
+		format Format[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+		header rfc791.RFC791InternetHeaderFormatWithoutOptions
+	)
+
+	format, e = FormatOf[rfc791.RFC791InternetHeaderFormatWithoutOptions]()
+
+	assert.Nil(t, e)
+
+	e = format.Unmarshal(make([]byte, 1), &header)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
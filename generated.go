@@ -0,0 +1,87 @@
+package binary
+
+import (
+	"encoding"
+	"reflect"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+type GeneratedFormat interface {
+	// Implemented by pointers to format-structs
+	// with methods generated by command binary-gen,
+	// which Marshal and Unmarshal call instead of reflecting.
+
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	AppendBinary(bytes []byte) ([]byte, error)
+	BinaryFormatLength() int
+}
+
+func generatedFormat(iface interface{}) (format GeneratedFormat, ok bool) {
+	// Nil pointers are left to reflection, which reports them.
+
+	var (
+		value reflect.Value
+	)
+
+	format, ok = iface.(GeneratedFormat)
+	if !ok {
+		return
+	}
+
+	value = reflect.ValueOf(iface)
+
+	ok = value.Kind() == reflect.Ptr && !value.IsNil()
+
+	return
+}
+
+func marshalGenerated(generated GeneratedFormat, formatName string) (
+	bytes []byte, e error,
+) {
+	// Failures of generated methods are returned, not retried by reflection.
+
+	bytes, e = generated.MarshalBinary()
+	if e != nil {
+		bytes = nil
+
+		e = validation.NewGeneratedMethodFailedError(e)
+
+		e.(validation.FormatError).SetFormatName(formatName)
+
+		return
+	}
+
+	return
+}
+
+func unmarshalGenerated(generated GeneratedFormat, formatName string,
+	bytes []byte,
+) (
+	e error,
+) {
+	// Byte slices of wrong lengths are reported as by reflection.
+
+	if len(bytes) != generated.BinaryFormatLength() {
+		e = validation.NewLengthOfByteSliceNotEqualToFormatLengthError(
+			uint(generated.BinaryFormatLength()),
+			uint(len(bytes)),
+		)
+
+		e.(validation.FormatError).SetFormatName(formatName)
+
+		return
+	}
+
+	e = generated.UnmarshalBinary(bytes)
+	if e != nil {
+		e = validation.NewGeneratedMethodFailedError(e)
+
+		e.(validation.FormatError).SetFormatName(formatName)
+
+		return
+	}
+
+	return
+}
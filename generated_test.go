@@ -0,0 +1,184 @@
+package binary
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/encodingx/binary/internal/codecs"
+	"github.com/encodingx/binary/pkg/rfc791"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratedMethodsShouldAgreeWithReflection(t *testing.T) {
+	const (
+		iterations = 1000
+		seed       = 791
+	)
+
+	var (
+		bytes          []byte
+		e              error
+		generated      rfc791.RFC791InternetHeaderFormatWithoutOptions
+		generatedBytes []byte
+		i              int
+		operation      codecs.CodecOperation
+		random         = rand.New(rand.NewSource(seed))
+		reflected      rfc791.RFC791InternetHeaderFormatWithoutOptions
+		reflectedBytes []byte
+	)
+
+	// Compare with the reflection path, which Marshal and Unmarshal bypass.
+
+	operation, e = defaultCodec.NewOperation(&reflected)
+
+	assert.Nil(t, e)
+
+	for i = 0; i < iterations; i++ {
+		bytes = make([]byte, generated.BinaryFormatLength())
+
+		random.Read(bytes)
+
+		e = operation.Unmarshal(bytes)
+
+		assert.Nil(t, e)
+
+		e = generated.UnmarshalBinary(bytes)
+
+		assert.Nil(t, e)
+
+		assert.Equal(t,
+			reflected, generated,
+		)
+
+		reflectedBytes, e = operation.Marshal()
+
+		assert.Nil(t, e)
+
+		generatedBytes, e = generated.MarshalBinary()
+
+		assert.Nil(t, e)
+
+		assert.Equal(t,
+			bytes, reflectedBytes,
+		)
+
+		assert.Equal(t,
+			reflectedBytes, generatedBytes,
+		)
+	}
+}
+
+func TestGeneratedMethodsAppendBinary(t *testing.T) {
+	var (
+		bytes []byte
+		e     error
+	)
+
+	bytes, e = internetHeaderStruct.AppendBinary(
+		[]byte{0xff},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		append([]byte{0xff}, internetHeaderBytes...), bytes,
+	)
+
+	e = internetHeaderStruct1.UnmarshalBinary(internetHeaderBytes[:19])
+
+	assert.EqualError(t,
+		e, "UnmarshalBinary error: "+
+			"length of byte slice, 19 byte(s), "+
+			"not equal to length of format "+
+			"RFC791InternetHeaderFormatWithoutOptions, 20 byte(s)",
+	)
+}
+
+type failingGeneratedFormat struct {
+	FailingGeneratedWord `word:"8"`
+}
+
+type FailingGeneratedWord struct {
+	Value uint8 `bitfield:"8"`
+}
+
+var (
+	errGeneratedMethod = errors.New("generated method failed")
+)
+
+func (f *failingGeneratedFormat) MarshalBinary() ([]byte, error) {
+	return f.AppendBinary(nil)
+}
+
+func (f *failingGeneratedFormat) AppendBinary(bytes []byte) ([]byte, error) {
+	return nil, errGeneratedMethod
+}
+
+func (f *failingGeneratedFormat) UnmarshalBinary(bytes []byte) error {
+	return errGeneratedMethod
+}
+
+func (f *failingGeneratedFormat) BinaryFormatLength() int {
+	return 1
+}
+
+func TestShouldReturnErrorGivenGeneratedMethodFailing(t *testing.T) {
+	const (
+		errorMessage = "%[1]s error: " +
+			"Methods generated by binary-gen should marshal and unmarshal " +
+			"format-structs as reflection would. " +
+			"Argument to %[1]s points to a format-struct " +
+			"\"binary.failingGeneratedFormat\" " +
+			"whose generated method failed: generated method failed."
+	)
+
+	var (
+		e      error
+		format Format[failingGeneratedFormat]
+		value  failingGeneratedFormat
+	)
+
+	// Reflection would succeed, so must not be tried instead.
+
+	_, e = Marshal(&value)
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage, "Marshal"), e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, errGeneratedMethod,
+	)
+
+	e = Unmarshal([]byte{0x2a}, &value)
+
+	assert.Equal(t,
+		fmt.Sprintf(errorMessage, "Unmarshal"), e.Error(),
+	)
+
+	assert.Equal(t,
+		uint8(0), value.Value,
+	)
+
+	format, e = FormatOf[failingGeneratedFormat]()
+
+	assert.Nil(t, e)
+
+	_, e = format.Marshal(&value)
+
+	assert.ErrorIs(t,
+		e, errGeneratedMethod,
+	)
+
+	e = format.Unmarshal([]byte{0x2a}, &value)
+
+	assert.ErrorIs(t,
+		e, errGeneratedMethod,
+	)
+
+	assert.Equal(t,
+		uint8(0), value.Value,
+	)
+}
@@ -0,0 +1,528 @@
+package bitstream
+
+import (
+	stdbytes "bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// Bits are read and written most significant first,
+// across byte boundaries, as in video bit streams (e.g. H.264 and AV1),
+// or least significant first, as in DEFLATE,
+// from and to byte slices or any io.Reader or io.Writer.
+// Exp-Golomb codes are sequences of bits alike in either order.
+
+type Order int
+
+const (
+	MSBFirst Order = iota
+	LSBFirst
+)
+
+const (
+	lengthUpperLimit = 64
+
+	expGolombZerosUpperLimit = 64
+)
+
+var (
+	ErrExpGolombOverflow = errors.New("Exp-Golomb code overflowing 64 bits")
+	ErrNotByteAligned    = errors.New("bit position not at a byte boundary")
+)
+
+type Reader struct {
+	source   io.Reader
+	order    Order
+	position uint
+	current  [1]byte
+}
+
+func NewReader(bytes []byte) *Reader {
+	return NewReaderFrom(
+		stdbytes.NewReader(bytes), MSBFirst,
+	)
+}
+
+func NewReaderFrom(source io.Reader, order Order) *Reader {
+	return &Reader{
+		source: source,
+		order:  order,
+	}
+}
+
+func (r *Reader) ReadBits(length uint) (value uint64, e error) {
+	// Read an unsigned integer of up to 64 bits,
+	// its most significant bit first if the reader is MSB-first.
+
+	value, e = r.readBits(length, r.order)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (r *Reader) ReadSigned(length uint) (number int64, e error) {
+	// Read a signed integer of up to 64 bits in two's complement.
+
+	var (
+		value uint64
+	)
+
+	value, e = r.ReadBits(length)
+	if e != nil || length == 0 {
+		return
+	}
+
+	number = int64(value<<(lengthUpperLimit-length)) >>
+		(lengthUpperLimit - length)
+
+	return
+}
+
+func (r *Reader) ReadBool() (flag bool, e error) {
+	var (
+		bit byte
+	)
+
+	bit, e = r.readBit()
+	if e != nil {
+		return
+	}
+
+	flag = bit == 1
+
+	return
+}
+
+func (r *Reader) ReadBytes(bytes []byte) (e error) {
+	// Fill a byte slice with the bytes following a byte boundary.
+
+	if r.position%8 != 0 {
+		e = ErrNotByteAligned
+
+		return
+	}
+
+	_, e = io.ReadFull(r.source, bytes)
+	if e == io.EOF {
+		e = io.ErrUnexpectedEOF
+	}
+
+	if e != nil {
+		return
+	}
+
+	r.position += uint(len(bytes)) * 8
+
+	return
+}
+
+func (r *Reader) ReadExpGolomb() (codeNum uint64, e error) {
+	// Read an unsigned Exp-Golomb code, ue(v):
+	// as many zeros as there are bits following the first one,
+	// which with it hold the code number plus one.
+
+	var (
+		bit          byte
+		leadingZeros uint
+		suffix       uint64
+	)
+
+	for {
+		bit, e = r.readBit()
+		if e != nil {
+			return
+		}
+
+		if bit == 1 {
+			break
+		}
+
+		leadingZeros++
+
+		if leadingZeros > expGolombZerosUpperLimit {
+			e = ErrExpGolombOverflow
+
+			return
+		}
+	}
+
+	suffix, e = r.readBits(leadingZeros, MSBFirst)
+	if e != nil {
+		return
+	}
+
+	if leadingZeros == expGolombZerosUpperLimit && suffix != 0 {
+		e = ErrExpGolombOverflow
+
+		return
+	}
+
+	codeNum = 1<<leadingZeros - 1 + suffix
+
+	return
+}
+
+func (r *Reader) ReadSignedExpGolomb() (number int64, e error) {
+	// Read a signed Exp-Golomb code, se(v),
+	// mapping code numbers 0, 1, 2, 3, 4 to 0, 1, -1, 2, -2.
+
+	var (
+		codeNum uint64
+	)
+
+	codeNum, e = r.ReadExpGolomb()
+	if e != nil {
+		return
+	}
+
+	switch {
+	case codeNum%2 == 0:
+		number = -int64(codeNum / 2)
+
+	case codeNum/2 >= math.MaxInt64:
+		e = ErrExpGolombOverflow
+
+		return
+
+	default:
+		number = int64(codeNum/2 + 1)
+	}
+
+	return
+}
+
+func (r *Reader) Align() {
+	// Skip to the next byte boundary, if not at one.
+
+	r.position = (r.position + 7) / 8 * 8
+
+	return
+}
+
+func (r *Reader) Position() uint {
+	// Number of bits read
+
+	return r.position
+}
+
+func (r *Reader) readBits(length uint, order Order) (value uint64, e error) {
+	var (
+		bit byte
+		i   uint
+	)
+
+	if length > lengthUpperLimit {
+		e = fmt.Errorf("length %d overflowing %d bits",
+			length, lengthUpperLimit,
+		)
+
+		return
+	}
+
+	for i = 0; i < length; i++ {
+		bit, e = r.readBit()
+		if e != nil {
+			return
+		}
+
+		if order == LSBFirst {
+			value |= uint64(bit) << i
+
+		} else {
+			value = value<<1 | uint64(bit)
+		}
+	}
+
+	return
+}
+
+func (r *Reader) readBit() (bit byte, e error) {
+	// Bytes are read from the source one at a time, as their bits are.
+
+	var (
+		shift = r.position % 8
+	)
+
+	if shift == 0 {
+		_, e = io.ReadFull(r.source, r.current[:])
+		if e == io.EOF {
+			e = io.ErrUnexpectedEOF
+		}
+
+		if e != nil {
+			return
+		}
+	}
+
+	if r.order == MSBFirst {
+		shift = 7 - shift
+	}
+
+	bit = r.current[0] >> shift & 1
+
+	r.position++
+
+	return
+}
+
+type Writer struct {
+	destination io.Writer
+	order       Order
+	bytes       []byte
+	position    uint
+	current     [1]byte
+}
+
+func NewWriter() *Writer {
+	return NewWriterTo(nil, MSBFirst)
+}
+
+func NewWriterTo(destination io.Writer, order Order) *Writer {
+	// Writers of no destination keep the bytes written.
+
+	return &Writer{
+		destination: destination,
+		order:       order,
+	}
+}
+
+func (w *Writer) WriteBits(value uint64, length uint) (e error) {
+	// Write an unsigned integer of up to 64 bits,
+	// failing given one of more bits than the length.
+
+	if length < lengthUpperLimit && value>>length != 0 {
+		e = fmt.Errorf("%d overflowing %d bit(s)", value, length)
+
+		return
+	}
+
+	e = w.writeBits(value, length, w.order)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (w *Writer) WriteSigned(number int64, length uint) (e error) {
+	// Write a signed integer of up to 64 bits in two's complement,
+	// failing given one of more bits than the length.
+
+	switch {
+	case length == 0 && number != 0:
+		e = fmt.Errorf("%d overflowing %d bit(s)", number, length)
+
+		return
+
+	case length > 0 && length < lengthUpperLimit &&
+		(number < -1<<(length-1) || number >= 1<<(length-1)):
+		e = fmt.Errorf("%d overflowing %d bit(s)", number, length)
+
+		return
+	}
+
+	e = w.writeBits(uint64(number)&(1<<length-1), length, w.order)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (w *Writer) WriteBool(flag bool) (e error) {
+	if flag {
+		e = w.writeBit(1)
+
+	} else {
+		e = w.writeBit(0)
+	}
+
+	return
+}
+
+func (w *Writer) WriteBytes(bytes []byte) (e error) {
+	// Write whole bytes following a byte boundary.
+
+	if w.position%8 != 0 {
+		e = ErrNotByteAligned
+
+		return
+	}
+
+	e = w.emit(bytes)
+	if e != nil {
+		return
+	}
+
+	w.position += uint(len(bytes)) * 8
+
+	return
+}
+
+func (w *Writer) WriteExpGolomb(codeNum uint64) (e error) {
+	// Write an unsigned Exp-Golomb code, ue(v).
+	// The greatest code number plus one overflows to zero,
+	// and is written with 64 leading zeros.
+
+	var (
+		leadingZeros uint = expGolombZerosUpperLimit
+		value             = codeNum + 1
+	)
+
+	if value != 0 {
+		leadingZeros = uint(bits.Len64(value)) - 1
+	}
+
+	e = w.writeBits(0, leadingZeros, MSBFirst)
+	if e != nil {
+		return
+	}
+
+	e = w.writeBit(1)
+	if e != nil {
+		return
+	}
+
+	e = w.writeBits(value&(1<<leadingZeros-1), leadingZeros, MSBFirst)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (w *Writer) WriteSignedExpGolomb(number int64) (e error) {
+	// Write a signed Exp-Golomb code, se(v).
+
+	switch {
+	case number == math.MinInt64:
+		e = ErrExpGolombOverflow
+
+		return
+
+	case number > 0:
+		e = w.WriteExpGolomb(
+			uint64(number)*2 - 1,
+		)
+
+	default:
+		e = w.WriteExpGolomb(
+			uint64(-number) * 2,
+		)
+	}
+
+	return
+}
+
+func (w *Writer) Align() (e error) {
+	// Pad to the next byte boundary with zeros, if not at one,
+	// writing out the last byte.
+
+	for w.position%8 != 0 {
+		e = w.writeBit(0)
+		if e != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (w *Writer) Position() uint {
+	// Number of bits written
+
+	return w.position
+}
+
+func (w *Writer) Bytes() []byte {
+	// Bytes written by a writer of no destination,
+	// the last padded with zeros if not whole
+
+	if w.position%8 == 0 {
+		return w.bytes
+	}
+
+	return append(w.bytes[:len(w.bytes):len(w.bytes)], w.current[0])
+}
+
+func (w *Writer) writeBits(value uint64, length uint, order Order) (
+	e error,
+) {
+	var (
+		i uint
+	)
+
+	if length > lengthUpperLimit {
+		e = fmt.Errorf("length %d overflowing %d bits",
+			length, lengthUpperLimit,
+		)
+
+		return
+	}
+
+	for i = 0; i < length; i++ {
+		if order == LSBFirst {
+			e = w.writeBit(
+				byte(value >> i & 1),
+			)
+
+		} else {
+			e = w.writeBit(
+				byte(value >> (length - 1 - i) & 1),
+			)
+		}
+
+		if e != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (w *Writer) writeBit(bit byte) (e error) {
+	// Bytes are written out once whole.
+
+	var (
+		shift = w.position % 8
+	)
+
+	if w.order == MSBFirst {
+		shift = 7 - shift
+	}
+
+	w.current[0] |= bit << shift
+
+	if w.position%8 == 7 {
+		e = w.emit(w.current[:])
+		if e != nil {
+			return
+		}
+
+		w.current[0] = 0
+	}
+
+	w.position++
+
+	return
+}
+
+func (w *Writer) emit(bytes []byte) (e error) {
+	if w.destination == nil {
+		w.bytes = append(w.bytes, bytes...)
+
+		return
+	}
+
+	_, e = w.destination.Write(bytes)
+	if e != nil {
+		return
+	}
+
+	return
+}
@@ -32,7 +32,7 @@ func (c Codec) formatMetadataFromTypeReflection(reflection reflect.Type) (
 		return
 	}
 
-	if reflection.Kind() != reflect.Ptr {
+	if reflection == nil || reflection.Kind() != reflect.Ptr {
 		e = validation.NewNonPointerError()
 
 		return
@@ -56,6 +56,19 @@ func (c Codec) formatMetadataFromTypeReflection(reflection reflect.Type) (
 	return
 }
 
+func (c Codec) FormatMetadata(iface interface{}) (
+	format metadata.FormatMetadata, e error,
+) {
+	format, e = c.formatMetadataFromTypeReflection(
+		reflect.TypeOf(iface),
+	)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
 func (c Codec) NewOperation(iface interface{}) (
 	operation CodecOperation, e error,
 ) {
@@ -71,19 +84,54 @@ func (c Codec) NewOperation(iface interface{}) (
 	return
 }
 
+func NewFormatMetadataFromTypeParameter(reflection reflect.Type) (
+	format metadata.FormatMetadata, e error,
+) {
+	if reflection.Kind() != reflect.Struct {
+		e = validation.NewTypeParameterNotStructError(reflection.String())
+
+		return
+	}
+
+	format, e = metadata.NewFormatMetadataFromTypeReflection(reflection)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func NewOperationFromFormatMetadata(
+	format metadata.FormatMetadata, valueReflection reflect.Value,
+) (
+	operation CodecOperation,
+) {
+	operation = CodecOperation{
+		format:          format,
+		valueReflection: valueReflection,
+	}
+
+	return
+}
+
 type CodecOperation struct {
 	format          metadata.FormatMetadata
 	valueReflection reflect.Value
 }
 
 func (c CodecOperation) Marshal() (bytes []byte, e error) {
-	bytes = c.format.Marshal(c.valueReflection)
+	bytes, e = c.format.Marshal(c.valueReflection)
+	if e != nil {
+		return
+	}
 
 	return
 }
 
 func (c CodecOperation) Unmarshal(bytes []byte) (e error) {
-	if len(bytes) != c.format.LengthInBytes() {
+	// Byte slices are fitted to variable-length formats word by word.
+
+	if !c.format.Variable() && len(bytes) != c.format.LengthInBytes() {
 		e = validation.NewLengthOfByteSliceNotEqualToFormatLengthError(
 			uint(c.format.LengthInBytes()),
 			uint(len(bytes)),
@@ -96,7 +144,10 @@ func (c CodecOperation) Unmarshal(bytes []byte) (e error) {
 		return
 	}
 
-	c.format.Unmarshal(bytes, c.valueReflection)
+	e = c.format.Unmarshal(bytes, c.valueReflection)
+	if e != nil {
+		return
+	}
 
 	return
 }
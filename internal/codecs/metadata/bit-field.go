@@ -2,30 +2,74 @@ package metadata
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"go/token"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/encodingx/binary/internal/validation"
 )
 
-type bitFieldMetadata struct {
-	length uint
-	offset uint64
-	kind   reflect.Kind
+type BitFieldMetadata struct {
+	name           string
+	length         uint
+	offset         uint64
+	kind           reflect.Kind
+	reflectionType reflect.Type
+	options        []string
+	constraints    bitFieldConstraints
+	marshaler      bool
+	typed          bool
+	encoding       timeEncoding
+	text           stringEncoding
+	coding         string
+	signed         string
+	golomb         string
+	aligned        bool
+	fieldIndex     int
+	wholeLength    uint
+	partOffset     int
+}
+
+const (
+	enumerationPrefix = "enum="
+	minimumPrefix     = "min="
+	maximumPrefix     = "max="
+	reservedOption    = "reserved"
+	strictOption      = "strict"
+)
+
+type bitFieldConstraints struct {
+	reserved    bool
+	strict      bool
+	minimum     uint64
+	maximum     uint64
+	hasMinimum  bool
+	hasMaximum  bool
+	enumeration []EnumeratedValue
+	flags       []EnumeratedValue
+}
+
+type EnumeratedValue struct {
+	Name  string
+	Value uint64
 }
 
 func newBitFieldMetadataFromStructFieldReflection(
 	reflection reflect.StructField,
 ) (
-	bitField bitFieldMetadata, e error,
+	bitField BitFieldMetadata, e error,
 ) {
 	const (
-		tagKey         = "bitfield"
-		tagValueFormat = "%d"
+		tagKey = "bitfield"
 	)
 
 	var (
 		bitFieldLengthCap uint
+		marshaler         bool
+		typed             bool
 	)
 
 	defer func() {
@@ -34,35 +78,32 @@ func newBitFieldMetadataFromStructFieldReflection(
 		}
 	}()
 
-	switch reflection.Type.Kind() {
-	case reflect.Uint:
-		fallthrough
+	// Addresses, ports and times are recognised before methods,
+	// since netip.Addr and time.Time also implement encoding.BinaryMarshaler.
 
-	case reflect.Uint64:
-		bitFieldLengthCap = 64
+	typed = isTypedType(reflection.Type)
 
-	case reflect.Uint32:
-		bitFieldLengthCap = 32
+	marshaler = !typed && implementsBinaryMarshaler(reflection.Type)
 
-	case reflect.Uint16:
-		bitFieldLengthCap = 16
-
-	case reflect.Uint8:
-		bitFieldLengthCap = 8
+	switch {
+	case typed:
+		bitFieldLengthCap = wordLengthUpperLimitBytes * 8
 
-	case reflect.Bool:
-		bitFieldLengthCap = 1
+	case marshaler:
+		bitFieldLengthCap = 64
 
 	default:
-		e = validation.NewBitFieldOfUnsupportedTypeError(
-			reflection.Type.String(),
-		)
-
-		return
+		bitFieldLengthCap, e = bitFieldLengthCapOfKind(reflection.Type)
+		if e != nil {
+			return
+		}
 	}
 
-	bitField = bitFieldMetadata{
-		kind: reflection.Type.Kind(),
+	bitField = BitFieldMetadata{
+		name:           reflection.Name,
+		kind:           reflection.Type.Kind(),
+		reflectionType: reflection.Type,
+		marshaler:      marshaler,
 	}
 
 	if len(reflection.Tag) == 0 {
@@ -71,10 +112,8 @@ func newBitFieldMetadataFromStructFieldReflection(
 		return
 	}
 
-	_, e = fmt.Sscanf(
+	bitField.length, bitField.options, e = parseTagValue(
 		reflection.Tag.Get(tagKey),
-		tagValueFormat,
-		&bitField.length,
 	)
 	if e != nil {
 		e = validation.NewBitFieldWithMalformedTagError()
@@ -82,23 +121,218 @@ func newBitFieldMetadataFromStructFieldReflection(
 		return
 	}
 
+	e = checkBitFieldOptions(bitField.options)
+	if e != nil {
+		return
+	}
+
+	bitField.constraints, e = parseBitFieldConstraints(bitField.options)
+	if e != nil {
+		e = validation.NewBitFieldWithMalformedTagError()
+
+		return
+	}
+
 	if bitField.length > bitFieldLengthCap {
 		e = validation.NewBitFieldOfLengthOverflowingTypeError(
 			bitField.length,
 			reflection.Type.String(),
 		)
+
+		return
+	}
+
+	bitField.coding, e = parseNumberCoding(reflection.Type, bitField.options)
+	if e != nil || marshaler && bitField.coding != "" {
+		e = validation.NewBitFieldWithMalformedTagError()
+
+		return
+	}
+
+	e = checkNumberCodingLength(bitField.coding, bitField.length)
+	if e != nil {
+		return
+	}
+
+	// Constraints on values compare them unsigned,
+	// so are refused for signed integers.
+
+	if !typed && !marshaler {
+		bitField.signed, e = parseSignedEncoding(reflection.Type,
+			bitField.options,
+		)
+		if e != nil {
+			e = validation.NewBitFieldWithMalformedTagError()
+
+			return
+		}
+	}
+
+	// Exp-Golomb codes are of plain integers,
+	// signed integers in two's complement.
+
+	bitField.golomb, e = parseExpGolomb(reflection.Type, bitField.options)
+	if e != nil || bitField.golomb != "" && (typed || marshaler ||
+		bitField.coding != "" ||
+		bitField.golomb == seExpGolomb && bitField.signed != twosComplement) {
+		e = validation.NewBitFieldWithMalformedTagError()
+
+		return
+	}
+
+	e = checkExpGolombLength(bitField.golomb, bitField.length)
+	if e != nil {
+		return
+	}
+
+	if bitField.signed != "" && (bitField.constraints.hasMinimum ||
+		bitField.constraints.hasMaximum ||
+		bitField.constraints.enumeration != nil) {
+		e = validation.NewBitFieldWithMalformedTagError()
+
+		return
+	}
+
+	if bitField.constraints.enumeration == nil {
+		bitField.constraints.enumeration, e = registeredEnumeration(
+			reflection.Type, bitField.length,
+		)
+		if e != nil {
+			e = validation.NewBitFieldWithMalformedEnumerationError(
+				reflection.Type.String(), e,
+			)
+
+			return
+		}
+	}
+
+	bitField.constraints.flags, e = registeredFlags(
+		reflection.Type, bitField.length,
+	)
+	if e != nil {
+		e = validation.NewBitFieldWithMalformedFlagsError(
+			reflection.Type.String(), e,
+		)
+
+		return
+	}
+
+	if bitField.constraints.strict &&
+		bitField.constraints.enumeration == nil &&
+		bitField.constraints.flags == nil {
+		e = validation.NewBitFieldWithMalformedTagError()
+
+		return
+	}
+
+	if typed {
+		bitField, e = newTypedBitFieldMetadata(bitField)
+		if e != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func bitFieldLengthCapOfKind(reflectionType reflect.Type) (
+	bitFieldLengthCap uint, e error,
+) {
+	switch reflectionType.Kind() {
+	case reflect.Uint:
+		fallthrough
+
+	case reflect.Uint64:
+		bitFieldLengthCap = 64
+
+	case reflect.Uint32:
+		bitFieldLengthCap = 32
+
+	case reflect.Uint16:
+		bitFieldLengthCap = 16
+
+	case reflect.Uint8:
+		bitFieldLengthCap = 8
+
+	case reflect.Int, reflect.Int64:
+		bitFieldLengthCap = 64
+
+	case reflect.Int32:
+		bitFieldLengthCap = 32
+
+	case reflect.Int16:
+		bitFieldLengthCap = 16
+
+	case reflect.Int8:
+		bitFieldLengthCap = 8
+
+	case reflect.Bool:
+		bitFieldLengthCap = 1
+
+	default:
+		e = validation.NewBitFieldOfUnsupportedTypeError(
+			reflectionType.String(),
+		)
+
+		return
 	}
 
 	return
 }
 
-func (m bitFieldMetadata) marshal(reflection reflect.Value) (value uint64) {
+func (m BitFieldMetadata) marshal(reflection reflect.Value) (
+	value uint64, e error,
+) {
+	defer func() {
+		if e != nil {
+			e.(validation.BitFieldError).SetBitFieldName(m.name)
+		}
+	}()
+
+	if m.marshaler {
+		value, e = m.marshalBinary(reflection)
+		if e != nil {
+			e = validation.NewBitFieldMarshalerFailedError(e)
+
+			return
+		}
+
+		value = value << m.offset
+
+		return
+	}
+
+	if m.typed {
+		value, e = m.marshalTyped(reflection)
+		if e != nil {
+			return
+		}
+
+		value = value << m.offset
+
+		return
+	}
+
 	switch m.kind {
 	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		fallthrough
 
 	case reflect.Uint:
-		value = reflection.Uint()
+		value, e = encodeNumber(m.coding, reflection.Uint(), m.length)
+		if e != nil {
+			e = validation.NewBitFieldNumberOutOfRangeError(e)
+
+			return
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		value, e = encodeSigned(m.signed, reflection.Int(), m.length)
+		if e != nil {
+			e = validation.NewBitFieldSignedOutOfRangeError(e)
+
+			return
+		}
 
 	case reflect.Bool:
 		if reflection.Bool() {
@@ -111,12 +345,78 @@ func (m bitFieldMetadata) marshal(reflection reflect.Value) (value uint64) {
 	return
 }
 
-func (m bitFieldMetadata) unmarshal(bytes []byte, reflection reflect.Value) {
+func (m BitFieldMetadata) unmarshal(bytes []byte, reflection reflect.Value) (
+	e error,
+) {
 	var (
-		value uint64
+		number     uint64
+		ok         bool
+		undeclared uint64
+		value      uint64
 	)
 
-	value = binary.BigEndian.Uint64(bytes) >> m.offset & (1<<m.length - 1)
+	value = m.Uint64(
+		binary.BigEndian.Uint64(bytes),
+	)
+
+	// Constraints apply to numbers as decoded.
+
+	number, ok = m.Decode(value)
+	if !ok {
+		e = validation.NewBitFieldInvalidBCDError(value)
+
+		e.(validation.BitFieldError).SetBitFieldName(m.name)
+
+		return
+	}
+
+	value = number
+
+	switch {
+	case m.constraints.strict && m.constraints.flags != nil:
+		_, undeclared = m.FlagNames(value)
+		if undeclared != 0 {
+			e = validation.NewBitFieldUndeclaredFlagsError(undeclared)
+
+			e.(validation.BitFieldError).SetBitFieldName(m.name)
+
+			return
+		}
+
+	case m.constraints.strict:
+		_, ok = m.EnumeratedName(value)
+		if !ok {
+			e = validation.NewBitFieldValueNotEnumeratedError(value)
+
+			e.(validation.BitFieldError).SetBitFieldName(m.name)
+
+			return
+		}
+	}
+
+	if m.marshaler {
+		e = m.unmarshalBinary(value, reflection)
+		if e != nil {
+			e = validation.NewBitFieldMarshalerFailedError(e)
+
+			e.(validation.BitFieldError).SetBitFieldName(m.name)
+
+			return
+		}
+
+		return
+	}
+
+	if m.typed {
+		e = m.unmarshalTyped(value, reflection)
+		if e != nil {
+			e.(validation.BitFieldError).SetBitFieldName(m.name)
+
+			return
+		}
+
+		return
+	}
 
 	switch m.kind {
 	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -125,6 +425,12 @@ func (m bitFieldMetadata) unmarshal(bytes []byte, reflection reflect.Value) {
 	case reflect.Uint:
 		reflection.SetUint(value)
 
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		reflection.SetInt(
+			decodeSigned(m.signed, value, m.length),
+		)
+
 	case reflect.Bool:
 		switch value {
 		case 1:
@@ -136,3 +442,355 @@ func (m bitFieldMetadata) unmarshal(bytes []byte, reflection reflect.Value) {
 
 	return
 }
+
+func (m BitFieldMetadata) marshalBinary(reflection reflect.Value) (
+	value uint64, e error,
+) {
+	// Read as many bytes as needed to hold the bit field, in big-endian order,
+	// right-justified as are values of unsigned integer types.
+
+	var (
+		b     byte
+		bytes []byte
+	)
+
+	bytes, e = marshalBinary(reflection,
+		int(m.length+7)/8,
+	)
+	if e != nil {
+		return
+	}
+
+	for _, b = range bytes {
+		value = value<<8 | uint64(b)
+	}
+
+	if m.length < 64 && value>>m.length != 0 {
+		e = fmt.Errorf("marshalled into a value %d overflowing %d bit(s)",
+			value,
+			m.length,
+		)
+
+		return
+	}
+
+	return
+}
+
+func (m BitFieldMetadata) unmarshalBinary(value uint64,
+	reflection reflect.Value,
+) (
+	e error,
+) {
+	var (
+		bytes []byte
+		i     int
+	)
+
+	bytes = make([]byte,
+		int(m.length+7)/8,
+	)
+
+	for i = len(bytes) - 1; i >= 0; i-- {
+		bytes[i] = byte(value)
+
+		value = value >> 8
+	}
+
+	e = unmarshalBinary(bytes, reflection)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (m BitFieldMetadata) Name() string {
+	return m.name
+}
+
+func (m BitFieldMetadata) Type() reflect.Type {
+	return m.reflectionType
+}
+
+func (m BitFieldMetadata) Length() uint {
+	return m.length
+}
+
+func (m BitFieldMetadata) Offset() uint {
+	// Number of bits between the bit field
+	// and the least significant end of its word
+
+	return uint(m.offset)
+}
+
+func (m BitFieldMetadata) Options() []string {
+	return m.options
+}
+
+func (m BitFieldMetadata) Uint64(word uint64) uint64 {
+	// Extract the value of the bit field from the value of its word
+
+	return word >> m.offset & (1<<m.length - 1)
+}
+
+func (m BitFieldMetadata) PutUint64(word, value uint64) uint64 {
+	// Replace the value of the bit field in the value of its word
+
+	var (
+		mask uint64 = 1<<m.length - 1
+	)
+
+	return word&^(mask<<m.offset) | (value&mask)<<m.offset
+}
+
+func (m BitFieldMetadata) Coding() string {
+	// The option "bcd" or "gray", if any
+
+	return m.coding
+}
+
+func (m BitFieldMetadata) Signed() string {
+	// The encoding of a bit field holding a signed integer, if it is one
+
+	return m.signed
+}
+
+func (m BitFieldMetadata) ExpGolomb() string {
+	// The option "ue" or "se", if any
+
+	return m.golomb
+}
+
+func (m BitFieldMetadata) Decode(value uint64) (number uint64, ok bool) {
+	// Convert the value of a bit field to the number it codes,
+	// failing given binary-coded decimal with digits greater than nine.
+
+	return decodeNumber(m.coding, value, m.length)
+}
+
+func (m BitFieldMetadata) Encode(number uint64) (value uint64, e error) {
+	// Convert a number to the value of a bit field coding it,
+	// failing given more decimal digits than fit binary-coded decimal.
+
+	value, e = encodeNumber(m.coding, number, m.length)
+	if e != nil {
+		e = validation.NewBitFieldNumberOutOfRangeError(e)
+
+		e.(validation.BitFieldError).SetBitFieldName(m.name)
+
+		return
+	}
+
+	return
+}
+
+func (m BitFieldMetadata) SignedValue(value uint64) (
+	number int64, ok bool,
+) {
+	// Convert the value of a bit field holding a signed integer
+	// to that integer, negative zero to zero.
+
+	if m.signed == "" {
+		return
+	}
+
+	number, ok = decodeSigned(m.signed, value, m.length), true
+
+	return
+}
+
+func (m BitFieldMetadata) EncodeSigned(number int64) (value uint64, e error) {
+	// Convert a signed integer to the value of a bit field holding it,
+	// failing given one that its length and encoding cannot represent.
+
+	value, e = encodeSigned(m.signed, number, m.length)
+	if e != nil {
+		e = validation.NewBitFieldSignedOutOfRangeError(e)
+
+		e.(validation.BitFieldError).SetBitFieldName(m.name)
+
+		return
+	}
+
+	return
+}
+
+func (m BitFieldMetadata) Reserved() bool {
+	return m.constraints.reserved
+}
+
+func (m BitFieldMetadata) Minimum() (minimum uint64, ok bool) {
+	return m.constraints.minimum, m.constraints.hasMinimum
+}
+
+func (m BitFieldMetadata) Maximum() (maximum uint64, ok bool) {
+	return m.constraints.maximum, m.constraints.hasMaximum
+}
+
+func (m BitFieldMetadata) Enumeration() []EnumeratedValue {
+	return m.constraints.enumeration
+}
+
+func (m BitFieldMetadata) EnumeratedName(value uint64) (name string, ok bool) {
+	var (
+		enumerated EnumeratedValue
+	)
+
+	for _, enumerated = range m.constraints.enumeration {
+		if enumerated.Value == value {
+			name, ok = enumerated.Name, true
+
+			return
+		}
+	}
+
+	return
+}
+
+func checkBitFieldOptions(options []string) (e error) {
+	// Refuse options known to no feature,
+	// but for the numbers in tags of v1.1 (e.g. `bitfield:"4,28"`),
+	// offsets of bit fields now computed from their lengths.
+
+	const (
+		offsetBase    = 10
+		offsetBitSize = 64
+	)
+
+	var (
+		option string
+	)
+
+	for _, option = range options {
+		_, e = strconv.ParseUint(option, offsetBase, offsetBitSize)
+		if e == nil {
+			continue
+		}
+
+		e = nil
+
+		switch option {
+		case reservedOption, strictOption,
+			bcdCoding, grayCoding,
+			twosComplement, signMagnitude, onesComplement,
+			ueExpGolomb, seExpGolomb,
+			nulPaddedOption, spacePaddedOption,
+			leftJustifiedOption, rightJustifiedOption,
+			trimOption, asciiOption,
+			unixEncoding, ntpEncoding, ptpEncoding, gpsEncoding, dosEncoding:
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(option, minimumPrefix),
+			strings.HasPrefix(option, maximumPrefix),
+			strings.HasPrefix(option, enumerationPrefix),
+			strings.HasPrefix(option, resolutionPrefix):
+
+		default:
+			e = validation.NewBitFieldWithUnknownOptionError(option)
+
+			return
+		}
+	}
+
+	return
+}
+
+func parseBitFieldConstraints(options []string) (
+	constraints bitFieldConstraints, e error,
+) {
+	// Recognise the options "reserved" (bits that must be zero),
+	// "min=N", "max=N", "enum=NAME:N|NAME:N" (the only values allowed)
+	// and "strict" (rejecting other values when unmarshalling,
+	// or bits other than flags).
+	// Other options are left to other features.
+
+	const (
+		valueBase    = 0
+		valueBitSize = 64
+	)
+
+	var (
+		option string
+	)
+
+	for _, option = range options {
+		switch {
+		case option == reservedOption:
+			constraints.reserved = true
+
+		case option == strictOption:
+			constraints.strict = true
+
+		case strings.HasPrefix(option, minimumPrefix):
+			constraints.minimum, e = strconv.ParseUint(
+				strings.TrimPrefix(option, minimumPrefix),
+				valueBase, valueBitSize,
+			)
+			if e != nil {
+				return
+			}
+
+			constraints.hasMinimum = true
+
+		case strings.HasPrefix(option, maximumPrefix):
+			constraints.maximum, e = strconv.ParseUint(
+				strings.TrimPrefix(option, maximumPrefix),
+				valueBase, valueBitSize,
+			)
+			if e != nil {
+				return
+			}
+
+			constraints.hasMaximum = true
+
+		case strings.HasPrefix(option, enumerationPrefix):
+			constraints.enumeration, e = parseEnumeration(
+				strings.TrimPrefix(option, enumerationPrefix),
+			)
+			if e != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func parseEnumeration(s string) (enumeration []EnumeratedValue, e error) {
+	const (
+		nameSeparator  = ":"
+		valueBase      = 0
+		valueBitSize   = 64
+		valueSeparator = "|"
+	)
+
+	var (
+		element    string
+		enumerated EnumeratedValue
+		ok         bool
+		value      string
+	)
+
+	for _, element = range strings.Split(s, valueSeparator) {
+		enumerated.Name, value, ok = strings.Cut(element, nameSeparator)
+		if !ok || !token.IsIdentifier(enumerated.Name) {
+			e = errors.New("malformed enumerated value")
+
+			return
+		}
+
+		enumerated.Value, e = strconv.ParseUint(value,
+			valueBase, valueBitSize,
+		)
+		if e != nil {
+			return
+		}
+
+		enumeration = append(enumeration, enumerated)
+	}
+
+	return
+}
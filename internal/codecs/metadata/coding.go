@@ -0,0 +1,147 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+// Numbers may be held in a bit field as they are,
+// as binary-coded decimal (a decimal digit per four bits,
+// the most significant first, as in real-time clocks),
+// or in reflected binary Gray code (as in rotary encoders),
+// converted when marshalling and unmarshalling.
+
+const (
+	bcdCoding  = "bcd"
+	grayCoding = "gray"
+
+	bcdDigitLength = 4
+)
+
+func parseNumberCoding(reflectionType reflect.Type, options []string) (
+	coding string, e error,
+) {
+	// Recognise the options "bcd" and "gray",
+	// applying only to unsigned integers.
+
+	var (
+		option string
+	)
+
+	for _, option = range options {
+		switch option {
+		case bcdCoding, grayCoding:
+			if coding != "" {
+				e = errors.New("contradictory number codings")
+
+				return
+			}
+
+			coding = option
+		}
+	}
+
+	if coding == "" {
+		return
+	}
+
+	switch reflectionType.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		break
+
+	default:
+		e = fmt.Errorf("coding %s not of %s", coding, reflectionType)
+
+		return
+	}
+
+	return
+}
+
+func encodeNumber(coding string, number uint64, length uint) (
+	value uint64, e error,
+) {
+	// Fail given more decimal digits than fit the bit field,
+	// rather than drop the most significant.
+	var (
+		digits uint64
+		shift  uint
+	)
+
+	switch coding {
+	case bcdCoding:
+		for digits = number; digits > 0; shift += bcdDigitLength {
+			if shift >= length {
+				e = fmt.Errorf("%d overflowing %d decimal digit(s)",
+					number, length/bcdDigitLength,
+				)
+
+				return
+			}
+
+			value |= digits % 10 << shift
+
+			digits = digits / 10
+		}
+
+	case grayCoding:
+		number = number & (1<<length - 1)
+
+		value = number ^ number>>1
+
+	default:
+		value = number
+	}
+
+	return
+}
+
+func decodeNumber(coding string, value uint64, length uint) (
+	number uint64, ok bool,
+) {
+	// Fail given binary-coded decimal with digits greater than nine.
+
+	var (
+		digit uint64
+		shift uint
+	)
+
+	switch coding {
+	case bcdCoding:
+		for shift = length; shift > 0; shift -= bcdDigitLength {
+			digit = value >> (shift - bcdDigitLength) & 0xf
+
+			if digit > 9 {
+				return
+			}
+
+			number = number*10 + digit
+		}
+
+	case grayCoding:
+		for number = value; value > 0; number ^= value {
+			value = value >> 1
+		}
+
+	default:
+		number = value
+	}
+
+	ok = true
+
+	return
+}
+
+func checkNumberCodingLength(coding string, length uint) (e error) {
+	if coding == bcdCoding && length%bcdDigitLength != 0 {
+		e = validation.NewBitFieldOfLengthUnfitForBCDError(length)
+
+		return
+	}
+
+	return
+}
@@ -0,0 +1,116 @@
+package metadata
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"sync"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+var (
+	// Enumerated values registered for the types of bit fields,
+	// applying to bit fields of those types that have no option "enum=".
+
+	enumerations = make(map[reflect.Type][]EnumeratedValue)
+
+	// Types whose registrations have been looked up,
+	// into metadata that codecs cache,
+	// are closed to further registrations.
+
+	registry  sync.RWMutex
+	usedTypes = make(map[reflect.Type]bool)
+)
+
+func RegisterEnumeration(reflectionType reflect.Type,
+	enumeration []EnumeratedValue,
+) (
+	e error,
+) {
+	registry.Lock()
+
+	defer registry.Unlock()
+
+	if usedTypes[reflectionType] {
+		e = validation.NewTypeRegisteredAfterUseError(reflectionType.String())
+
+		return
+	}
+
+	enumerations[reflectionType] = append([]EnumeratedValue(nil),
+		enumeration...,
+	)
+
+	return
+}
+
+func useRegistered(reflectionType reflect.Type) (
+	enumeration, flags []EnumeratedValue,
+) {
+	// Close the type to registrations before reading them.
+
+	var (
+		used bool
+	)
+
+	registry.RLock()
+
+	used = usedTypes[reflectionType]
+
+	registry.RUnlock()
+
+	if !used {
+		registry.Lock()
+
+		usedTypes[reflectionType] = true
+
+		registry.Unlock()
+	}
+
+	registry.RLock()
+
+	defer registry.RUnlock()
+
+	enumeration = enumerations[reflectionType]
+
+	flags = flagSets[reflectionType]
+
+	return
+}
+
+func registeredEnumeration(reflectionType reflect.Type, length uint) (
+	enumeration []EnumeratedValue, e error,
+) {
+	var (
+		enumerated EnumeratedValue
+		names      = make(map[string]bool)
+	)
+
+	enumeration, _ = useRegistered(reflectionType)
+
+	for _, enumerated = range enumeration {
+		switch {
+		case !token.IsIdentifier(enumerated.Name):
+			e = fmt.Errorf("name %q not an identifier", enumerated.Name)
+
+			return
+
+		case names[enumerated.Name]:
+			e = fmt.Errorf("name %s repeated", enumerated.Name)
+
+			return
+
+		case length < 64 && enumerated.Value>>length != 0:
+			e = fmt.Errorf("value %d of %s overflowing %d bit(s)",
+				enumerated.Value, enumerated.Name, length,
+			)
+
+			return
+		}
+
+		names[enumerated.Name] = true
+	}
+
+	return
+}
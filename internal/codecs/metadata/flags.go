@@ -0,0 +1,114 @@
+package metadata
+
+import (
+	"fmt"
+	"go/token"
+	"math/bits"
+	"reflect"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+var (
+	// Flags registered for the types of bit fields,
+	// each a single bit named as its mask (e.g. DF:0b010).
+	// Bits of such bit fields not named are reserved.
+
+	flagSets = make(map[reflect.Type][]EnumeratedValue)
+)
+
+func RegisterFlags(reflectionType reflect.Type, flags []EnumeratedValue) (
+	e error,
+) {
+	registry.Lock()
+
+	defer registry.Unlock()
+
+	if usedTypes[reflectionType] {
+		e = validation.NewTypeRegisteredAfterUseError(reflectionType.String())
+
+		return
+	}
+
+	flagSets[reflectionType] = append([]EnumeratedValue(nil), flags...)
+
+	return
+}
+
+func registeredFlags(reflectionType reflect.Type, length uint) (
+	flags []EnumeratedValue, e error,
+) {
+	var (
+		flag  EnumeratedValue
+		mask  uint64
+		names = make(map[string]bool)
+	)
+
+	_, flags = useRegistered(reflectionType)
+
+	for _, flag = range flags {
+		switch {
+		case !token.IsIdentifier(flag.Name):
+			e = fmt.Errorf("name %q not an identifier", flag.Name)
+
+			return
+
+		case names[flag.Name]:
+			e = fmt.Errorf("name %s repeated", flag.Name)
+
+			return
+
+		case bits.OnesCount64(flag.Value) != 1:
+			e = fmt.Errorf("value %#x of %s not a single bit",
+				flag.Value, flag.Name,
+			)
+
+			return
+
+		case length < 64 && flag.Value>>length != 0:
+			e = fmt.Errorf("value %#x of %s overflowing %d bit(s)",
+				flag.Value, flag.Name, length,
+			)
+
+			return
+
+		case mask&flag.Value != 0:
+			e = fmt.Errorf("value %#x of %s repeated", flag.Value, flag.Name)
+
+			return
+		}
+
+		names[flag.Name] = true
+
+		mask |= flag.Value
+	}
+
+	return
+}
+
+func (m BitFieldMetadata) Flags() []EnumeratedValue {
+	return m.constraints.flags
+}
+
+func (m BitFieldMetadata) FlagNames(value uint64) (
+	names []string, undeclared uint64,
+) {
+	// Name the flags set in a value,
+	// and return the bits set that are not flags.
+
+	var (
+		flag EnumeratedValue
+	)
+
+	undeclared = value
+
+	for _, flag = range m.constraints.flags {
+		if value&flag.Value != 0 {
+			names = append(names, flag.Name)
+
+			undeclared &^= flag.Value
+		}
+	}
+
+	return
+}
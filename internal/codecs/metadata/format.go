@@ -1,28 +1,47 @@
 package metadata
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/encodingx/binary/internal/validation"
 )
 
 type FormatMetadata struct {
-	words         []wordMetadata
+	name          string
+	words         []WordMetadata
+	parts         []WordMetadata
 	lengthInBytes int
+	variable      bool
 }
 
 func NewFormatMetadataFromTypeReflection(reflection reflect.Type) (
 	format FormatMetadata, e error,
 ) {
+	return NewNamedFormatMetadataFromTypeReflection(
+		reflection.String(),
+		reflection,
+	)
+}
+
+func NewNamedFormatMetadataFromTypeReflection(
+	name string, reflection reflect.Type,
+) (
+	format FormatMetadata, e error,
+) {
+	// Name formats built from struct types constructed at run time,
+	// whose names would otherwise be their literal type definitions.
+
 	var (
-		i int
+		i    int
+		part WordMetadata
+		word WordMetadata
 	)
 
 	defer func() {
 		if e != nil {
-			e.(validation.FormatError).SetFormatName(
-				reflection.String(),
-			)
+			e.(validation.FormatError).SetFormatName(name)
 		}
 	}()
 
@@ -33,42 +52,85 @@ func NewFormatMetadataFromTypeReflection(reflection reflect.Type) (
 	}
 
 	format = FormatMetadata{
-		words: make([]wordMetadata,
-			reflection.NumField(),
-		),
+		name: name,
 	}
 
 	for i = 0; i < reflection.NumField(); i++ {
-		format.words[i], e = newWordMetadataFromStructFieldReflection(
+		word, e = newWordMetadataFromStructFieldReflection(
 			reflection.Field(i),
 		)
 		if e != nil {
 			return
 		}
 
-		format.lengthInBytes += format.words[i].lengthInBytes
+		word.fieldIndex = i
+
+		// Words following a variable-length integer or a bit stream
+		// are placed as if it were of its greatest length,
+		// and are found in byte slices by laying out the format.
+
+		format.variable = format.variable || word.varint != "" ||
+			word.bitStream
+
+		// Words longer than 64 bits are marshalled and unmarshalled
+		// in parts, but described as declared.
+
+		word.byteOffset = format.lengthInBytes
+
+		format.words = append(format.words, word)
+
+		for _, part = range word.split() {
+			part.byteOffset = format.lengthInBytes
+
+			format.parts = append(format.parts, part)
+
+			format.lengthInBytes += part.lengthInBytes
+		}
 	}
 
 	return
 }
 
-func (m FormatMetadata) Marshal(reflection reflect.Value) (bytes []byte) {
+func (m FormatMetadata) Marshal(reflection reflect.Value) (
+	bytes []byte, e error,
+) {
 	// Merge byte slices marshalled from words,
 	// in the order they appear in the format.
 
 	var (
 		copyIndex int
-		i         int
-		word      wordMetadata
+		word      WordMetadata
 		wordBytes []byte
 	)
 
-	bytes = make([]byte, m.lengthInBytes)
+	defer func() {
+		if e != nil {
+			bytes = nil
 
-	for i, word = range m.words {
-		wordBytes = word.marshal(
-			reflection.Field(i),
+			e.(validation.FormatError).SetFormatName(m.name)
+		}
+	}()
+
+	if m.variable {
+		bytes = make([]byte, 0, m.lengthInBytes)
+
+	} else {
+		bytes = make([]byte, m.lengthInBytes)
+	}
+
+	for _, word = range m.parts {
+		wordBytes, e = word.marshal(
+			reflection.Field(word.fieldIndex),
 		)
+		if e != nil {
+			return
+		}
+
+		if m.variable {
+			bytes = append(bytes, wordBytes...)
+
+			continue
+		}
 
 		copy(bytes[copyIndex:], wordBytes)
 
@@ -78,15 +140,45 @@ func (m FormatMetadata) Marshal(reflection reflect.Value) (bytes []byte) {
 	return
 }
 
-func (m FormatMetadata) Unmarshal(bytes []byte, reflection reflect.Value) {
+func (m FormatMetadata) Unmarshal(bytes []byte, reflection reflect.Value) (
+	e error,
+) {
 	var (
-		i    int
-		j    int
-		k    int
-		word wordMetadata
+		j     int
+		k     int
+		word  WordMetadata
+		words []WordMetadata
 	)
 
-	for i, word = range m.words {
+	defer func() {
+		if e != nil {
+			e.(validation.FormatError).SetFormatName(m.name)
+		}
+	}()
+
+	if m.variable {
+		// Words of variable-length formats are given their bytes alone,
+		// found by laying out the format.
+
+		words, e = m.layout(bytes, m.parts)
+		if e != nil {
+			return
+		}
+
+		for _, word = range words {
+			e = word.unmarshal(
+				bytes[word.byteOffset:word.byteOffset+word.lengthInBytes],
+				reflection.Field(word.fieldIndex),
+			)
+			if e != nil {
+				return
+			}
+		}
+
+		return
+	}
+
+	for _, word = range m.parts {
 		k = j + word.lengthInBytes
 
 		if k < wordLengthUpperLimitBytes {
@@ -96,9 +188,12 @@ func (m FormatMetadata) Unmarshal(bytes []byte, reflection reflect.Value) {
 			j = k - wordLengthUpperLimitBytes
 		}
 
-		word.unmarshal(bytes[j:k],
-			reflection.Field(i),
+		e = word.unmarshal(bytes[j:k],
+			reflection.Field(word.fieldIndex),
 		)
+		if e != nil {
+			return
+		}
 
 		j = k
 	}
@@ -106,6 +201,160 @@ func (m FormatMetadata) Unmarshal(bytes []byte, reflection reflect.Value) {
 	return
 }
 
+func (m FormatMetadata) Name() string {
+	return m.name
+}
+
+func (m FormatMetadata) Words() []WordMetadata {
+	return m.words
+}
+
 func (m FormatMetadata) LengthInBytes() int {
+	// Greatest length of the format if it is variable in length
+
 	return m.lengthInBytes
 }
+
+func (m FormatMetadata) Variable() bool {
+	// Whether the format has words holding variable-length integers
+	// or bit streams
+
+	return m.variable
+}
+
+func (m FormatMetadata) Layout(bytes []byte) (
+	words []WordMetadata, e error,
+) {
+	// Find the words of the format in a byte slice,
+	// which must be of the length of the format if it is fixed in length,
+	// or hold each word in turn and nothing more if it is variable.
+
+	return m.layout(bytes, m.words)
+}
+
+func (m FormatMetadata) layout(bytes []byte, declared []WordMetadata) (
+	words []WordMetadata, e error,
+) {
+	// Lay out either the words as declared or the parts they are
+	// marshalled in.
+
+	var (
+		i      int
+		offset int
+		word   WordMetadata
+	)
+
+	defer func() {
+		if e != nil {
+			words = nil
+
+			e.(validation.FormatError).SetFormatName(m.name)
+		}
+	}()
+
+	if !m.variable {
+		if len(bytes) != m.lengthInBytes {
+			e = validation.NewLengthOfByteSliceNotEqualToFormatLengthError(
+				uint(m.lengthInBytes),
+				uint(len(bytes)),
+			)
+
+			return
+		}
+
+		words = declared
+
+		return
+	}
+
+	words = make([]WordMetadata, len(declared))
+
+	for i, word = range declared {
+		word.byteOffset = offset
+
+		switch {
+		case word.varint != "":
+			_, word.lengthInBytes, e = decodeVarint(word.varint,
+				bytes[offset:],
+				word.lengthInBits,
+			)
+			if e != nil {
+				e = validation.NewByteSliceUnfitForVariableLengthFormatError(
+					fmt.Errorf("word \"%s\" %w", word.name, e),
+				)
+
+				return
+			}
+
+		case word.bitStream:
+			_, word.lengthInBytes, e = word.readBitStream(bytes[offset:])
+			if e != nil {
+				e = validation.NewByteSliceUnfitForVariableLengthFormatError(
+					fmt.Errorf("word \"%s\" %w", word.name, e),
+				)
+
+				return
+			}
+
+			word.lengthInBits = uint(word.lengthInBytes) * 8
+
+		case offset+word.lengthInBytes > len(bytes):
+			e = validation.NewByteSliceUnfitForVariableLengthFormatError(
+				fmt.Errorf("word \"%s\" truncated", word.name),
+			)
+
+			return
+		}
+
+		words[i] = word
+
+		offset += word.lengthInBytes
+	}
+
+	if offset != len(bytes) {
+		e = validation.NewByteSliceUnfitForVariableLengthFormatError(
+			fmt.Errorf("%d byte(s) left over", len(bytes)-offset),
+		)
+
+		return
+	}
+
+	return
+}
+
+func (m FormatMetadata) BitField(name string) (
+	word WordMetadata, bitField BitFieldMetadata, ok bool,
+) {
+	// Find a bit field by its name,
+	// optionally qualified by the name of its word (e.g. "Word.BitField").
+
+	var (
+		bitFieldName string
+		qualified    bool
+		wordName     string
+	)
+
+	wordName, bitFieldName, qualified = strings.Cut(name, ".")
+
+	if !qualified {
+		bitFieldName = name
+	}
+
+	for _, word = range m.words {
+		if qualified && word.name != wordName {
+			continue
+		}
+
+		for _, bitField = range word.bitFields {
+			if bitField.name == bitFieldName {
+				ok = true
+
+				return
+			}
+		}
+	}
+
+	word, bitField = WordMetadata{}, BitFieldMetadata{}
+
+	return
+}
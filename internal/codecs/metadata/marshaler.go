@@ -0,0 +1,70 @@
+package metadata
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	binaryMarshalerType = reflect.TypeOf(
+		(*encoding.BinaryMarshaler)(nil),
+	).Elem()
+
+	binaryUnmarshalerType = reflect.TypeOf(
+		(*encoding.BinaryUnmarshaler)(nil),
+	).Elem()
+)
+
+func implementsBinaryMarshaler(reflectionType reflect.Type) bool {
+	// Words and bit fields delegate to their own methods
+	// only if they can be converted both ways.
+
+	return reflect.PtrTo(reflectionType).Implements(binaryMarshalerType) &&
+		reflect.PtrTo(reflectionType).Implements(binaryUnmarshalerType)
+}
+
+func marshalBinary(reflection reflect.Value, lengthInBytes int) (
+	bytes []byte, e error,
+) {
+	var (
+		pointer reflect.Value
+	)
+
+	if reflection.CanAddr() {
+		pointer = reflection.Addr()
+
+	} else {
+		pointer = reflect.New(
+			reflection.Type(),
+		)
+
+		pointer.Elem().Set(reflection)
+	}
+
+	bytes, e = pointer.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+	if e != nil {
+		return
+	}
+
+	if len(bytes) != lengthInBytes {
+		e = fmt.Errorf("marshalled into %d byte(s) instead of %d",
+			len(bytes),
+			lengthInBytes,
+		)
+
+		return
+	}
+
+	return
+}
+
+func unmarshalBinary(bytes []byte, reflection reflect.Value) (e error) {
+	e = reflection.Addr().Interface().(encoding.BinaryUnmarshaler).
+		UnmarshalBinary(bytes)
+	if e != nil {
+		return
+	}
+
+	return
+}
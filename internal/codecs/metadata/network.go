@@ -0,0 +1,230 @@
+package metadata
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strings"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+var (
+	addrType         = reflect.TypeOf(netip.Addr{})
+	addrPortType     = reflect.TypeOf(netip.AddrPort{})
+	hardwareAddrType = reflect.TypeOf(net.HardwareAddr(nil))
+)
+
+func networkLengths(reflectionType reflect.Type) (lengths []uint, ok bool) {
+	// Addresses and ports are held as bytes in network order,
+	// in bit fields or words exactly as long as those bytes.
+	// Only IPv6 addresses and arrays of sixteen bytes
+	// are longer than a word may otherwise be.
+
+	switch {
+	case reflectionType == addrType:
+		lengths = []uint{32, 128}
+
+	case reflectionType == addrPortType:
+		lengths = []uint{48}
+
+	case reflectionType == hardwareAddrType:
+		lengths = []uint{48, 64}
+
+	case reflectionType.Kind() == reflect.Array &&
+		reflectionType.Elem().Kind() == reflect.Uint8:
+		switch {
+		case reflectionType.Len() > 0 && reflectionType.Len() <= 8,
+			reflectionType.Len() == 16:
+			lengths = []uint{
+				uint(reflectionType.Len()) * 8,
+			}
+		}
+
+	default:
+		return
+	}
+
+	ok = len(lengths) > 0
+
+	return
+}
+
+func networkLengthsString(lengths []uint) string {
+	var (
+		elements []string
+		length   uint
+	)
+
+	for _, length = range lengths {
+		elements = append(elements,
+			fmt.Sprint(length),
+		)
+	}
+
+	return strings.Join(elements, ", ")
+}
+
+func checkNetworkLength(reflectionType reflect.Type, length uint) (e error) {
+	var (
+		allowed uint
+		lengths []uint
+	)
+
+	lengths, _ = networkLengths(reflectionType)
+
+	for _, allowed = range lengths {
+		if allowed == length {
+			return
+		}
+	}
+
+	e = validation.NewBitFieldOfLengthUnfitForNetworkTypeError(length,
+		reflectionType.String(),
+		networkLengthsString(lengths),
+	)
+
+	return
+}
+
+func networkBytes(reflection reflect.Value, lengthInBytes int) (
+	bytes []byte, e error,
+) {
+	// Convert an address, address and port, or array to bytes,
+	// leaving zero values (e.g. netip.Addr{} or a nil net.HardwareAddr)
+	// as zeroes.
+
+	var (
+		addr     netip.Addr
+		addrPort netip.AddrPort
+		hardware net.HardwareAddr
+	)
+
+	switch reflection.Type() {
+	case addrType:
+		addr = reflection.Interface().(netip.Addr)
+
+		bytes, e = networkAddrBytes(addr, lengthInBytes)
+		if e != nil {
+			return
+		}
+
+	case addrPortType:
+		addrPort = reflection.Interface().(netip.AddrPort)
+
+		bytes, e = networkAddrBytes(addrPort.Addr(), lengthInBytes-2)
+		if e != nil {
+			return
+		}
+
+		bytes = append(bytes,
+			byte(addrPort.Port()>>8), byte(addrPort.Port()),
+		)
+
+	case hardwareAddrType:
+		hardware = reflection.Interface().(net.HardwareAddr)
+
+		bytes = make([]byte, lengthInBytes)
+
+		if hardware == nil {
+			return
+		}
+
+		if len(hardware) != lengthInBytes {
+			e = fmt.Errorf("hardware address %s of %d byte(s) instead of %d",
+				hardware,
+				len(hardware),
+				lengthInBytes,
+			)
+
+			return
+		}
+
+		copy(bytes, hardware)
+
+	default:
+		bytes = make([]byte, lengthInBytes)
+
+		reflect.Copy(
+			reflect.ValueOf(bytes), reflection,
+		)
+	}
+
+	return
+}
+
+func networkAddrBytes(addr netip.Addr, lengthInBytes int) (
+	bytes []byte, e error,
+) {
+	var (
+		addr4  [4]byte
+		addr16 [16]byte
+	)
+
+	switch {
+	case !addr.IsValid():
+		bytes = make([]byte, lengthInBytes)
+
+	case lengthInBytes == len(addr16):
+		addr16 = addr.As16()
+
+		bytes = addr16[:]
+
+	case addr.Unmap().Is4():
+		addr4 = addr.Unmap().As4()
+
+		bytes = addr4[:]
+
+	default:
+		e = fmt.Errorf("address %s not of IPv4", addr)
+
+		return
+	}
+
+	return
+}
+
+func setNetworkBytes(bytes []byte, reflection reflect.Value) {
+	// Convert bytes back to an address, address and port, or array.
+	// Addresses of four bytes are IPv4, and of sixteen, IPv6.
+
+	switch reflection.Type() {
+	case addrType:
+		reflection.Set(
+			reflect.ValueOf(
+				networkAddr(bytes),
+			),
+		)
+
+	case addrPortType:
+		reflection.Set(
+			reflect.ValueOf(
+				netip.AddrPortFrom(
+					networkAddr(bytes[:len(bytes)-2]),
+					uint16(bytes[len(bytes)-2])<<8|uint16(bytes[len(bytes)-1]),
+				),
+			),
+		)
+
+	case hardwareAddrType:
+		reflection.Set(
+			reflect.ValueOf(
+				append(net.HardwareAddr(nil), bytes...),
+			),
+		)
+
+	default:
+		reflect.Copy(reflection,
+			reflect.ValueOf(bytes),
+		)
+	}
+
+	return
+}
+
+func networkAddr(bytes []byte) (addr netip.Addr) {
+	addr, _ = netip.AddrFromSlice(bytes)
+
+	return
+}
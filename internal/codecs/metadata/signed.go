@@ -0,0 +1,147 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Signed integers are held in two's complement unless an option names
+// sign-magnitude (as in ARINC 429) or ones' complement,
+// in both of which zero may also be negative,
+// unmarshalled as zero.
+
+const (
+	twosComplement = "twoscomplement"
+	signMagnitude  = "signmagnitude"
+	onesComplement = "onescomplement"
+)
+
+func isSignedKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return true
+	}
+
+	return false
+}
+
+func parseSignedEncoding(reflectionType reflect.Type, options []string) (
+	encoding string, e error,
+) {
+	// Recognise the options "twoscomplement" (the default),
+	// "signmagnitude" and "onescomplement",
+	// applying only to signed integers.
+
+	var (
+		option string
+	)
+
+	for _, option = range options {
+		switch option {
+		case twosComplement, signMagnitude, onesComplement:
+			if encoding != "" {
+				e = errors.New("contradictory signed encodings")
+
+				return
+			}
+
+			encoding = option
+		}
+	}
+
+	switch {
+	case !isSignedKind(reflectionType.Kind()) && encoding != "":
+		e = fmt.Errorf("encoding %s not of %s", encoding, reflectionType)
+
+		return
+
+	case !isSignedKind(reflectionType.Kind()):
+		return
+
+	case encoding == "":
+		encoding = twosComplement
+	}
+
+	return
+}
+
+func encodeSigned(encoding string, number int64, length uint) (
+	value uint64, e error,
+) {
+	// Fail given numbers that do not fit the bit field,
+	// rather than drop the most significant bits,
+	// which would change their signs.
+
+	var (
+		magnitude = uint64(number)
+		mask      = uint64(1)<<length - 1
+		signBit   = uint64(1) << (length - 1)
+		ok        bool
+	)
+
+	if number < 0 {
+		magnitude = -magnitude
+	}
+
+	switch encoding {
+	case twosComplement:
+		value = uint64(number) & mask
+
+		ok = magnitude < signBit || number < 0 && magnitude == signBit
+
+	case signMagnitude:
+		value = magnitude
+
+		if number < 0 {
+			value = value | signBit
+		}
+
+		ok = magnitude < signBit
+
+	case onesComplement:
+		value = magnitude
+
+		if number < 0 {
+			value = ^value & mask
+		}
+
+		ok = magnitude < signBit
+	}
+
+	if !ok {
+		e = fmt.Errorf("%d overflowing %d bit(s) in %s",
+			number, length, encoding,
+		)
+
+		return
+	}
+
+	return
+}
+
+func decodeSigned(encoding string, value uint64, length uint) (
+	number int64,
+) {
+	var (
+		mask     = uint64(1)<<length - 1
+		negative = value>>(length-1)&1 == 1
+	)
+
+	switch {
+	case !negative:
+		number = int64(value)
+
+	case encoding == signMagnitude:
+		number = -int64(value &^ (1 << (length - 1)))
+
+	case encoding == onesComplement:
+		number = -int64(^value & mask)
+
+	default:
+		number = int64(value | ^mask)
+	}
+
+	return
+}
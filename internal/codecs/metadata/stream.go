@@ -0,0 +1,492 @@
+package metadata
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/encodingx/binary/internal/bitstream"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+// Words tagged with the option "bitstream" are bit streams,
+// holding their bit fields one after another at any bit position
+// (as in H.264 and AV1 headers), padded with zeros to a whole byte.
+// Bit fields may hold Exp-Golomb codes, ue(v) and se(v),
+// of integers of no more bits than their lengths,
+// and may be preceded by zero-length markers aligning them to a byte.
+
+const (
+	bitStreamOption = "bitstream"
+	alignOption     = "align"
+
+	ueExpGolomb = "ue"
+	seExpGolomb = "se"
+)
+
+type BitFieldValue struct {
+	Position uint
+	Length   uint
+	Value    uint64
+	Bytes    []byte
+}
+
+func parseExpGolomb(reflectionType reflect.Type, options []string) (
+	golomb string, e error,
+) {
+	// Recognise the options "ue", applying only to unsigned integers,
+	// and "se", applying only to signed integers.
+
+	var (
+		option string
+	)
+
+	for _, option = range options {
+		switch option {
+		case ueExpGolomb, seExpGolomb:
+			if golomb != "" {
+				e = errors.New("contradictory Exp-Golomb codes")
+
+				return
+			}
+
+			golomb = option
+		}
+	}
+
+	switch {
+	case golomb == "":
+		return
+
+	case golomb == ueExpGolomb && isUnsignedKind(reflectionType.Kind()):
+		return
+
+	case golomb == seExpGolomb && isSignedKind(reflectionType.Kind()):
+		return
+	}
+
+	e = fmt.Errorf("code %s not of %s", golomb, reflectionType)
+
+	return
+}
+
+func checkExpGolombLength(golomb string, length uint) (e error) {
+	// Signed integers of 64 bits include one too negative to code.
+
+	var (
+		lengthCap = map[string]uint{
+			ueExpGolomb: 64,
+			seExpGolomb: 63,
+		}[golomb]
+	)
+
+	if golomb != "" && (length == 0 || length > lengthCap) {
+		e = validation.NewBitFieldOfLengthUnfitForVarintError(
+			length,
+			golomb,
+			fmt.Sprintf("[1, %d]", lengthCap),
+		)
+
+		return
+	}
+
+	return
+}
+
+func isBitStreamWord(options []string) bool {
+	var (
+		option string
+	)
+
+	for _, option = range options {
+		if option == bitStreamOption {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAlignmentMarker(reflection reflect.StructField) (
+	marker bool, e error,
+) {
+	// Markers are fields of empty struct types (e.g. _ struct{})
+	// tagged with a length of zero and the option "align".
+
+	const (
+		tagKey = "bitfield"
+	)
+
+	var (
+		length  uint
+		option  string
+		options []string
+	)
+
+	length, options, e = parseTagValue(
+		reflection.Tag.Get(tagKey),
+	)
+	if e != nil {
+		e = nil
+
+		return
+	}
+
+	for _, option = range options {
+		marker = marker || option == alignOption
+	}
+
+	if !marker {
+		return
+	}
+
+	if length != 0 || reflection.Type.Kind() != reflect.Struct ||
+		reflection.Type.NumField() != 0 {
+		e = validation.NewBitFieldWithMalformedTagError()
+
+		e.(validation.BitFieldError).SetBitFieldName(reflection.Name)
+
+		return
+	}
+
+	return
+}
+
+func newBitStreamWordMetadata(reflection reflect.StructField,
+	options []string,
+) (
+	word WordMetadata, e error,
+) {
+	// Bit fields are placed as if each Exp-Golomb code were of its
+	// greatest length, so that the word is of its greatest length,
+	// and are found in byte slices by laying out the format.
+
+	var (
+		aligned  bool
+		bitField BitFieldMetadata
+		field    reflect.StructField
+		i        int
+		marker   bool
+		position uint
+	)
+
+	word = WordMetadata{
+		name:      reflection.Name,
+		options:   options,
+		bitStream: true,
+	}
+
+	for i = 0; i < reflection.Type.NumField(); i++ {
+		field = reflection.Type.Field(i)
+
+		marker, e = isAlignmentMarker(field)
+		if e != nil {
+			return
+		}
+
+		if marker {
+			aligned = true
+
+			continue
+		}
+
+		bitField, e = newBitFieldMetadataFromStructFieldReflection(field)
+		if e != nil {
+			return
+		}
+
+		if aligned {
+			position = (position + 7) / 8 * 8
+		}
+
+		bitField.aligned = aligned
+		bitField.fieldIndex = i
+		bitField.offset = uint64(position)
+
+		aligned = false
+
+		position += bitField.length
+
+		if bitField.golomb != "" {
+			position += bitField.length + 1
+		}
+
+		word.bitFields = append(word.bitFields, bitField)
+	}
+
+	if len(word.bitFields) == 0 {
+		e = validation.NewWordWithNoBitFieldsError()
+
+		return
+	}
+
+	word.lengthInBits = (position + 7) / 8 * 8
+	word.lengthInBytes = int(word.lengthInBits / 8)
+
+	// Offsets count from the least significant bit, as in other words.
+
+	for i = range word.bitFields {
+		word.bitFields[i].offset = uint64(word.lengthInBits) -
+			word.bitFields[i].offset - uint64(word.bitFields[i].length)
+	}
+
+	return
+}
+
+func (m WordMetadata) marshalBitStream(reflection reflect.Value) (
+	bytes []byte, e error,
+) {
+	var (
+		bitField BitFieldMetadata
+		number   uint64
+		value    uint64
+		writer   = bitstream.NewWriter()
+	)
+
+	for _, bitField = range m.bitFields {
+		bitField.offset = 0
+
+		if bitField.aligned {
+			e = writer.Align()
+			if e != nil {
+				return
+			}
+		}
+
+		if bitField.golomb != "" {
+			if bitField.signed != "" {
+				number = uint64(
+					reflection.Field(bitField.fieldIndex).Int(),
+				)
+
+			} else {
+				number = reflection.Field(bitField.fieldIndex).Uint()
+			}
+
+			e = checkVarintRange(bitField, number)
+			if e != nil {
+				e.(validation.BitFieldError).SetBitFieldName(bitField.name)
+
+				return
+			}
+		}
+
+		value, e = bitField.marshal(
+			reflection.Field(bitField.fieldIndex),
+		)
+		if e != nil {
+			return
+		}
+
+		switch bitField.golomb {
+		case ueExpGolomb:
+			e = writer.WriteExpGolomb(value)
+
+		case seExpGolomb:
+			e = writer.WriteSignedExpGolomb(
+				decodeSigned(twosComplement, value, bitField.length),
+			)
+
+		default:
+			e = writer.WriteBits(value, bitField.length)
+		}
+
+		if e != nil {
+			e = validation.NewBitFieldVarintOutOfRangeError(e)
+
+			e.(validation.BitFieldError).SetBitFieldName(bitField.name)
+
+			return
+		}
+	}
+
+	e = writer.Align()
+	if e != nil {
+		return
+	}
+
+	bytes = writer.Bytes()
+
+	return
+}
+
+func (m WordMetadata) unmarshalBitStream(bytes []byte,
+	reflection reflect.Value,
+) (
+	e error,
+) {
+	// Bytes are those of the bit stream alone,
+	// found and checked when laying out the format.
+
+	var (
+		bitFieldBytes = make([]byte, wordLengthUpperLimitBytes)
+		bitField      BitFieldMetadata
+		i             int
+		values        []BitFieldValue
+	)
+
+	values, _, _ = m.readBitStream(bytes)
+
+	for i, bitField = range m.bitFields {
+		bitField.offset = 0
+
+		binary.BigEndian.PutUint64(bitFieldBytes, values[i].Value)
+
+		e = bitField.unmarshal(bitFieldBytes,
+			reflection.Field(bitField.fieldIndex),
+		)
+		if e != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (m WordMetadata) readBitStream(bytes []byte) (
+	values []BitFieldValue, lengthInBytes int, e error,
+) {
+	// Read each bit field in turn, Exp-Golomb codes decoded,
+	// signed integers given in two's complement of their lengths.
+
+	var (
+		bitField BitFieldValue
+		i        int
+		metadata BitFieldMetadata
+		number   int64
+		reader   = bitstream.NewReader(bytes)
+	)
+
+	defer func() {
+		switch {
+		case errors.Is(e, io.ErrUnexpectedEOF):
+			e = fmt.Errorf("bit field \"%s\" truncated", metadata.name)
+
+		case e != nil:
+			e = fmt.Errorf("bit field \"%s\" %w", metadata.name, e)
+		}
+	}()
+
+	values = make([]BitFieldValue, len(m.bitFields))
+
+	for i, metadata = range m.bitFields {
+		if metadata.aligned {
+			reader.Align()
+		}
+
+		bitField = BitFieldValue{
+			Position: reader.Position(),
+		}
+
+		switch metadata.golomb {
+		case ueExpGolomb:
+			bitField.Value, e = reader.ReadExpGolomb()
+			if e != nil {
+				return
+			}
+
+		case seExpGolomb:
+			number, e = reader.ReadSignedExpGolomb()
+			if e != nil {
+				return
+			}
+
+			bitField.Value = uint64(number)
+
+		default:
+			bitField.Value, e = reader.ReadBits(metadata.length)
+			if e != nil {
+				return
+			}
+		}
+
+		if metadata.golomb != "" {
+			e = checkVarintDecoded(metadata.signed != "", bitField.Value,
+				metadata.length,
+			)
+			if e != nil {
+				return
+			}
+
+			bitField.Value = bitField.Value & (1<<metadata.length - 1)
+		}
+
+		bitField.Length = reader.Position() - bitField.Position
+
+		values[i] = bitField
+	}
+
+	reader.Align()
+
+	lengthInBytes = int(reader.Position() / 8)
+
+	return
+}
+
+func (m WordMetadata) Values(bytes []byte) (values []BitFieldValue) {
+	// Read the value of each bit field of the word
+	// from the bytes of its format, as laid out,
+	// with its position counted from the most significant bit of the word
+	// and its length in the bytes, which differ for Exp-Golomb codes.
+	// The value of a word longer than 64 bits is given as bytes instead,
+	// most significant first.
+
+	var (
+		bitField BitFieldMetadata
+		i        int
+		word     uint64
+	)
+
+	if m.bitStream {
+		values, _, _ = m.readBitStream(
+			bytes[m.byteOffset : m.byteOffset+m.lengthInBytes],
+		)
+
+		return
+	}
+
+	if m.lengthInBits > maximumLengthInBits {
+		values = []BitFieldValue{
+			{
+				Length: m.lengthInBits,
+				Bytes:  make([]byte, m.lengthInBytes),
+			},
+		}
+
+		copy(values[0].Bytes,
+			bytes[m.byteOffset:m.byteOffset+m.lengthInBytes],
+		)
+
+		if m.littleEndian {
+			for i = 0; i < m.lengthInBytes/2; i++ {
+				values[0].Bytes[i], values[0].Bytes[m.lengthInBytes-1-i] =
+					values[0].Bytes[m.lengthInBytes-1-i], values[0].Bytes[i]
+			}
+		}
+
+		return
+	}
+
+	word = m.Uint64(bytes)
+
+	values = make([]BitFieldValue, len(m.bitFields))
+
+	for i, bitField = range m.bitFields {
+		values[i] = BitFieldValue{
+			Position: m.lengthInBits - bitField.length - uint(bitField.offset),
+			Length:   bitField.length,
+			Value:    bitField.Uint64(word),
+		}
+	}
+
+	return
+}
+
+func (m WordMetadata) BitStream() bool {
+	// Whether the word holds its bit fields one after another
+	// at any bit position
+
+	return m.bitStream
+}
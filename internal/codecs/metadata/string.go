@@ -0,0 +1,182 @@
+package metadata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+// Strings are held as bytes, the first character first,
+// in bit fields or words of whole bytes,
+// padded to their lengths with NUL or spaces
+// after (left-justified) or before (right-justified) their characters.
+// Strings longer than a word may otherwise be are split across words,
+// each holding up to eight of their bytes.
+
+type stringEncoding struct {
+	padding        byte
+	rightJustified bool
+	trimmed        bool
+	ascii          bool
+}
+
+const (
+	nulPaddedOption      = "nulpadded"
+	spacePaddedOption    = "spacepadded"
+	leftJustifiedOption  = "leftjustified"
+	rightJustifiedOption = "rightjustified"
+	trimOption           = "trim"
+	asciiOption          = "ascii"
+
+	nulPadding   = '\x00'
+	spacePadding = ' '
+)
+
+func isStringType(reflectionType reflect.Type) bool {
+	return reflectionType.Kind() == reflect.String
+}
+
+func parseStringEncoding(options []string) (
+	encoding stringEncoding, e error,
+) {
+	// Recognise the options "nulpadded" (the default) and "spacepadded",
+	// "leftjustified" (the default) and "rightjustified",
+	// "trim", removing padding when unmarshalling,
+	// and "ascii", refusing other characters.
+
+	var (
+		justified bool
+		option    string
+		padded    bool
+	)
+
+	encoding.padding = nulPadding
+
+	for _, option = range options {
+		switch option {
+		case nulPaddedOption, spacePaddedOption:
+			if padded {
+				e = errors.New("contradictory paddings")
+
+				return
+			}
+
+			padded = true
+
+			if option == spacePaddedOption {
+				encoding.padding = spacePadding
+			}
+
+		case leftJustifiedOption, rightJustifiedOption:
+			if justified {
+				e = errors.New("contradictory justifications")
+
+				return
+			}
+
+			justified = true
+
+			encoding.rightJustified = option == rightJustifiedOption
+
+		case trimOption:
+			encoding.trimmed = true
+
+		case asciiOption:
+			encoding.ascii = true
+		}
+	}
+
+	return
+}
+
+func checkStringLength(length uint) (e error) {
+	if length == 0 || length%8 != 0 {
+		e = validation.NewBitFieldOfLengthUnfitForStringError(length)
+
+		return
+	}
+
+	return
+}
+
+func (s stringEncoding) stringBytes(reflection reflect.Value,
+	lengthInBytes int,
+) (
+	b []byte, e error,
+) {
+	var (
+		str = reflection.String()
+	)
+
+	if len(str) > lengthInBytes {
+		e = fmt.Errorf("string %q of %d byte(s) overflowing %d",
+			str, len(str), lengthInBytes,
+		)
+
+		return
+	}
+
+	e = s.checkCharacters(str)
+	if e != nil {
+		return
+	}
+
+	b = bytes.Repeat([]byte{s.padding}, lengthInBytes)
+
+	if s.rightJustified {
+		copy(b[lengthInBytes-len(str):], str)
+
+		return
+	}
+
+	copy(b, str)
+
+	return
+}
+
+func (s stringEncoding) setStringBytes(b []byte, reflection reflect.Value) {
+	var (
+		cutset = string(s.padding)
+	)
+
+	switch {
+	case !s.trimmed:
+		break
+
+	case s.rightJustified:
+		b = bytes.TrimLeft(b, cutset)
+
+	default:
+		b = bytes.TrimRight(b, cutset)
+	}
+
+	reflection.SetString(
+		string(b),
+	)
+
+	return
+}
+
+func (s stringEncoding) checkCharacters(str string) (e error) {
+	var (
+		i int
+	)
+
+	if !s.ascii {
+		return
+	}
+
+	for i = 0; i < len(str); i++ {
+		if str[i] >= utf8.RuneSelf {
+			e = fmt.Errorf("string %q not of ASCII at byte %d", str, i)
+
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,33 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+)
+
+func parseTagValue(tagValue string) (
+	length uint, options []string, e error,
+) {
+	// A tag value is a length in number of bits,
+	// optionally followed by comma-separated options (e.g. "2,reserved").
+
+	const (
+		lengthFormat    = "%d"
+		optionSeparator = ","
+	)
+
+	var (
+		elements []string
+	)
+
+	elements = strings.Split(tagValue, optionSeparator)
+
+	_, e = fmt.Sscanf(elements[0], lengthFormat, &length)
+	if e != nil {
+		return
+	}
+
+	options = elements[1:]
+
+	return
+}
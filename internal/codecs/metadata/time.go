@@ -0,0 +1,689 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+// Times are held as counts since the epoch of an encoding,
+// in the time scale of the protocol (e.g. GPS or TAI for PTP),
+// with no correction for leap seconds.
+// Durations are held as counts of a resolution,
+// or in the fixed-point seconds of NTP.
+
+type timeEncoding struct {
+	name       string
+	resolution time.Duration
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+
+	gpsEpoch  = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+	ntpEpoch  = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+	unixEpoch = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	timeUpperLimit = time.Date(timeYearUpperLimit+1, time.January, 1,
+		0, 0, 0, 0, time.UTC,
+	)
+)
+
+const (
+	countEncoding = "count"
+	dosEncoding   = "dos"
+	gpsEncoding   = "gps"
+	ntpEncoding   = "ntp"
+	ptpEncoding   = "ptp"
+	unixEncoding  = "unix"
+
+	resolutionPrefix = "resolution="
+
+	dosYearLowerLimit = 1980
+	dosYearUpperLimit = 2107
+
+	timeYearUpperLimit = 9999
+
+	ptpLength             = 80
+	ptpSecondsLength      = 48
+	nanosecondsPerSecond  = uint64(time.Second)
+	secondsPerWeek        = 7 * 24 * 60 * 60
+	fixedPointFraction32  = 32
+	fixedPointFraction16  = 16
+	maximumLengthInBits   = wordLengthUpperLimitBytes * 8
+	defaultTimeResolution = time.Second
+)
+
+func isTimeType(reflectionType reflect.Type) bool {
+	return reflectionType == timeType || reflectionType == durationType
+}
+
+func parseTimeEncoding(reflectionType reflect.Type, options []string) (
+	encoding timeEncoding, e error,
+) {
+	// Recognise the options "unix" (the default for times),
+	// "ntp", "ptp", "gps" and "dos", naming an encoding,
+	// and "resolution=D" (e.g. "resolution=1ms"), a Go duration
+	// dividing or divisible by one second, for counts of time.
+
+	var (
+		name   string
+		option string
+	)
+
+	encoding.resolution = defaultTimeResolution
+
+	for _, option = range options {
+		switch option {
+		case unixEncoding, ntpEncoding, ptpEncoding, gpsEncoding, dosEncoding:
+			if name != "" {
+				e = errors.New("contradictory time encodings")
+
+				return
+			}
+
+			name = option
+
+		default:
+			if !strings.HasPrefix(option, resolutionPrefix) {
+				continue
+			}
+
+			encoding.resolution, e = time.ParseDuration(
+				strings.TrimPrefix(option, resolutionPrefix),
+			)
+			if e != nil {
+				return
+			}
+
+			if encoding.resolution <= 0 ||
+				encoding.resolution%time.Second != 0 &&
+					time.Second%encoding.resolution != 0 {
+				e = fmt.Errorf("resolution %s neither dividing "+
+					"nor divisible by one second", encoding.resolution,
+				)
+
+				return
+			}
+		}
+	}
+
+	switch {
+	case name == "" && reflectionType == timeType:
+		name = unixEncoding
+
+	case name == "":
+		name = countEncoding
+	}
+
+	encoding.name = name
+
+	switch {
+	case reflectionType == durationType &&
+		name != countEncoding && name != ntpEncoding:
+		e = fmt.Errorf("encoding %s not of durations", name)
+
+		return
+
+	case encoding.resolution != defaultTimeResolution &&
+		name != countEncoding && name != unixEncoding && name != gpsEncoding:
+		e = fmt.Errorf("encoding %s of fixed resolution", name)
+
+		return
+
+	case name == gpsEncoding &&
+		encoding.resolution > time.Second &&
+		secondsPerWeek%(encoding.resolution/time.Second) != 0:
+		e = fmt.Errorf("resolution %s not dividing one week",
+			encoding.resolution,
+		)
+
+		return
+	}
+
+	return
+}
+
+func (t timeEncoding) checkLength(length uint) (e error) {
+	var (
+		lengths string
+		ok      bool
+	)
+
+	switch t.name {
+	case ntpEncoding:
+		lengths, ok = "{32, 64}", length == 32 || length == 64
+
+	case ptpEncoding:
+		lengths, ok = "{80}", length == ptpLength
+
+	case dosEncoding:
+		lengths, ok = "{32}", length == 32
+
+	case gpsEncoding:
+		lengths = fmt.Sprintf("[%d, %d]",
+			t.gpsTimeOfWeekLength()+1, maximumLengthInBits,
+		)
+
+		ok = length > t.gpsTimeOfWeekLength() && length <= maximumLengthInBits
+
+	default:
+		lengths = fmt.Sprintf("[1, %d]", maximumLengthInBits)
+
+		ok = length >= 1 && length <= maximumLengthInBits
+	}
+
+	if !ok {
+		e = validation.NewBitFieldOfLengthUnfitForTimeEncodingError(length,
+			t.name, lengths,
+		)
+
+		return
+	}
+
+	return
+}
+
+func (t timeEncoding) gpsTicksPerWeek() uint64 {
+	if t.resolution >= time.Second {
+		return secondsPerWeek / uint64(t.resolution/time.Second)
+	}
+
+	return secondsPerWeek * uint64(time.Second/t.resolution)
+}
+
+func (t timeEncoding) gpsTimeOfWeekLength() uint {
+	// Weeks are held above the time of week,
+	// in as many bits as the time of week needs.
+
+	return uint(
+		bits.Len64(t.gpsTicksPerWeek() - 1),
+	)
+}
+
+func (t timeEncoding) uint64Of(reflection reflect.Value, length uint) (
+	value uint64, e error,
+) {
+	var (
+		duration    time.Duration
+		nanoseconds uint64
+		seconds     int64
+		stamp       time.Time
+		ok          bool
+		weeks       uint64
+	)
+
+	if reflection.Type() == durationType {
+		duration = time.Duration(
+			reflection.Int(),
+		)
+
+		if duration < 0 {
+			e = fmt.Errorf("duration %s negative", duration)
+
+			return
+		}
+
+		seconds = int64(duration / time.Second)
+
+		nanoseconds = uint64(duration % time.Second)
+
+	} else {
+		stamp = reflection.Interface().(time.Time)
+
+		if stamp.IsZero() {
+			return
+		}
+
+		seconds = stamp.Unix() - t.epoch().Unix()
+
+		nanoseconds = uint64(
+			stamp.Nanosecond(),
+		)
+
+		if t.name != dosEncoding && seconds < 0 {
+			e = fmt.Errorf("time %s before epoch %s",
+				stamp.Format(time.RFC3339Nano),
+				t.epoch().Format(time.RFC3339),
+			)
+
+			return
+		}
+
+		if !stamp.Before(timeUpperLimit) {
+			e = fmt.Errorf("time %s beyond year %d",
+				stamp.Format(time.RFC3339Nano), timeYearUpperLimit,
+			)
+
+			return
+		}
+	}
+
+	switch t.name {
+	case countEncoding, unixEncoding:
+		value, ok = countTicks(uint64(seconds), nanoseconds, t.resolution)
+
+	case ntpEncoding:
+		value, ok = t.ntpUint64(reflection.Type(),
+			uint64(seconds), nanoseconds, length,
+		)
+
+	case gpsEncoding:
+		weeks = uint64(seconds) / secondsPerWeek
+
+		value, _ = countTicks(uint64(seconds)%secondsPerWeek, nanoseconds,
+			t.resolution,
+		)
+
+		ok = weeks>>(length-t.gpsTimeOfWeekLength()) == 0
+
+		value = weeks<<t.gpsTimeOfWeekLength() | value
+
+	case ptpEncoding:
+		value, ok = uint64(seconds), true
+
+	case dosEncoding:
+		value, ok = dosUint64(stamp)
+	}
+
+	if !ok || length < maximumLengthInBits && value>>length != 0 {
+		e = fmt.Errorf("%s overflowing %d bit(s) encoded as %s",
+			t.valueString(reflection), length, t.name,
+		)
+
+		return
+	}
+
+	return
+}
+
+func (t timeEncoding) setUint64(value uint64, reflection reflect.Value,
+	length uint,
+) (
+	e error,
+) {
+	// Fail given values beyond the range of durations
+	// or of times that can be printed, rather than wrap or normalise them.
+
+	var (
+		carry       uint64
+		count       uint64
+		high        uint64
+		nanoseconds uint64
+		ok          = true
+		seconds     uint64
+		stamp       time.Time
+		weeks       uint64
+	)
+
+	switch t.name {
+	case countEncoding, unixEncoding:
+		seconds, nanoseconds, ok = countSeconds(value, t.resolution)
+
+	case ntpEncoding:
+		seconds, nanoseconds = t.ntpSeconds(reflection.Type(), value, length)
+
+	case gpsEncoding:
+		seconds, nanoseconds, ok = countSeconds(
+			value&(1<<t.gpsTimeOfWeekLength()-1), t.resolution,
+		)
+
+		high, weeks = bits.Mul64(value>>t.gpsTimeOfWeekLength(),
+			secondsPerWeek,
+		)
+
+		seconds, carry = bits.Add64(seconds, weeks, 0)
+
+		ok = ok && high == 0 && carry == 0
+
+	case dosEncoding:
+		stamp, e = dosTime(value)
+		if e != nil {
+			return
+		}
+
+		reflection.Set(
+			reflect.ValueOf(stamp),
+		)
+
+		return
+	}
+
+	if reflection.Type() == durationType {
+		high, count = bits.Mul64(seconds, nanosecondsPerSecond)
+
+		count, carry = bits.Add64(count, nanoseconds, 0)
+
+		if !ok || high != 0 || carry != 0 || count > math.MaxInt64 {
+			e = fmt.Errorf("%d overflowing a duration encoded as %s",
+				value, t.name,
+			)
+
+			return
+		}
+
+		reflection.SetInt(
+			int64(count),
+		)
+
+		return
+	}
+
+	stamp, e = t.timeOf(value, seconds, nanoseconds, ok)
+	if e != nil {
+		return
+	}
+
+	reflection.Set(
+		reflect.ValueOf(stamp),
+	)
+
+	return
+}
+
+func (t timeEncoding) timeOf(value, seconds, nanoseconds uint64, ok bool) (
+	stamp time.Time, e error,
+) {
+	// Times are limited to the years of RFC 3339, in which they are printed.
+
+	if !ok || seconds >= uint64(timeUpperLimit.Unix()-t.epoch().Unix()) {
+		e = fmt.Errorf("%d beyond year %d encoded as %s",
+			value, timeYearUpperLimit, t.name,
+		)
+
+		return
+	}
+
+	stamp = time.Unix(t.epoch().Unix()+int64(seconds), int64(nanoseconds)).
+		UTC()
+
+	return
+}
+
+func (t timeEncoding) epoch() time.Time {
+	switch t.name {
+	case ntpEncoding:
+		return ntpEpoch
+
+	case gpsEncoding:
+		return gpsEpoch
+	}
+
+	return unixEpoch
+}
+
+func (t timeEncoding) valueString(reflection reflect.Value) string {
+	if reflection.Type() == durationType {
+		return fmt.Sprintf("duration %s",
+			time.Duration(
+				reflection.Int(),
+			),
+		)
+	}
+
+	return fmt.Sprintf("time %s",
+		reflection.Interface().(time.Time).Format(time.RFC3339Nano),
+	)
+}
+
+func countTicks(seconds, nanoseconds uint64, resolution time.Duration) (
+	ticks uint64, ok bool,
+) {
+	// Count whole ticks of a resolution in a number of seconds,
+	// failing if they overflow 64 bits.
+
+	var (
+		carry uint64
+		high  uint64
+	)
+
+	if resolution >= time.Second {
+		ticks, ok = seconds/uint64(resolution/time.Second), true
+
+		return
+	}
+
+	high, ticks = bits.Mul64(seconds,
+		uint64(time.Second/resolution),
+	)
+
+	ticks, carry = bits.Add64(ticks, nanoseconds/uint64(resolution), 0)
+
+	ok = high == 0 && carry == 0
+
+	return
+}
+
+func countSeconds(ticks uint64, resolution time.Duration) (
+	seconds, nanoseconds uint64, ok bool,
+) {
+	// Fail if whole seconds overflow 64 bits.
+
+	var (
+		high uint64
+	)
+
+	if resolution >= time.Second {
+		high, seconds = bits.Mul64(ticks,
+			uint64(resolution/time.Second),
+		)
+
+		ok = high == 0
+
+		return
+	}
+
+	ok = true
+
+	seconds = ticks / uint64(time.Second/resolution)
+
+	nanoseconds = ticks % uint64(time.Second/resolution) * uint64(resolution)
+
+	return
+}
+
+func (t timeEncoding) ntpUint64(reflectionType reflect.Type,
+	seconds, nanoseconds uint64, length uint,
+) (
+	value uint64, ok bool,
+) {
+	// Times of 64 bits and durations of 32 or 64 bits are fixed-point,
+	// with as many bits of fraction as of whole seconds.
+	// Times of 32 bits are whole seconds, as in RFC 868.
+
+	var (
+		fraction uint = fixedPointFraction32
+	)
+
+	switch {
+	case reflectionType == timeType && length == 32:
+		value, ok = seconds, seconds>>32 == 0
+
+		return
+
+	case length == 32:
+		fraction = fixedPointFraction16
+	}
+
+	ok = seconds>>(length-fraction) == 0
+
+	value = seconds<<fraction | nanoseconds<<fraction/nanosecondsPerSecond
+
+	return
+}
+
+func (t timeEncoding) ntpSeconds(reflectionType reflect.Type,
+	value uint64, length uint,
+) (
+	seconds, nanoseconds uint64,
+) {
+	// Round fractions to the nearest nanosecond,
+	// so that times and durations convert back as they were.
+
+	var (
+		fraction uint = fixedPointFraction32
+	)
+
+	switch {
+	case reflectionType == timeType && length == 32:
+		seconds = value
+
+		return
+
+	case length == 32:
+		fraction = fixedPointFraction16
+	}
+
+	seconds = value >> fraction
+
+	nanoseconds = (value&(1<<fraction-1)*nanosecondsPerSecond +
+		1<<(fraction-1)) >> fraction
+
+	return
+}
+
+func dosUint64(stamp time.Time) (value uint64, ok bool) {
+	// Pack a date and time as in the headers of ZIP files,
+	// the date in the more significant half, to a resolution of two seconds.
+
+	if stamp.Year() < dosYearLowerLimit || stamp.Year() > dosYearUpperLimit {
+		return
+	}
+
+	value = uint64(stamp.Year()-dosYearLowerLimit)<<25 |
+		uint64(stamp.Month())<<21 |
+		uint64(stamp.Day())<<16 |
+		uint64(stamp.Hour())<<11 |
+		uint64(stamp.Minute())<<5 |
+		uint64(stamp.Second())>>1
+
+	ok = true
+
+	return
+}
+
+func dosTime(value uint64) (stamp time.Time, e error) {
+	// A date of zero, which is no date, is the zero time.
+	// Fields out of range are refused rather than normalised.
+
+	var (
+		year   = int(value>>25&0x7f) + dosYearLowerLimit
+		month  = time.Month(value >> 21 & 0xf)
+		day    = int(value >> 16 & 0x1f)
+		hour   = int(value >> 11 & 0x1f)
+		minute = int(value >> 5 & 0x3f)
+		second = int(value&0x1f) << 1
+	)
+
+	if value>>16 == 0 {
+		return
+	}
+
+	switch {
+	case month < time.January || month > time.December:
+		e = fmt.Errorf("month %d invalid in DOS date", month)
+
+	case day < 1 || day > time.Date(year, month+1, 0, 0, 0, 0, 0,
+		time.UTC).Day():
+		e = fmt.Errorf("day %d invalid in DOS date of month %d", day, month)
+
+	case hour > 23:
+		e = fmt.Errorf("hour %d invalid in DOS time", hour)
+
+	case minute > 59:
+		e = fmt.Errorf("minute %d invalid in DOS time", minute)
+
+	case second > 59:
+		e = fmt.Errorf("second %d invalid in DOS time", second)
+	}
+
+	if e != nil {
+		return
+	}
+
+	stamp = time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+
+	return
+}
+
+func (t timeEncoding) ptpBytes(reflection reflect.Value) (
+	bytes []byte, e error,
+) {
+	// Hold seconds in 48 bits and nanoseconds in 32, as in IEEE 1588.
+
+	var (
+		i           int
+		nanoseconds uint64
+		seconds     uint64
+	)
+
+	seconds, e = t.uint64Of(reflection, ptpSecondsLength)
+	if e != nil {
+		return
+	}
+
+	nanoseconds = uint64(
+		reflection.Interface().(time.Time).Nanosecond(),
+	)
+
+	bytes = make([]byte, ptpLength/8)
+
+	for i = ptpSecondsLength/8 - 1; i >= 0; i-- {
+		bytes[i] = byte(seconds)
+
+		seconds = seconds >> 8
+	}
+
+	for i = len(bytes) - 1; i >= ptpSecondsLength/8; i-- {
+		bytes[i] = byte(nanoseconds)
+
+		nanoseconds = nanoseconds >> 8
+	}
+
+	return
+}
+
+func (t timeEncoding) setPTPBytes(bytes []byte, reflection reflect.Value) (
+	e error,
+) {
+	var (
+		b           byte
+		nanoseconds uint64
+		seconds     uint64
+		stamp       time.Time
+	)
+
+	for _, b = range bytes[:ptpSecondsLength/8] {
+		seconds = seconds<<8 | uint64(b)
+	}
+
+	for _, b = range bytes[ptpSecondsLength/8:] {
+		nanoseconds = nanoseconds<<8 | uint64(b)
+	}
+
+	if nanoseconds >= nanosecondsPerSecond {
+		e = fmt.Errorf("%d nanoseconds invalid in PTP timestamp",
+			nanoseconds,
+		)
+
+		return
+	}
+
+	stamp, e = t.timeOf(seconds, seconds, nanoseconds, true)
+	if e != nil {
+		return
+	}
+
+	reflection.Set(
+		reflect.ValueOf(stamp),
+	)
+
+	return
+}
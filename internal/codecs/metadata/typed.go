@@ -0,0 +1,336 @@
+package metadata
+
+import (
+	"reflect"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+// Addresses, ports, times, durations and strings are typed values,
+// converted to and from the unsigned integers of bit fields
+// rather than held in them as they are.
+// Those converted through bytes (addresses, ports, PTP timestamps
+// and strings) may be longer than a word,
+// and are then split across words, each holding part of the bytes.
+
+func isTypedType(reflectionType reflect.Type) (typed bool) {
+	_, typed = networkLengths(reflectionType)
+
+	typed = typed || isTimeType(reflectionType)
+
+	// Strings converting themselves to and from bytes are left to methods.
+
+	typed = typed || isStringType(reflectionType) &&
+		!implementsBinaryMarshaler(reflectionType)
+
+	return
+}
+
+func newTypedBitFieldMetadata(bitField BitFieldMetadata) (
+	typed BitFieldMetadata, e error,
+) {
+	typed = bitField
+
+	typed.typed = true
+
+	typed.wholeLength = bitField.length
+
+	if isStringType(bitField.reflectionType) {
+		typed.text, e = parseStringEncoding(bitField.options)
+		if e != nil {
+			e = validation.NewBitFieldWithMalformedTagError()
+
+			return
+		}
+
+		e = checkStringLength(bitField.length)
+		if e != nil {
+			return
+		}
+
+		return
+	}
+
+	if !isTimeType(bitField.reflectionType) {
+		e = checkNetworkLength(bitField.reflectionType, bitField.length)
+		if e != nil {
+			return
+		}
+
+		return
+	}
+
+	typed.encoding, e = parseTimeEncoding(bitField.reflectionType,
+		bitField.options,
+	)
+	if e != nil {
+		e = validation.NewBitFieldWithMalformedTagError()
+
+		return
+	}
+
+	e = typed.encoding.checkLength(bitField.length)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (m BitFieldMetadata) bytewise() bool {
+	// Whether the value is converted through bytes
+
+	return m.encoding.name == "" || m.encoding.name == ptpEncoding
+}
+
+func (m BitFieldMetadata) typedBytes(reflection reflect.Value) (
+	bytes []byte, e error,
+) {
+	if m.encoding.name == ptpEncoding {
+		bytes, e = m.encoding.ptpBytes(reflection)
+		if e != nil {
+			e = validation.NewBitFieldTimeOutOfRangeError(e)
+
+			return
+		}
+
+		return
+	}
+
+	if isStringType(m.reflectionType) {
+		bytes, e = m.text.stringBytes(reflection,
+			int(m.wholeLength/8),
+		)
+		if e != nil {
+			e = validation.NewBitFieldStringUnfitError(e)
+
+			return
+		}
+
+		return
+	}
+
+	bytes, e = networkBytes(reflection,
+		int(m.wholeLength/8),
+	)
+	if e != nil {
+		e = validation.NewBitFieldNetworkValueUnfitError(e)
+
+		return
+	}
+
+	return
+}
+
+func (m BitFieldMetadata) setTypedBytes(bytes []byte,
+	reflection reflect.Value,
+) (
+	e error,
+) {
+	if m.encoding.name == ptpEncoding {
+		e = m.encoding.setPTPBytes(bytes, reflection)
+		if e != nil {
+			e = validation.NewBitFieldInvalidTimeError(e)
+
+			return
+		}
+
+		return
+	}
+
+	if isStringType(m.reflectionType) {
+		m.text.setStringBytes(bytes, reflection)
+
+		return
+	}
+
+	setNetworkBytes(bytes, reflection)
+
+	return
+}
+
+func (m BitFieldMetadata) marshalTyped(reflection reflect.Value) (
+	value uint64, e error,
+) {
+	// Read the bytes covered by the bit field,
+	// which may be part of a value split across words.
+
+	var (
+		b     byte
+		bytes []byte
+	)
+
+	if !m.bytewise() {
+		value, e = m.encoding.uint64Of(reflection, m.length)
+		if e != nil {
+			e = validation.NewBitFieldTimeOutOfRangeError(e)
+
+			return
+		}
+
+		return
+	}
+
+	bytes, e = m.typedBytes(reflection)
+	if e != nil {
+		return
+	}
+
+	for _, b = range m.partOf(bytes) {
+		value = value<<8 | uint64(b)
+	}
+
+	return
+}
+
+func (m BitFieldMetadata) unmarshalTyped(value uint64,
+	reflection reflect.Value,
+) (
+	e error,
+) {
+	// Replace the bytes covered by the bit field,
+	// keeping those of other parts of a value split across words.
+
+	var (
+		bytes []byte
+		i     int
+		part  []byte
+	)
+
+	if !m.bytewise() {
+		e = m.encoding.setUint64(value, reflection, m.length)
+		if e != nil {
+			e = validation.NewBitFieldInvalidTimeError(e)
+
+			return
+		}
+
+		return
+	}
+
+	// Values that do not convert (e.g. strings too long) are replaced.
+
+	bytes, e = m.typedBytes(reflection)
+	if e != nil {
+		bytes, e = make([]byte, m.wholeLength/8), nil
+	}
+
+	part = m.partOf(bytes)
+
+	for i = len(part) - 1; i >= 0; i-- {
+		part[i] = byte(value)
+
+		value = value >> 8
+	}
+
+	if isStringType(m.reflectionType) {
+		e = m.text.checkCharacters(
+			string(part),
+		)
+		if e != nil {
+			e = validation.NewBitFieldStringUnfitError(e)
+
+			return
+		}
+	}
+
+	e = m.setTypedBytes(bytes, reflection)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (m BitFieldMetadata) partOf(bytes []byte) []byte {
+	return bytes[m.partOffset:][:m.length/8]
+}
+
+func (m BitFieldMetadata) parts() (lengths []uint) {
+	// Split IPv6 addresses in halves,
+	// PTP timestamps in seconds and nanoseconds,
+	// and strings in eight bytes at a time.
+
+	var (
+		length uint
+	)
+
+	switch {
+	case m.wholeLength <= maximumLengthInBits:
+		return
+
+	case isStringType(m.reflectionType):
+		length = m.wholeLength
+
+		for length > maximumLengthInBits {
+			lengths = append(lengths, maximumLengthInBits)
+
+			length -= maximumLengthInBits
+		}
+
+		lengths = append(lengths, length)
+
+	case m.encoding.name == ptpEncoding:
+		lengths = []uint{ptpSecondsLength, ptpLength - ptpSecondsLength}
+
+	default:
+		lengths = []uint{m.wholeLength / 2, m.wholeLength / 2}
+	}
+
+	return
+}
+
+func (m BitFieldMetadata) TypedValue(value uint64) (
+	decoded interface{}, ok bool,
+) {
+	// Convert the value of a bit field holding an address, port,
+	// time, duration or string to its type (e.g. netip.Addr or time.Time),
+	// which prints as usually written.
+
+	var (
+		e          error
+		reflection reflect.Value
+	)
+
+	if !m.typed || m.length > maximumLengthInBits {
+		return
+	}
+
+	reflection = reflect.New(m.reflectionType).Elem()
+
+	e = m.unmarshalTyped(value, reflection)
+	if e != nil {
+		return
+	}
+
+	decoded, ok = reflection.Interface(), true
+
+	return
+}
+
+func (m BitFieldMetadata) TypedBytesValue(bytes []byte) (
+	decoded interface{}, ok bool,
+) {
+	// Convert the bytes of a bit field longer than 64 bits,
+	// as given in BitFieldValue, to its type.
+
+	var (
+		e          error
+		reflection reflect.Value
+	)
+
+	if !m.typed || len(bytes) != int(m.wholeLength/8) {
+		return
+	}
+
+	reflection = reflect.New(m.reflectionType).Elem()
+
+	e = m.setTypedBytes(bytes, reflection)
+	if e != nil {
+		return
+	}
+
+	decoded, ok = reflection.Interface(), true
+
+	return
+}
@@ -0,0 +1,380 @@
+package metadata
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"reflect"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+// Words may hold a variable-length integer
+// of no more bits than the length in their tags,
+// in unsigned LEB128 (as are Protocol Buffers varints),
+// signed LEB128 (as in DWARF and WebAssembly),
+// zig-zag (as are Protocol Buffers sint fields),
+// the two-bit-prefixed encoding of QUIC,
+// or the remaining length of MQTT,
+// making their formats variable in length.
+
+const (
+	uleb128Varint = "uleb128"
+	sleb128Varint = "sleb128"
+	zigzagVarint  = "zigzag"
+	quicVarint    = "quic"
+	mqttVarint    = "mqtt"
+
+	varintGroupLength = 7
+	varintContinue    = 0x80
+	varintSign        = 0x40
+	varintPayload     = 0x7f
+
+	quicPrefixLength = 2
+)
+
+func isUnsignedKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		return true
+	}
+
+	return false
+}
+
+func parseVarintEncoding(reflectionType reflect.Type, options []string) (
+	encoding string, e error,
+) {
+	// Recognise the options "uleb128", "quic" and "mqtt",
+	// applying only to unsigned integers,
+	// and "sleb128" and "zigzag", applying only to signed integers.
+
+	var (
+		option string
+		signed bool
+	)
+
+	for _, option = range options {
+		switch option {
+		case uleb128Varint, sleb128Varint, zigzagVarint, quicVarint,
+			mqttVarint:
+			if encoding != "" {
+				e = errors.New("contradictory varint encodings")
+
+				return
+			}
+
+			encoding = option
+		}
+	}
+
+	signed = isSignedVarint(encoding)
+
+	switch {
+	case encoding == "":
+		return
+
+	case signed && isSignedKind(reflectionType.Kind()):
+		return
+
+	case !signed && isUnsignedKind(reflectionType.Kind()):
+		return
+	}
+
+	e = fmt.Errorf("encoding %s not of %s", encoding, reflectionType)
+
+	return
+}
+
+func isSignedVarint(encoding string) bool {
+	return encoding == sleb128Varint || encoding == zigzagVarint
+}
+
+func newVarintBitFieldMetadata(bitField BitFieldMetadata, encoding string) (
+	varint BitFieldMetadata, e error,
+) {
+	// Describe the integer held by a word as one bit field
+	// spanning the length of the word.
+
+	var (
+		lengthCap = map[string]uint{
+			uleb128Varint: 64,
+			sleb128Varint: 64,
+			zigzagVarint:  64,
+			quicVarint:    62,
+			mqttVarint:    28,
+		}[encoding]
+	)
+
+	if bitField.length == 0 || bitField.length > lengthCap {
+		e = validation.NewBitFieldOfLengthUnfitForVarintError(
+			bitField.length,
+			encoding,
+			fmt.Sprintf("[1, %d]", lengthCap),
+		)
+
+		return
+	}
+
+	if bitField.length > uint(bitField.reflectionType.Bits()) {
+		e = validation.NewBitFieldOfLengthOverflowingTypeError(
+			bitField.length,
+			bitField.reflectionType.String(),
+		)
+
+		return
+	}
+
+	varint = bitField
+
+	if isSignedKind(bitField.kind) {
+		varint.signed = twosComplement
+	}
+
+	return
+}
+
+func varintLengthCap(encoding string, length uint) (lengthInBytes int) {
+	// Count the bytes needed to encode the greatest integer
+	// of the given number of bits.
+
+	const (
+		quicLengthInBytes = 8
+	)
+
+	if encoding != quicVarint {
+		lengthInBytes = int(
+			(length + varintGroupLength - 1) / varintGroupLength,
+		)
+
+		return
+	}
+
+	for lengthInBytes = 1; lengthInBytes < quicLengthInBytes; {
+		if length <= uint(8*lengthInBytes-quicPrefixLength) {
+			break
+		}
+
+		lengthInBytes = lengthInBytes * 2
+	}
+
+	return
+}
+
+func checkVarintRange(bitField BitFieldMetadata, number uint64) (e error) {
+	// Fail given integers of more bits than the word,
+	// rather than drop the most significant bits.
+
+	var (
+		length = bitField.length
+		signed = int64(number)
+	)
+
+	switch {
+	case length == 64:
+		return
+
+	case bitField.signed == "" && number>>length != 0:
+		e = fmt.Errorf("%d overflowing %d bit(s)", number, length)
+
+	case bitField.signed != "" && (signed < -1<<(length-1) ||
+		signed >= 1<<(length-1)):
+		e = fmt.Errorf("%d overflowing %d bit(s)", signed, length)
+	}
+
+	if e != nil {
+		e = validation.NewBitFieldVarintOutOfRangeError(e)
+
+		return
+	}
+
+	return
+}
+
+func encodeVarint(encoding string, number uint64) (bytes []byte) {
+	// Encode an integer, signed integers given in two's complement,
+	// in as few bytes as the encoding allows.
+
+	var (
+		b      byte
+		i      int
+		length int
+		signed = int64(number)
+	)
+
+	switch encoding {
+	case sleb128Varint:
+		for {
+			b = byte(signed) & varintPayload
+
+			signed = signed >> varintGroupLength
+
+			if signed == 0 && b&varintSign == 0 ||
+				signed == -1 && b&varintSign != 0 {
+				bytes = append(bytes, b)
+
+				return
+			}
+
+			bytes = append(bytes, b|varintContinue)
+		}
+
+	case zigzagVarint:
+		number = uint64(signed<<1) ^ uint64(signed>>63)
+
+	case quicVarint:
+		length = varintLengthCap(quicVarint,
+			uint(
+				bits.Len64(number),
+			),
+		)
+
+		bytes = make([]byte, length)
+
+		for i = length - 1; i >= 0; i-- {
+			bytes[i] = byte(number)
+
+			number = number >> 8
+		}
+
+		bytes[0] |= byte(
+			bits.TrailingZeros(uint(length)),
+		) << (8 - quicPrefixLength)
+
+		return
+	}
+
+	bytes = make([]byte, binary.MaxVarintLen64)
+
+	bytes = bytes[:binary.PutUvarint(bytes, number)]
+
+	return
+}
+
+func decodeVarint(encoding string, bytes []byte, length uint) (
+	number uint64, n int, e error,
+) {
+	// Decode an integer from the start of a byte slice,
+	// failing given a slice ending within the integer,
+	// an integer of more bytes than the word allows,
+	// or of more bits than the word.
+
+	var (
+		b         byte
+		lengthCap = varintLengthCap(encoding, length)
+		integer   int64
+		shift     uint
+		signed    = isSignedVarint(encoding)
+	)
+
+	if len(bytes) > lengthCap {
+		bytes = bytes[:lengthCap]
+	}
+
+	switch encoding {
+	case sleb128Varint:
+		for n = 0; n < len(bytes); {
+			b = bytes[n]
+
+			n++
+
+			integer |= int64(b&varintPayload) << shift
+
+			shift += varintGroupLength
+
+			if b&varintContinue != 0 {
+				continue
+			}
+
+			if shift < 64 && b&varintSign != 0 {
+				integer |= -1 << shift
+			}
+
+			number = uint64(integer)
+
+			e = checkVarintDecoded(signed, number, length)
+
+			return
+		}
+
+		n = 0
+
+	case quicVarint:
+		if len(bytes) == 0 {
+			break
+		}
+
+		n = 1 << (bytes[0] >> 6)
+
+		if n > lengthCap {
+			e = fmt.Errorf("overflowing %d byte(s)", lengthCap)
+
+			return
+		}
+
+		if n > len(bytes) {
+			n = 0
+
+			break
+		}
+
+		for _, b = range bytes[:n] {
+			number = number<<8 | uint64(b)
+		}
+
+		number = number & (1<<(8*n-quicPrefixLength) - 1)
+
+		e = checkVarintDecoded(signed, number, length)
+
+		return
+
+	default:
+		number, n = binary.Uvarint(bytes)
+
+		if n < 0 {
+			e = fmt.Errorf("overflowing %d bit(s)", length)
+
+			return
+		}
+
+		if encoding == zigzagVarint {
+			number = uint64(int64(number>>1) ^ -int64(number&1))
+		}
+	}
+
+	switch {
+	case n > 0:
+		e = checkVarintDecoded(signed, number, length)
+
+	case len(bytes) < lengthCap:
+		e = errors.New("truncated")
+
+	default:
+		e = fmt.Errorf("overflowing %d byte(s)", lengthCap)
+	}
+
+	return
+}
+
+func checkVarintDecoded(signed bool, number uint64, length uint) (
+	e error,
+) {
+	// Fail given integers decoded of more bits than their bit fields,
+	// signed integers given in two's complement.
+
+	switch {
+	case length == 64:
+		return
+
+	case !signed && number>>length != 0:
+		e = fmt.Errorf("of %d overflowing %d bit(s)", number, length)
+
+	case signed && (int64(number) < -1<<(length-1) ||
+		int64(number) >= 1<<(length-1)):
+		e = fmt.Errorf("of %d overflowing %d bit(s)", int64(number), length)
+	}
+
+	return
+}
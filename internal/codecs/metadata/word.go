@@ -2,24 +2,33 @@ package metadata
 
 import (
 	"encoding/binary"
-	"fmt"
+	"errors"
 	"reflect"
 
 	"github.com/encodingx/binary/internal/validation"
 )
 
-type wordMetadata struct {
-	bitFields     []bitFieldMetadata
+type WordMetadata struct {
+	name          string
+	bitFields     []BitFieldMetadata
 	lengthInBits  uint
 	lengthInBytes int
+	byteOffset    int
+	options       []string
+	littleEndian  bool
+	lsbFirst      bool
+	marshaler     bool
+	typed         bool
+	varint        string
+	bitStream     bool
+	fieldIndex    int
 }
 
 func newWordMetadataFromStructFieldReflection(reflection reflect.StructField) (
-	word wordMetadata, e error,
+	word WordMetadata, e error,
 ) {
 	const (
-		tagKey         = "word"
-		tagValueFormat = "%d"
+		tagKey = "word"
 
 		wordLengthFactor     = 8
 		wordLengthLowerLimit = 8
@@ -27,7 +36,16 @@ func newWordMetadataFromStructFieldReflection(reflection reflect.StructField) (
 	)
 
 	var (
+		bitField     BitFieldMetadata
+		bitStream    bool
+		integer      bool
+		littleEndian bool
+		lsbFirst     bool
+		marshaler    bool
 		offset       uint
+		typed        bool
+		options      []string
+		varint       string
 		wordLength   uint
 		wordLengthOK bool
 
@@ -40,7 +58,16 @@ func newWordMetadataFromStructFieldReflection(reflection reflect.StructField) (
 		}
 	}()
 
-	if reflection.Type.Kind() != reflect.Struct {
+	typed = isTypedType(reflection.Type)
+
+	marshaler = !typed && implementsBinaryMarshaler(reflection.Type)
+
+	integer = !typed && !marshaler &&
+		(isSignedKind(reflection.Type.Kind()) ||
+			isUnsignedKind(reflection.Type.Kind()))
+
+	if !typed && !marshaler && !integer &&
+		reflection.Type.Kind() != reflect.Struct {
 		e = validation.NewWordNotStructError()
 
 		return
@@ -52,10 +79,8 @@ func newWordMetadataFromStructFieldReflection(reflection reflect.StructField) (
 		return
 	}
 
-	_, e = fmt.Sscanf(
+	wordLength, options, e = parseTagValue(
 		reflection.Tag.Get(tagKey),
-		tagValueFormat,
-		&wordLength,
 	)
 	if e != nil {
 		e = validation.NewWordWithMalformedTagError()
@@ -63,9 +88,110 @@ func newWordMetadataFromStructFieldReflection(reflection reflect.StructField) (
 		return
 	}
 
+	littleEndian, e = parseWordByteOrder(options)
+	if e != nil {
+		e = validation.NewWordWithMalformedTagError()
+
+		return
+	}
+
+	lsbFirst, e = parseWordBitOrder(options)
+	if e != nil {
+		e = validation.NewWordWithMalformedTagError()
+
+		return
+	}
+
+	varint, e = parseVarintEncoding(reflection.Type, options)
+	if e != nil || varint != "" && !integer {
+		e = validation.NewWordWithMalformedTagError()
+
+		return
+	}
+
+	// Bit streams are of no fixed length, most significant bit first.
+
+	bitStream = isBitStreamWord(options)
+
+	if bitStream && (wordLength != 0 || typed || marshaler || integer ||
+		littleEndian || lsbFirst) {
+		e = validation.NewWordWithMalformedTagError()
+
+		return
+	}
+
+	if bitStream {
+		word, e = newBitStreamWordMetadata(reflection, options)
+		if e != nil {
+			return
+		}
+
+		return
+	}
+
+	if integer && varint == "" {
+		e = validation.NewWordNotStructError()
+
+		return
+	}
+
+	if integer {
+		// Words holding a variable-length integer
+		// are described as one bit field of the length of the word,
+		// counted in bits of the integer rather than of its encoding.
+
+		bitField, e = newVarintBitFieldMetadata(
+			BitFieldMetadata{
+				name:           reflection.Name,
+				length:         wordLength,
+				kind:           reflection.Type.Kind(),
+				reflectionType: reflection.Type,
+				options:        options,
+			},
+			varint,
+		)
+		if e != nil {
+			e.(validation.BitFieldError).SetBitFieldName(reflection.Name)
+
+			return
+		}
+
+		word = WordMetadata{
+			name:          reflection.Name,
+			bitFields:     []BitFieldMetadata{bitField},
+			lengthInBits:  wordLength,
+			lengthInBytes: varintLengthCap(varint, wordLength),
+			options:       options,
+			varint:        varint,
+		}
+
+		return
+	}
+
+	if typed {
+		// Words holding an address, port, time, duration or string
+		// are described as one bit field spanning the whole word,
+		// which may be longer than other words until split.
+
+		bitField, e = newTypedBitFieldMetadata(
+			BitFieldMetadata{
+				name:           reflection.Name,
+				length:         wordLength,
+				kind:           reflection.Type.Kind(),
+				reflectionType: reflection.Type,
+				options:        options,
+			},
+		)
+		if e != nil {
+			e.(validation.BitFieldError).SetBitFieldName(reflection.Name)
+
+			return
+		}
+	}
+
 	wordLengthOK = wordLength%wordLengthFactor == 0
 	wordLengthOK = wordLengthOK && wordLength >= wordLengthLowerLimit
-	wordLengthOK = wordLengthOK && wordLength <= wordLengthUpperLimit
+	wordLengthOK = wordLengthOK && (wordLength <= wordLengthUpperLimit || typed)
 
 	if !wordLengthOK {
 		e = validation.NewWordOfIncompatibleLengthError(wordLength)
@@ -73,21 +199,65 @@ func newWordMetadataFromStructFieldReflection(reflection reflect.StructField) (
 		return
 	}
 
+	if typed {
+		word = WordMetadata{
+			name:          reflection.Name,
+			bitFields:     []BitFieldMetadata{bitField},
+			lengthInBits:  wordLength,
+			lengthInBytes: int(wordLength / wordLengthFactor),
+			options:       options,
+			littleEndian:  littleEndian,
+			typed:         true,
+		}
+
+		return
+	}
+
+	if marshaler {
+		// Words converting themselves to and from bytes are opaque,
+		// and are described as one bit field spanning the whole word.
+
+		word = WordMetadata{
+			name: reflection.Name,
+			bitFields: []BitFieldMetadata{
+				{
+					name:           reflection.Name,
+					length:         wordLength,
+					kind:           reflect.Uint64,
+					reflectionType: reflection.Type,
+				},
+			},
+			lengthInBits:  wordLength,
+			lengthInBytes: int(wordLength / wordLengthFactor),
+			options:       options,
+			littleEndian:  littleEndian,
+			marshaler:     true,
+		}
+
+		return
+	}
+
 	if reflection.Type.NumField() == 0 {
 		e = validation.NewWordWithNoBitFieldsError()
 
 		return
 	}
 
-	word = wordMetadata{
-		bitFields: make([]bitFieldMetadata,
+	word = WordMetadata{
+		name: reflection.Name,
+		bitFields: make([]BitFieldMetadata,
 			reflection.Type.NumField(),
 		),
 		lengthInBits:  wordLength,
 		lengthInBytes: int(wordLength / wordLengthFactor),
+		options:       options,
+		littleEndian:  littleEndian,
+		lsbFirst:      lsbFirst,
 	}
 
-	offset = wordLength
+	// Allocate bit fields from the most significant bit by default,
+	// or from the least significant bit as do many C compilers
+	// on little-endian targets.
 
 	for i = 0; i < reflection.Type.NumField(); i++ {
 		word.bitFields[i], e = newBitFieldMetadataFromStructFieldReflection(
@@ -97,15 +267,32 @@ func newWordMetadataFromStructFieldReflection(reflection reflect.StructField) (
 			return
 		}
 
-		offset -= word.bitFields[i].length
+		if word.bitFields[i].golomb != "" {
+			e = validation.NewBitFieldWithMalformedTagError()
+
+			e.(validation.BitFieldError).SetBitFieldName(
+				word.bitFields[i].name,
+			)
 
-		word.bitFields[i].offset = uint64(offset)
+			return
+		}
+
+		if lsbFirst {
+			word.bitFields[i].offset = uint64(offset)
+
+			offset += word.bitFields[i].length
+
+		} else {
+			offset += word.bitFields[i].length
+
+			word.bitFields[i].offset = uint64(wordLength - offset)
+		}
 	}
 
-	if offset != 0 {
+	if offset != wordLength {
 		e = validation.NewWordOfLengthNotEqualToSumOfLengthsOfBitFieldsError(
 			wordLength,
-			wordLength-offset,
+			offset,
 		)
 
 		return
@@ -114,18 +301,67 @@ func newWordMetadataFromStructFieldReflection(reflection reflect.StructField) (
 	return
 }
 
-func (m wordMetadata) marshal(reflection reflect.Value) (bytes []byte) {
+func (m WordMetadata) marshal(reflection reflect.Value) (
+	bytes []byte, e error,
+) {
 	var (
-		bitField       bitFieldMetadata
+		bitField       BitFieldMetadata
 		bitFieldUint64 uint64
 		i              int
 		wordUint64     uint64
 	)
 
+	defer func() {
+		if e != nil {
+			e.(validation.WordError).SetWordName(m.name)
+		}
+	}()
+
+	if m.varint != "" {
+		bytes, e = m.marshalVarint(reflection)
+		if e != nil {
+			return
+		}
+
+		return
+	}
+
+	if m.bitStream {
+		bytes, e = m.marshalBitStream(reflection)
+		if e != nil {
+			return
+		}
+
+		return
+	}
+
+	if m.marshaler {
+		// Methods produce bytes in big-endian order,
+		// reversed as for other words if the word is little-endian.
+
+		bytes, e = marshalBinary(reflection, m.lengthInBytes)
+		if e != nil {
+			e = validation.NewWordMarshalerFailedError(e)
+
+			return
+		}
+
+		if m.littleEndian {
+			bytes = append([]byte(nil), bytes...)
+
+			reverseBytes(bytes)
+		}
+
+		return
+	}
+
 	for i, bitField = range m.bitFields {
-		bitFieldUint64 = bitField.marshal(
-			reflection.Field(i),
+		bitFieldUint64, e = bitField.marshal(
+			m.bitFieldReflection(reflection, i),
 		)
+		if e != nil {
+			return
+		}
 
 		wordUint64 = wordUint64 | bitFieldUint64
 	}
@@ -136,15 +372,68 @@ func (m wordMetadata) marshal(reflection reflect.Value) (bytes []byte) {
 
 	bytes = bytes[wordLengthUpperLimitBytes-m.lengthInBytes:]
 
+	if m.littleEndian {
+		reverseBytes(bytes)
+	}
+
 	return
 }
 
-func (m wordMetadata) unmarshal(bytes []byte, reflection reflect.Value) {
+func (m WordMetadata) unmarshal(bytes []byte, reflection reflect.Value) (
+	e error,
+) {
 	var (
 		bitFieldBytes []byte
 		i             int
 	)
 
+	defer func() {
+		if e != nil {
+			e.(validation.WordError).SetWordName(m.name)
+		}
+	}()
+
+	if m.varint != "" {
+		m.unmarshalVarint(bytes, reflection)
+
+		return
+	}
+
+	if m.bitStream {
+		e = m.unmarshalBitStream(bytes, reflection)
+		if e != nil {
+			return
+		}
+
+		return
+	}
+
+	if m.littleEndian {
+		// Bytes preceding the word, passed in for alignment, do not matter.
+
+		bytes = append([]byte(nil),
+			bytes[len(bytes)-m.lengthInBytes:]...,
+		)
+
+		reverseBytes(bytes)
+	}
+
+	if m.marshaler {
+		e = unmarshalBinary(
+			append([]byte(nil),
+				bytes[len(bytes)-m.lengthInBytes:]...,
+			),
+			reflection,
+		)
+		if e != nil {
+			e = validation.NewWordMarshalerFailedError(e)
+
+			return
+		}
+
+		return
+	}
+
 	for i = 0; i < len(m.bitFields); i++ {
 		if len(bytes) < wordLengthUpperLimitBytes {
 			bitFieldBytes = make([]byte, wordLengthUpperLimitBytes)
@@ -157,9 +446,303 @@ func (m wordMetadata) unmarshal(bytes []byte, reflection reflect.Value) {
 			bitFieldBytes = bytes
 		}
 
-		m.bitFields[i].unmarshal(bitFieldBytes,
-			reflection.Field(i),
+		e = m.bitFields[i].unmarshal(bitFieldBytes,
+			m.bitFieldReflection(reflection, i),
+		)
+		if e != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (m WordMetadata) marshalVarint(reflection reflect.Value) (
+	bytes []byte, e error,
+) {
+	var (
+		number uint64
+	)
+
+	if m.bitFields[0].signed != "" {
+		number = uint64(
+			reflection.Int(),
+		)
+
+	} else {
+		number = reflection.Uint()
+	}
+
+	e = checkVarintRange(m.bitFields[0], number)
+	if e != nil {
+		e.(validation.BitFieldError).SetBitFieldName(m.name)
+
+		return
+	}
+
+	bytes = encodeVarint(m.varint, number)
+
+	return
+}
+
+func (m WordMetadata) unmarshalVarint(bytes []byte, reflection reflect.Value) {
+	// Bytes are those of the integer alone,
+	// found and checked when laying out the format.
+
+	var (
+		number uint64
+	)
+
+	number, _, _ = decodeVarint(m.varint, bytes, m.lengthInBits)
+
+	if m.bitFields[0].signed != "" {
+		reflection.SetInt(
+			int64(number),
 		)
+
+		return
+	}
+
+	reflection.SetUint(number)
+
+	return
+}
+
+func (m WordMetadata) bitFieldReflection(reflection reflect.Value, i int) (
+	bitField reflect.Value,
+) {
+	// Words holding an address, port, time, duration or string
+	// are their own bit fields.
+
+	if m.typed {
+		bitField = reflection
+
+		return
+	}
+
+	bitField = reflection.Field(i)
+
+	return
+}
+
+func (m WordMetadata) split() (words []WordMetadata) {
+	// Split a word holding an IPv6 address, PTP timestamp or long string
+	// into words of no more than 64 bits, each holding part of its bytes,
+	// in reverse order if the word is little-endian.
+	// Parts are marshalled and unmarshalled in place of the word,
+	// keeping its name and that of its bit field.
+
+	var (
+		i       int
+		lengths []uint
+		offset  int
+		word    WordMetadata
+	)
+
+	lengths = m.bitFields[0].parts()
+
+	if len(lengths) == 0 {
+		words = []WordMetadata{m}
+
+		return
+	}
+
+	for i = range lengths {
+		word = m
+
+		word.lengthInBits = lengths[i]
+		word.lengthInBytes = int(lengths[i] / 8)
+
+		word.bitFields = []BitFieldMetadata{m.bitFields[0]}
+
+		word.bitFields[0].length = lengths[i]
+		word.bitFields[0].partOffset = offset
+
+		offset += word.lengthInBytes
+
+		words = append(words, word)
+	}
+
+	if m.littleEndian {
+		for i = 0; i < len(words)/2; i++ {
+			words[i], words[len(words)-1-i] = words[len(words)-1-i], words[i]
+		}
+	}
+
+	return
+}
+
+func (m WordMetadata) Name() string {
+	return m.name
+}
+
+func (m WordMetadata) BitFields() []BitFieldMetadata {
+	return m.bitFields
+}
+
+func (m WordMetadata) LengthInBits() uint {
+	return m.lengthInBits
+}
+
+func (m WordMetadata) LengthInBytes() int {
+	return m.lengthInBytes
+}
+
+func (m WordMetadata) ByteOffset() int {
+	return m.byteOffset
+}
+
+func (m WordMetadata) Marshaler() bool {
+	// Whether the word converts itself to and from bytes
+
+	return m.marshaler
+}
+
+func (m WordMetadata) Options() []string {
+	return m.options
+}
+
+func (m WordMetadata) Varint() string {
+	// Encoding of the variable-length integer held by the word, if any
+
+	return m.varint
+}
+
+func (m WordMetadata) LittleEndian() bool {
+	return m.littleEndian
+}
+
+func (m WordMetadata) LSBFirst() bool {
+	return m.lsbFirst
+}
+
+func (m WordMetadata) Uint64(bytes []byte) (word uint64) {
+	// Read the value of the word from the bytes of its format
+
+	var (
+		b byte
+		i int
+	)
+
+	bytes = bytes[m.byteOffset : m.byteOffset+m.lengthInBytes]
+
+	if m.varint != "" {
+		word, _, _ = decodeVarint(m.varint, bytes, m.lengthInBits)
+
+		return
+	}
+
+	for i = range bytes {
+		if m.littleEndian {
+			b = bytes[len(bytes)-1-i]
+
+		} else {
+			b = bytes[i]
+		}
+
+		word = word<<8 | uint64(b)
+	}
+
+	return
+}
+
+func (m WordMetadata) PutUint64(bytes []byte, word uint64) {
+	// Write the value of the word into the bytes of its format
+
+	var (
+		i int
+	)
+
+	bytes = bytes[m.byteOffset : m.byteOffset+m.lengthInBytes]
+
+	for i = len(bytes) - 1; i >= 0; i-- {
+		if m.littleEndian {
+			bytes[len(bytes)-1-i] = byte(word)
+
+		} else {
+			bytes[i] = byte(word)
+		}
+
+		word = word >> 8
+	}
+
+	return
+}
+
+func parseWordByteOrder(options []string) (littleEndian bool, e error) {
+	// Recognise the options "bigendian" (the default) and "littleendian".
+	// Giving both is contradictory.
+
+	const (
+		bigEndianOption    = "bigendian"
+		littleEndianOption = "littleendian"
+	)
+
+	var (
+		bigEndian bool
+		option    string
+	)
+
+	for _, option = range options {
+		switch option {
+		case bigEndianOption:
+			bigEndian = true
+
+		case littleEndianOption:
+			littleEndian = true
+		}
+	}
+
+	if bigEndian && littleEndian {
+		e = errors.New("contradictory byte orders")
+
+		return
+	}
+
+	return
+}
+
+func parseWordBitOrder(options []string) (lsbFirst bool, e error) {
+	// Recognise the options "msbfirst" (the default) and "lsbfirst",
+	// which are independent of byte order.
+
+	const (
+		lsbFirstOption = "lsbfirst"
+		msbFirstOption = "msbfirst"
+	)
+
+	var (
+		msbFirst bool
+		option   string
+	)
+
+	for _, option = range options {
+		switch option {
+		case msbFirstOption:
+			msbFirst = true
+
+		case lsbFirstOption:
+			lsbFirst = true
+		}
+	}
+
+	if msbFirst && lsbFirst {
+		e = errors.New("contradictory bit orders")
+
+		return
+	}
+
+	return
+}
+
+func reverseBytes(bytes []byte) {
+	var (
+		i int
+		j int
+	)
+
+	for i, j = 0, len(bytes)-1; i < j; i, j = i+1, j-1 {
+		bytes[i], bytes[j] = bytes[j], bytes[i]
 	}
 
 	return
@@ -0,0 +1,361 @@
+package csource
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/encodingx/binary"
+)
+
+func Generate(generator string, formats ...binary.FormatDescriptor) (
+	source []byte, e error,
+) {
+	// Emit a C header with the length and offset of each format and word,
+	// functions loading and storing words in their byte orders,
+	// and masks, shifts and accessor macros for each bit field
+	// of words of no more than 64 bits.
+	// Formats of variable length have no fixed offsets, so are refused.
+
+	const (
+		header = "" +
+			"/* Code generated by %s; DO NOT EDIT. */\n" +
+			"\n" +
+			"#ifndef %s\n" +
+			"#define %s\n" +
+			"\n" +
+			"#include <stdint.h>\n"
+
+		footer = "" +
+			"\n" +
+			"#endif /* %s */\n"
+	)
+
+	var (
+		buffer bytes.Buffer
+		f      binary.FormatDescriptor
+		guard  string
+		names  []string
+	)
+
+	for _, f = range formats {
+		e = checkFixedLength(f)
+		if e != nil {
+			return
+		}
+
+		names = append(names,
+			macroName(
+				baseName(f.Name()),
+			),
+		)
+	}
+
+	guard = strings.Join(names, "_") + "_H"
+
+	fmt.Fprintf(&buffer, header, generator, guard, guard)
+
+	for _, f = range formats {
+		generateFormat(&buffer, f)
+	}
+
+	fmt.Fprintf(&buffer, footer, guard)
+
+	source = buffer.Bytes()
+
+	return
+}
+
+func checkFixedLength(format binary.FormatDescriptor) (e error) {
+	var (
+		word binary.WordDescriptor
+	)
+
+	for _, word = range format.Words() {
+		if word.Varint() != "" {
+			e = fmt.Errorf("word %s of format %s holds a variable-length "+
+				"integer, not representable at fixed offsets",
+				word.Name(), baseName(format.Name()),
+			)
+
+			return
+		}
+
+		if word.BitStream() {
+			e = fmt.Errorf("word %s of format %s is a bit stream, "+
+				"not representable at fixed offsets",
+				word.Name(), baseName(format.Name()),
+			)
+
+			return
+		}
+	}
+
+	return
+}
+
+func generateFormat(buffer *bytes.Buffer, format binary.FormatDescriptor) {
+	var (
+		bitField   binary.BitFieldDescriptor
+		enumerated binary.EnumeratedValue
+		field      string
+		prefix     string
+		repeated   map[string]bool
+		word       binary.WordDescriptor
+		wordMacro  string
+		wordType   string
+	)
+
+	prefix = macroName(
+		baseName(format.Name()),
+	)
+
+	repeated = repeatedBitFieldNames(format)
+
+	fmt.Fprintf(buffer, "\n/* %s: %d byte(s) */\n\n#define %s_LENGTH %d\n",
+		baseName(format.Name()), format.LengthInBytes(),
+		prefix, format.LengthInBytes(),
+	)
+
+	for _, word = range format.Words() {
+		wordMacro = prefix + "_" + macroName(
+			trimCommonPrefix(word.Name(),
+				baseName(format.Name()),
+			),
+		)
+
+		wordType = cType(
+			uint(word.LengthInBytes()) * 8,
+		)
+
+		fmt.Fprintf(buffer, "\n/* %s: %d bit(s) at byte offset %d, %s */\n\n",
+			word.Name(), word.LengthInBits(), word.ByteOffset(),
+			byteOrderName(word),
+		)
+
+		fmt.Fprintf(buffer, "#define %s_OFFSET %d\n#define %s_LENGTH %d\n",
+			wordMacro, word.ByteOffset(),
+			wordMacro, word.LengthInBytes(),
+		)
+
+		// Words longer than 64 bits (e.g. IPv6 addresses)
+		// fit in no integer type, so are left as bytes.
+
+		if word.LengthInBits() > 64 {
+			continue
+		}
+
+		generateLoadAndStore(buffer, word, wordMacro, wordType)
+
+		for _, bitField = range word.BitFields() {
+			field = prefix + "_" + macroName(
+				bitField.Name(),
+			)
+
+			if repeated[bitField.Name()] {
+				field = wordMacro + "_" + macroName(
+					bitField.Name(),
+				)
+			}
+
+			fmt.Fprintf(buffer, "\n#define %s_SHIFT %d\n",
+				field,
+				word.LengthInBits()-bitField.BitOffset()-bitField.LengthInBits(),
+			)
+
+			fmt.Fprintf(buffer, "#define %s_MASK UINT64_C(%#x)\n",
+				field,
+				uint64(1)<<bitField.LengthInBits()-1,
+			)
+
+			fmt.Fprintf(buffer, "#define %[1]s_GET(word) \\\n"+
+				"\t((%[2]s)(((uint64_t)(word) >> %[1]s_SHIFT) & %[1]s_MASK))\n",
+				field,
+				cType(
+					bitField.LengthInBits(),
+				),
+			)
+
+			fmt.Fprintf(buffer, "#define %[1]s_SET(word, value) \\\n"+
+				"\t((word) = (%[2]s)(((uint64_t)(word) & "+
+				"~(%[1]s_MASK << %[1]s_SHIFT)) | \\\n"+
+				"\t\t(((uint64_t)(value) & %[1]s_MASK) << %[1]s_SHIFT)))\n",
+				field, wordType,
+			)
+
+			for _, enumerated = range bitField.Enumeration() {
+				fmt.Fprintf(buffer, "#define %s_%s %d\n",
+					field,
+					macroName(enumerated.Name),
+					enumerated.Value,
+				)
+			}
+		}
+	}
+
+	return
+}
+
+func generateLoadAndStore(buffer *bytes.Buffer, word binary.WordDescriptor,
+	wordMacro, wordType string,
+) {
+	// Assemble words byte by byte,
+	// so that neither alignment nor host byte order matters.
+
+	var (
+		function string
+		i        int
+		shift    int
+		shifts   []int
+	)
+
+	for i = 0; i < word.LengthInBytes(); i++ {
+		if word.ByteOrder() == binary.LittleEndian {
+			shift = 8 * i
+
+		} else {
+			shift = 8 * (word.LengthInBytes() - 1 - i)
+		}
+
+		shifts = append(shifts, shift)
+	}
+
+	function = strings.ToLower(wordMacro)
+
+	fmt.Fprintf(buffer, "\nstatic inline %s %s_load(const uint8_t *bytes)\n"+
+		"{\n\treturn",
+		wordType, function,
+	)
+
+	for i, shift = range shifts {
+		if i > 0 {
+			buffer.WriteString(" |\n\t\t")
+
+		} else {
+			buffer.WriteString(" ")
+		}
+
+		fmt.Fprintf(buffer, "(%s)bytes[%s_OFFSET + %d] << %d",
+			wordType, wordMacro, i, shift,
+		)
+	}
+
+	buffer.WriteString(";\n}\n")
+
+	fmt.Fprintf(buffer, "\nstatic inline void %s_store(uint8_t *bytes, %s word)\n"+
+		"{\n",
+		function, wordType,
+	)
+
+	for i, shift = range shifts {
+		fmt.Fprintf(buffer, "\tbytes[%s_OFFSET + %d] = (uint8_t)(word >> %d);\n",
+			wordMacro, i, shift,
+		)
+	}
+
+	buffer.WriteString("}\n")
+
+	return
+}
+
+func repeatedBitFieldNames(format binary.FormatDescriptor) (
+	repeated map[string]bool,
+) {
+	// Bit fields need only be named uniquely in their words,
+	// so names repeated in a format are qualified by word.
+
+	var (
+		bitField binary.BitFieldDescriptor
+		seen     = make(map[string]bool)
+		word     binary.WordDescriptor
+	)
+
+	repeated = make(map[string]bool)
+
+	for _, word = range format.Words() {
+		for _, bitField = range word.BitFields() {
+			if seen[bitField.Name()] {
+				repeated[bitField.Name()] = true
+			}
+
+			seen[bitField.Name()] = true
+		}
+	}
+
+	return
+}
+
+func byteOrderName(word binary.WordDescriptor) string {
+	if word.ByteOrder() == binary.LittleEndian {
+		return "little-endian"
+	}
+
+	return "big-endian"
+}
+
+func cType(lengthInBits uint) string {
+	switch {
+	case lengthInBits <= 8:
+		return "uint8_t"
+
+	case lengthInBits <= 16:
+		return "uint16_t"
+
+	case lengthInBits <= 32:
+		return "uint32_t"
+	}
+
+	return "uint64_t"
+}
+
+func baseName(name string) string {
+	// Drop package names of format-structs (e.g. "rfc791.").
+
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func trimCommonPrefix(name, other string) string {
+	// Shorten names of words that repeat those of their formats
+	// (e.g. RFC791InternetHeaderFormatWord0 to Word0).
+
+	var (
+		i int
+	)
+
+	for i < len(name) && i < len(other) && name[i] == other[i] {
+		i++
+	}
+
+	for i > 0 && (i == len(name) || !unicode.IsUpper(rune(name[i]))) {
+		i--
+	}
+
+	return name[i:]
+}
+
+func macroName(name string) string {
+	// Convert mixed caps to upper snake case,
+	// keeping initialisms and numbers together (e.g. RFC791_INTERNET_HEADER).
+
+	var (
+		builder strings.Builder
+		i       int
+		r       rune
+		runes   = []rune(name)
+	)
+
+	for i, r = range runes {
+		if i > 0 && unicode.IsUpper(r) && runes[i-1] != '_' &&
+			(!unicode.IsUpper(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			builder.WriteRune('_')
+		}
+
+		builder.WriteRune(
+			unicode.ToUpper(r),
+		)
+	}
+
+	return strings.Trim(builder.String(), "_")
+}
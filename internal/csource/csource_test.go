@@ -0,0 +1,284 @@
+package csource
+
+import (
+	"net/netip"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/encodingx/binary"
+	"github.com/encodingx/binary/pkg/rfc791"
+)
+
+func TestGenerate(t *testing.T) {
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+		line       string
+		source     []byte
+	)
+
+	descriptor, e = binary.Describe(
+		new(rfc791.RFC791InternetHeaderFormatWithoutOptions),
+	)
+
+	assert.Nil(t, e)
+
+	source, e = Generate("test", descriptor)
+
+	assert.Nil(t, e)
+
+	for _, line = range []string{
+		"/* Code generated by test; DO NOT EDIT. */\n",
+		"#ifndef RFC791_INTERNET_HEADER_FORMAT_WITHOUT_OPTIONS_H\n",
+		"#define RFC791_INTERNET_HEADER_FORMAT_WITHOUT_OPTIONS_LENGTH 20\n",
+		"/* RFC791InternetHeaderFormatWord1: " +
+			"32 bit(s) at byte offset 4, big-endian */\n",
+		"#define RFC791_INTERNET_HEADER_FORMAT_WITHOUT_OPTIONS_WORD1_OFFSET 4\n",
+		"static inline uint32_t " +
+			"rfc791_internet_header_format_without_options_word1_load(" +
+			"const uint8_t *bytes)\n" +
+			"{\n" +
+			"\treturn (uint32_t)bytes[" +
+			"RFC791_INTERNET_HEADER_FORMAT_WITHOUT_OPTIONS_WORD1_OFFSET + 0]" +
+			" << 24 |\n",
+		"#define RFC791_INTERNET_HEADER_FORMAT_WITHOUT_OPTIONS_" +
+			"FLAGS_BIT0_RESERVED_SHIFT 15\n",
+		"#define RFC791_INTERNET_HEADER_FORMAT_WITHOUT_OPTIONS_" +
+			"FRAGMENT_OFFSET_MASK UINT64_C(0x1fff)\n",
+		"#endif /* RFC791_INTERNET_HEADER_FORMAT_WITHOUT_OPTIONS_H */\n",
+	} {
+		assert.Contains(t,
+			string(source), line,
+		)
+	}
+}
+
+func TestGenerateShouldQualifyRepeatedNames(t *testing.T) {
+	// Bit fields named alike in different words are qualified by word,
+	// and formats in one header share its include guard.
+
+	type (
+		Header struct {
+			Kind     uint8 `bitfield:"4"`
+			Reserved uint8 `bitfield:"4"`
+		}
+
+		Trailer struct {
+			Reserved uint8 `bitfield:"8"`
+		}
+
+		Frame struct {
+			Header  `word:"8"`
+			Trailer `word:"8"`
+		}
+
+		Ack struct {
+			Header `word:"8"`
+		}
+	)
+
+	var (
+		ack    binary.FormatDescriptor
+		e      error
+		frame  binary.FormatDescriptor
+		line   string
+		source []byte
+	)
+
+	frame, e = binary.Describe(
+		new(Frame),
+	)
+
+	assert.Nil(t, e)
+
+	ack, e = binary.Describe(
+		new(Ack),
+	)
+
+	assert.Nil(t, e)
+
+	source, e = Generate("test", frame, ack)
+
+	assert.Nil(t, e)
+
+	for _, line = range []string{
+		"#ifndef FRAME_ACK_H\n",
+		"#define FRAME_KIND_SHIFT 4\n",
+		"#define FRAME_HEADER_RESERVED_SHIFT 0\n",
+		"#define FRAME_TRAILER_RESERVED_MASK UINT64_C(0xff)\n",
+		"#define ACK_RESERVED_SHIFT 0\n",
+	} {
+		assert.Contains(t,
+			string(source), line,
+		)
+	}
+}
+
+func TestMacroName(t *testing.T) {
+	assert.Equal(t,
+		"RFC791_INTERNET_HEADER", macroName("RFC791InternetHeader"),
+	)
+
+	assert.Equal(t,
+		"TIME_TO_LIVE", macroName("TimeToLive"),
+	)
+
+	assert.Equal(t,
+		"Word0", trimCommonPrefix("RFC791InternetHeaderFormatWord0",
+			"RFC791InternetHeaderFormatWithoutOptions",
+		),
+	)
+}
+
+func TestGenerateShouldRefuseVarints(t *testing.T) {
+	type (
+		Header struct {
+			Kind uint8 `bitfield:"8"`
+		}
+
+		Telemetry struct {
+			Length uint16 `word:"14,quic"`
+			Header `word:"8"`
+		}
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = binary.Describe(
+		new(Telemetry),
+	)
+
+	assert.Nil(t, e)
+
+	_, e = Generate("test", descriptor)
+
+	assert.EqualError(t, e,
+		"word Length of format Telemetry holds a variable-length "+
+			"integer, not representable at fixed offsets",
+	)
+}
+
+func TestGenerateShouldRefuseBitStreams(t *testing.T) {
+	type (
+		Header struct {
+			CodeNum uint8 `bitfield:"4,ue"`
+		}
+
+		SequenceParameterSet struct {
+			Header `word:"0,bitstream"`
+		}
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = binary.Describe(
+		new(SequenceParameterSet),
+	)
+
+	assert.Nil(t, e)
+
+	_, e = Generate("test", descriptor)
+
+	assert.EqualError(t, e,
+		"word Header of format SequenceParameterSet is a bit stream, "+
+			"not representable at fixed offsets",
+	)
+}
+
+func TestGenerateShouldCompileWordsLongerThan64Bits(t *testing.T) {
+	const (
+		program = "" +
+			"#include <string.h>\n" +
+			"#include \"flow.h\"\n" +
+			"\n" +
+			"int main(void)\n" +
+			"{\n" +
+			"\tuint8_t bytes[FLOW_LENGTH] = {0};\n" +
+			"\tuint8_t address[FLOW_SOURCE_ADDRESS_LENGTH];\n" +
+			"\tuint32_t ports = flow_ports_load(bytes);\n" +
+			"\n" +
+			"\tmemcpy(address, bytes + FLOW_SOURCE_ADDRESS_OFFSET, " +
+			"sizeof(address));\n" +
+			"\tFLOW_DESTINATION_SET(ports, 443);\n" +
+			"\tflow_ports_store(bytes, ports);\n" +
+			"\n" +
+			"\treturn address[0] + FLOW_SOURCE_GET(ports);\n" +
+			"}\n"
+	)
+
+	type (
+		Ports struct {
+			Source      uint16 `bitfield:"16"`
+			Destination uint16 `bitfield:"16"`
+		}
+
+		Flow struct {
+			SourceAddress      netip.Addr `word:"128"`
+			DestinationAddress netip.Addr `word:"128"`
+			Ports              `word:"32"`
+		}
+	)
+
+	var (
+		compile    string
+		descriptor binary.FormatDescriptor
+		directory  string
+		e          error
+		output     []byte
+		source     []byte
+	)
+
+	descriptor, e = binary.Describe(
+		new(Flow),
+	)
+
+	assert.Nil(t, e)
+
+	source, e = Generate("test", descriptor)
+
+	assert.Nil(t, e)
+
+	assert.Contains(t, string(source),
+		"#define FLOW_SOURCE_ADDRESS_OFFSET 0\n"+
+			"#define FLOW_SOURCE_ADDRESS_LENGTH 16\n",
+	)
+
+	assert.NotContains(t, string(source),
+		"flow_source_address_load",
+	)
+
+	compile, e = exec.LookPath("cc")
+	if e != nil {
+		t.Skip("no C compiler")
+	}
+
+	directory = t.TempDir()
+
+	assert.Nil(t,
+		os.WriteFile(
+			filepath.Join(directory, "flow.h"), source, 0o644,
+		),
+	)
+
+	assert.Nil(t,
+		os.WriteFile(
+			filepath.Join(directory, "flow.c"), []byte(program), 0o644,
+		),
+	)
+
+	output, e = exec.Command(compile, "-fsyntax-only", "-Wall", "-Werror",
+		filepath.Join(directory, "flow.c"),
+	).CombinedOutput()
+
+	assert.Nil(t, e, string(output))
+}
@@ -0,0 +1,351 @@
+package diagrams
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/encodingx/binary"
+)
+
+var (
+	borderPattern        = regexp.MustCompile(`^[^|+]*\+(-\+)+\s*$`)
+	partialBorderPattern = regexp.MustCompile(`^[^|+]*\+([^|+]*)\+\s*$`)
+	rowPattern           = regexp.MustCompile(`^[^|+]*\|.*\|\s*$`)
+)
+
+const (
+	wordLengthUpperLimit = 64
+	ipv6Length           = 128
+)
+
+type cell struct {
+	label  string
+	length uint
+}
+
+func Parse(name, diagram string, typeHints map[string]string) (
+	format binary.FormatDefinition, e error,
+) {
+	// Parse an RFC-style header diagram, where each tick mark represents
+	// one bit position and rows between borders are words.
+	//
+	// Labels may end with a type hint (e.g. "Delay:bool");
+	// hints may also be given separately, keyed by bit field name.
+	//
+	// Borders open between their ends (e.g. "+   Source Address   +")
+	// continue a bit field spanning the row above into the row below,
+	// as do addresses in RFC 8200, joined in one bit field.
+
+	var (
+		block      []cell
+		blockLines int
+		cells      []cell
+		continued  bool
+		i          int
+		line       string
+		lineNumber int
+		match      []string
+		rowLength  uint
+		word       binary.WordDefinition
+	)
+
+	format = binary.FormatDefinition{
+		Name: name,
+	}
+
+	for lineNumber, line = range strings.Split(diagram, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+
+		switch {
+		case continued && !rowPattern.MatchString(line):
+			e = fmt.Errorf("line %d: partial border not followed by a row",
+				lineNumber+1,
+			)
+
+			return
+
+		case borderPattern.MatchString(line):
+			if blockLines > 0 {
+				word, e = newWord(
+					fmt.Sprintf("%sWord%d", name, len(format.Words)),
+					block, typeHints,
+				)
+				if e != nil {
+					return
+				}
+
+				format.Words = append(format.Words, word)
+			}
+
+			block = nil
+			blockLines = 0
+
+		case partialBorderPattern.MatchString(line):
+			match = partialBorderPattern.FindStringSubmatch(line)
+
+			if len(block) != 1 || uint(len(match[1])+1) != rowLength*2 {
+				e = fmt.Errorf("line %d: partial border "+
+					"not continuing a bit field spanning the row above",
+					lineNumber+1,
+				)
+
+				return
+			}
+
+			block[0].label = strings.TrimSpace(
+				block[0].label + " " + match[1],
+			)
+
+			continued = true
+
+		case rowPattern.MatchString(line):
+			cells, e = parseRow(line)
+			if e != nil {
+				e = fmt.Errorf("line %d: %w", lineNumber+1, e)
+
+				return
+			}
+
+			switch {
+			case blockLines == 0:
+				block = cells
+
+				rowLength = 0
+
+				for i = range cells {
+					rowLength += cells[i].length
+				}
+
+			case len(block) == 1 && (continued || block[0].length > rowLength):
+				// Rows below a partial border add to the bit field above.
+
+				if len(cells) != 1 || cells[0].length != rowLength {
+					e = fmt.Errorf(
+						"line %d: bit field does not line up with the row above",
+						lineNumber+1,
+					)
+
+					return
+				}
+
+				if continued {
+					block[0].length += rowLength
+				}
+
+				block[0].label = strings.TrimSpace(
+					block[0].label + " " + cells[0].label,
+				)
+
+				continued = false
+
+			case !sameColumns(block, cells):
+				e = fmt.Errorf(
+					"line %d: bit fields do not line up with the line above",
+					lineNumber+1,
+				)
+
+				return
+
+			default:
+				for i = range cells {
+					block[i].label = strings.TrimSpace(
+						block[i].label + " " + cells[i].label,
+					)
+				}
+			}
+
+			blockLines++
+		}
+	}
+
+	if blockLines > 0 {
+		e = fmt.Errorf("diagram does not end with a border")
+
+		return
+	}
+
+	if len(format.Words) == 0 {
+		e = fmt.Errorf("diagram has no rows of bit fields")
+
+		return
+	}
+
+	e = format.Validate()
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func parseRow(line string) (cells []cell, e error) {
+	var (
+		start int
+		width int
+		i     int
+	)
+
+	start = strings.IndexByte(line, '|')
+
+	for i = start + 1; i < len(line); i++ {
+		if line[i] != '|' {
+			continue
+		}
+
+		width = i - start
+
+		if width%2 != 0 {
+			e = fmt.Errorf("bit field %q does not line up with tick marks",
+				strings.TrimSpace(line[start+1:i]),
+			)
+
+			return
+		}
+
+		cells = append(cells,
+			cell{
+				label:  strings.TrimSpace(line[start+1 : i]),
+				length: uint(width / 2),
+			},
+		)
+
+		start = i
+	}
+
+	return
+}
+
+func sameColumns(a, b []cell) bool {
+	var (
+		i int
+	)
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i = range a {
+		if a[i].length != b[i].length {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newWord(name string, cells []cell, typeHints map[string]string) (
+	word binary.WordDefinition, e error,
+) {
+	var (
+		bitField binary.BitFieldDefinition
+		c        cell
+		hint     string
+		i        int
+		names    = make(map[string]int)
+		ok       bool
+	)
+
+	word = binary.WordDefinition{
+		Name: name,
+	}
+
+	for _, c = range cells {
+		bitField = binary.BitFieldDefinition{
+			Length: c.length,
+		}
+
+		i = strings.LastIndexByte(c.label, ':')
+		if i >= 0 {
+			bitField.Name = identifier(c.label[:i])
+			bitField.Type = strings.TrimSpace(c.label[i+1:])
+
+		} else {
+			bitField.Name = identifier(c.label)
+		}
+
+		names[bitField.Name]++
+
+		if names[bitField.Name] > 1 {
+			bitField.Name = fmt.Sprintf("%s%d",
+				bitField.Name, names[bitField.Name]-1,
+			)
+		}
+
+		hint, ok = typeHints[bitField.Name]
+		if ok {
+			bitField.Type = hint
+		}
+
+		// Bit fields of 128 bits are taken for IPv6 addresses
+		// if so named (e.g. "Source Address"), or else bytes,
+		// and those longer than 64 bits otherwise for strings.
+
+		switch {
+		case bitField.Type == "" && bitField.Length == ipv6Length &&
+			(strings.HasSuffix(bitField.Name, "Address") ||
+				strings.HasSuffix(bitField.Name, "Addr")):
+			bitField.Type = "netip.Addr"
+
+		case bitField.Type == "" && bitField.Length == ipv6Length:
+			bitField.Type = fmt.Sprintf("[%d]byte", ipv6Length/8)
+
+		case bitField.Type == "" && bitField.Length > wordLengthUpperLimit:
+			bitField.Type = "string"
+
+		case bitField.Type == "":
+			bitField.Type = binary.DefaultBitFieldType(bitField.Length)
+		}
+
+		word.Length += bitField.Length
+
+		word.BitFields = append(word.BitFields, bitField)
+	}
+
+	// Bit fields longer than words may otherwise be are words of their own,
+	// named after them.
+
+	if len(word.BitFields) == 1 && word.Length > wordLengthUpperLimit {
+		word.Name = word.BitFields[0].Name
+	}
+
+	return
+}
+
+func identifier(label string) (name string) {
+	// Convert a label such as "Type of Service" into "TypeOfService".
+	// Labels without letters, such as the zeros marking reserved bits,
+	// become "Reserved".
+
+	const (
+		reserved = "Reserved"
+	)
+
+	var (
+		part  string
+		runes []rune
+	)
+
+	for _, part = range strings.FieldsFunc(label,
+		func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		},
+	) {
+		runes = []rune(part)
+
+		runes[0] = unicode.ToUpper(runes[0])
+
+		name += string(runes)
+	}
+
+	switch {
+	case strings.IndexFunc(name, unicode.IsLetter) < 0:
+		name = reserved
+
+	case !unicode.IsLetter([]rune(name)[0]):
+		name = "Field" + name
+	}
+
+	return
+}
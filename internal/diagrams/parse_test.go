@@ -0,0 +1,272 @@
+package diagrams
+
+import (
+	"testing"
+
+	"github.com/encodingx/binary"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// Section 3.1 of RFC 791 Internet Protocol
+
+	rfc791Diagram = `
+    0                   1                   2                   3
+    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |Version|  IHL  |Type of Service|          Total Length         |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |         Identification        |Flags|      Fragment Offset    |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |  Time to Live |    Protocol   |         Header Checksum       |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |                       Source Address                          |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |                    Destination Address                        |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+`
+
+	// Section 3 of RFC 8200 Internet Protocol, Version 6 (IPv6)
+
+	rfc8200Diagram = `
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |Version| Traffic Class |           Flow Label                  |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |         Payload Length        |  Next Header  |   Hop Limit   |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |                                                               |
+   +                                                               +
+   |                                                               |
+   +                         Source Address                        +
+   |                                                               |
+   +                                                               +
+   |                                                               |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |                                                               |
+   +                                                               +
+   |                                                               |
+   +                      Destination Address                      +
+   |                                                               |
+   +                                                               +
+   |                                                               |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+`
+)
+
+func TestParse(t *testing.T) {
+	var (
+		e      error
+		format binary.FormatDefinition
+	)
+
+	format, e = Parse("Header", rfc791Diagram,
+		map[string]string{
+			"TotalLength": "uint16",
+		},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		5, len(format.Words),
+	)
+
+	assert.Equal(t,
+		binary.WordDefinition{
+			Name:   "HeaderWord0",
+			Length: 32,
+			BitFields: []binary.BitFieldDefinition{
+				{Name: "Version", Length: 4, Type: "uint8"},
+				{Name: "IHL", Length: 4, Type: "uint8"},
+				{Name: "TypeOfService", Length: 8, Type: "uint8"},
+				{Name: "TotalLength", Length: 16, Type: "uint16"},
+			},
+		},
+		format.Words[0],
+	)
+
+	assert.Equal(t,
+		binary.BitFieldDefinition{
+			Name: "SourceAddress", Length: 32, Type: "uint32",
+		},
+		format.Words[3].BitFields[0],
+	)
+}
+
+func TestParseMultilineLabelsAndTypeHints(t *testing.T) {
+	var (
+		e      error
+		format binary.FormatDefinition
+	)
+
+	format, e = Parse("Header",
+		"+-+-+-+-+-+-+-+-+\n"+
+			"// > | Data  |0|D|M|0|\n"+
+			"// > |Offset | | | | |\n"+
+			"+-+-+-+-+-+-+-+-+\n",
+		map[string]string{
+			"D": "uint8",
+		},
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]binary.BitFieldDefinition{
+			{Name: "DataOffset", Length: 4, Type: "uint8"},
+			{Name: "Reserved", Length: 1, Type: "bool"},
+			{Name: "D", Length: 1, Type: "uint8"},
+			{Name: "M", Length: 1, Type: "bool"},
+			{Name: "Reserved1", Length: 1, Type: "bool"},
+		},
+		format.Words[0].BitFields,
+	)
+}
+
+func TestParseBitFieldsSpanningRows(t *testing.T) {
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+		format     binary.FormatDefinition
+	)
+
+	format, e = Parse("IPv6Header", rfc8200Diagram, nil)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]binary.WordDefinition{
+			{
+				Name:   "IPv6HeaderWord0",
+				Length: 32,
+				BitFields: []binary.BitFieldDefinition{
+					{Name: "Version", Length: 4, Type: "uint8"},
+					{Name: "TrafficClass", Length: 8, Type: "uint8"},
+					{Name: "FlowLabel", Length: 20, Type: "uint32"},
+				},
+			},
+			{
+				Name:   "IPv6HeaderWord1",
+				Length: 32,
+				BitFields: []binary.BitFieldDefinition{
+					{Name: "PayloadLength", Length: 16, Type: "uint16"},
+					{Name: "NextHeader", Length: 8, Type: "uint8"},
+					{Name: "HopLimit", Length: 8, Type: "uint8"},
+				},
+			},
+			{
+				Name:   "SourceAddress",
+				Length: 128,
+				BitFields: []binary.BitFieldDefinition{
+					{Name: "SourceAddress", Length: 128, Type: "netip.Addr"},
+				},
+			},
+			{
+				Name:   "DestinationAddress",
+				Length: 128,
+				BitFields: []binary.BitFieldDefinition{
+					{Name: "DestinationAddress", Length: 128, Type: "netip.Addr"},
+				},
+			},
+		},
+		format.Words,
+	)
+
+	descriptor, e = format.Describe()
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		40, descriptor.LengthInBytes(),
+	)
+}
+
+func TestParseShouldReturnErrorGivenPartialBorderNotContinuingRow(
+	t *testing.T,
+) {
+	var (
+		e error
+	)
+
+	_, e = Parse("Header",
+		"+-+-+-+-+-+-+-+-+\n"+
+			"|   A   |   B   |\n"+
+			"+               +\n"+
+			"|               |\n"+
+			"+-+-+-+-+-+-+-+-+\n",
+		nil,
+	)
+
+	assert.EqualError(t,
+		e, "line 3: partial border "+
+			"not continuing a bit field spanning the row above",
+	)
+
+	_, e = Parse("Header",
+		"+-+-+-+-+-+-+-+-+\n"+
+			"|       A       |\n"+
+			"+               +\n"+
+			"|   B   |   C   |\n"+
+			"+-+-+-+-+-+-+-+-+\n",
+		nil,
+	)
+
+	assert.EqualError(t,
+		e, "line 4: bit field does not line up with the row above",
+	)
+
+	_, e = Parse("Header",
+		"+-+-+-+-+-+-+-+-+\n"+
+			"|       A       |\n"+
+			"+               +\n"+
+			"+-+-+-+-+-+-+-+-+\n",
+		nil,
+	)
+
+	assert.EqualError(t,
+		e, "line 4: partial border not followed by a row",
+	)
+}
+
+func TestParseShouldReturnErrorGivenMisalignedBitField(t *testing.T) {
+	var (
+		e error
+	)
+
+	_, e = Parse("Header",
+		"+-+-+-+-+-+-+-+-+\n"+
+			"| Data |  Flags |\n"+
+			"+-+-+-+-+-+-+-+-+\n",
+		nil,
+	)
+
+	assert.EqualError(t,
+		e, "line 2: bit field \"Data\" does not line up with tick marks",
+	)
+}
+
+func TestParseShouldReturnErrorGivenWordOfIncompatibleLength(t *testing.T) {
+	const (
+		errorMessage = "Validate error: " +
+			"The length of a word should be a multiple of eight " +
+			"in the range [8, 64]. " +
+			"Argument to Validate points to a format-struct \"Header\" " +
+			"that has a word \"HeaderWord0\" " +
+			"of length 4 not in {8, 16, 24, ... 64}."
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Parse("Header",
+		"+-+-+-+-+\n"+
+			"| Data  |\n"+
+			"+-+-+-+-+\n",
+		nil,
+	)
+
+	assert.EqualError(t,
+		e, errorMessage,
+	)
+}
@@ -0,0 +1,189 @@
+package gosource
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/encodingx/binary"
+)
+
+func Generate(
+	generator, packageName string, formats ...binary.FormatDefinition,
+) (
+	source []byte, e error,
+) {
+	// Emit format-structs and word-structs
+	// with "word" and "bitfield" tags equivalent to the definitions,
+	// and constants for the enumerated values of bit fields.
+
+	const (
+		header = "" +
+			"// Code generated by %s; DO NOT EDIT.\n" +
+			"\n" +
+			"package %s\n"
+	)
+
+	var (
+		bitField   binary.BitFieldDefinition
+		buffer     bytes.Buffer
+		descriptor binary.FormatDescriptor
+		f          binary.FormatDefinition
+		word       binary.WordDefinition
+	)
+
+	fmt.Fprintf(&buffer, header, generator, packageName)
+
+	generateImports(&buffer, formats)
+
+	for _, f = range formats {
+		descriptor, e = f.Describe()
+		if e != nil {
+			return
+		}
+
+		fmt.Fprintf(&buffer, "\ntype %s struct {\n", f.Name)
+
+		for _, word = range f.Words {
+			if word.LongField() {
+				fmt.Fprintf(&buffer, "\t%s %s `%s`\n",
+					word.Name,
+					word.BitFields[0].Type,
+					word.Tag(),
+				)
+
+				continue
+			}
+
+			fmt.Fprintf(&buffer, "\t%s `%s`\n",
+				word.Name,
+				word.Tag(),
+			)
+		}
+
+		buffer.WriteString("}\n")
+
+		for _, word = range f.Words {
+			if word.LongField() {
+				continue
+			}
+
+			fmt.Fprintf(&buffer, "\ntype %s struct {\n", word.Name)
+
+			for _, bitField = range word.BitFields {
+				fmt.Fprintf(&buffer, "\t%s %s `%s`\n",
+					bitField.Name,
+					bitField.Type,
+					bitField.Tag(),
+				)
+			}
+
+			buffer.WriteString("}\n")
+		}
+
+		generateConstants(&buffer, descriptor)
+	}
+
+	source, e = format.Source(
+		buffer.Bytes(),
+	)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func generateImports(buffer *bytes.Buffer,
+	formats []binary.FormatDefinition,
+) {
+	// Import the packages of qualified types of bit fields
+	// (e.g. "net/netip" for netip.Addr).
+
+	var (
+		bitField binary.BitFieldDefinition
+		f        binary.FormatDefinition
+		imported = make(map[string]bool)
+		path     string
+		paths    []string
+		word     binary.WordDefinition
+	)
+
+	for _, f = range formats {
+		for _, word = range f.Words {
+			for _, bitField = range word.BitFields {
+				switch {
+				case strings.HasPrefix(bitField.Type, "netip."):
+					path = "net/netip"
+
+				case strings.HasPrefix(bitField.Type, "net."):
+					path = "net"
+
+				default:
+					continue
+				}
+
+				if !imported[path] {
+					paths = append(paths, path)
+				}
+
+				imported[path] = true
+			}
+		}
+	}
+
+	if len(paths) == 0 {
+		return
+	}
+
+	sort.Strings(paths)
+
+	buffer.WriteString("\nimport (\n")
+
+	for _, path = range paths {
+		fmt.Fprintf(buffer, "\t%q\n", path)
+	}
+
+	buffer.WriteString(")\n")
+
+	return
+}
+
+func generateConstants(buffer *bytes.Buffer,
+	descriptor binary.FormatDescriptor,
+) {
+	// Name constants after their formats and bit fields
+	// (e.g. IPv4HeaderProtocolTCP), which may repeat enumerated names.
+
+	var (
+		bitField   binary.BitFieldDescriptor
+		enumerated binary.EnumeratedValue
+		started    bool
+		word       binary.WordDescriptor
+	)
+
+	for _, word = range descriptor.Words() {
+		for _, bitField = range word.BitFields() {
+			for _, enumerated = range bitField.Enumeration() {
+				if !started {
+					buffer.WriteString("\nconst (\n")
+
+					started = true
+				}
+
+				fmt.Fprintf(buffer, "\t%s%s%s = %d\n",
+					descriptor.Name(), bitField.Name(), enumerated.Name,
+					enumerated.Value,
+				)
+			}
+		}
+	}
+
+	if started {
+		buffer.WriteString(")\n")
+	}
+
+	return
+}
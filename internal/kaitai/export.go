@@ -0,0 +1,217 @@
+package kaitai
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/encodingx/binary"
+)
+
+func Export(format binary.FormatDescriptor) (ksyBytes []byte, e error) {
+	// Write a format as a sequence of bit-sized integers,
+	// in the bit order of each word.
+
+	var (
+		attribute  ksyAttribute
+		attributes []ksyAttribute
+		bitField   binary.BitFieldDescriptor
+		buffer     bytes.Buffer
+		encoder    *yaml.Encoder
+		enumerated binary.EnumeratedValue
+		i          int
+		name       string
+		reversed   bool
+		suffix     string
+		word       binary.WordDescriptor
+		k          ksy
+	)
+
+	name = format.Name()[strings.LastIndex(format.Name(), ".")+1:]
+
+	k = ksy{
+		Meta: ksyMeta{
+			ID:        snakeCase(name),
+			Title:     name,
+			BitEndian: "be",
+		},
+		Enums: make(map[string]map[uint64]ksyEnumValue),
+	}
+
+	for _, word = range format.Words() {
+		if word.Varint() != "" {
+			e = fmt.Errorf("word %s holds a variable-length integer, "+
+				"not representable as bit-sized integers",
+				word.Name(),
+			)
+
+			return
+		}
+
+		if word.BitStream() {
+			e = fmt.Errorf("word %s is a bit stream, "+
+				"not representable as bit-sized integers",
+				word.Name(),
+			)
+
+			return
+		}
+
+		if word.LengthInBits() > 64 {
+			e = fmt.Errorf("word %s is longer than 64 bits, "+
+				"not representable as bit-sized integers",
+				word.Name(),
+			)
+
+			return
+		}
+
+		// Little-endian words are read from their least significant bits,
+		// as are words allocating bit fields LSB-first,
+		// so that either reverses the declared order of bit fields.
+
+		suffix = ""
+
+		if word.ByteOrder() == binary.LittleEndian {
+			suffix = "le"
+		}
+
+		reversed = word.LSBFirst() != (suffix == "le")
+
+		attributes = nil
+
+		for _, bitField = range word.BitFields() {
+			attribute = ksyAttribute{
+				ID:     snakeCase(bitField.Name()),
+				OrigID: origID(bitField.Name()),
+				Type: fmt.Sprintf("b%d%s",
+					bitField.LengthInBits(), suffix,
+				),
+				Valid: exportValid(bitField),
+			}
+
+			if len(bitField.Enumeration()) > 0 {
+				attribute.Enum = attribute.ID
+
+				k.Enums[attribute.Enum] = make(map[uint64]ksyEnumValue)
+
+				for _, enumerated = range bitField.Enumeration() {
+					k.Enums[attribute.Enum][enumerated.Value] = ksyEnumValue{
+						ID:     snakeCase(enumerated.Name),
+						OrigID: origID(enumerated.Name),
+					}
+				}
+			}
+
+			attribute.GoType = exportGoType(bitField)
+
+			attributes = append(attributes, attribute)
+		}
+
+		if reversed {
+			for i = 0; i < len(attributes)/2; i++ {
+				attributes[i], attributes[len(attributes)-1-i] =
+					attributes[len(attributes)-1-i], attributes[i]
+			}
+		}
+
+		attributes[0].Word = word.Name()
+
+		k.Seq = append(k.Seq, attributes...)
+	}
+
+	encoder = yaml.NewEncoder(&buffer)
+
+	encoder.SetIndent(2)
+
+	e = encoder.Encode(k)
+	if e != nil {
+		return
+	}
+
+	ksyBytes = buffer.Bytes()
+
+	return
+}
+
+func exportValid(bitField binary.BitFieldDescriptor) (valid *ksyValid) {
+	// Reserved bits must be zero (false for one-bit fields, read as booleans),
+	// and other constraints become ranges of valid values.
+
+	const (
+		maximumPrefix  = "max="
+		minimumPrefix  = "min="
+		reservedOption = "reserved"
+	)
+
+	var (
+		e       error
+		maximum uint64
+		minimum uint64
+		option  string
+	)
+
+	for _, option = range bitField.Options() {
+		switch {
+		case option == reservedOption:
+			valid = &ksyValid{
+				Eq:      new(uint64),
+				boolean: bitField.LengthInBits() == 1,
+			}
+
+			return
+
+		case strings.HasPrefix(option, minimumPrefix):
+			minimum, e = strconv.ParseUint(
+				strings.TrimPrefix(option, minimumPrefix), 0, 64,
+			)
+			if e != nil {
+				continue
+			}
+
+			if valid == nil {
+				valid = &ksyValid{}
+			}
+
+			valid.Min = &minimum
+
+		case strings.HasPrefix(option, maximumPrefix):
+			maximum, e = strconv.ParseUint(
+				strings.TrimPrefix(option, maximumPrefix), 0, 64,
+			)
+			if e != nil {
+				continue
+			}
+
+			if valid == nil {
+				valid = &ksyValid{}
+			}
+
+			valid.Max = &maximum
+		}
+	}
+
+	return
+}
+
+func exportGoType(bitField binary.BitFieldDescriptor) string {
+	// Named types (e.g. type Protocol uint8) are exported as their kinds,
+	// and types of words converting themselves, not at all.
+
+	var (
+		kind = bitField.Type().Kind().String()
+	)
+
+	switch kind {
+	case "bool", "uint", "uint8", "uint16", "uint32", "uint64",
+		"int", "int8", "int16", "int32", "int64":
+		if kind != binary.DefaultBitFieldType(bitField.LengthInBits()) {
+			return kind
+		}
+	}
+
+	return ""
+}
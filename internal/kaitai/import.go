@@ -0,0 +1,422 @@
+package kaitai
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/encodingx/binary"
+)
+
+var (
+	bitTypePattern     = regexp.MustCompile(`^b([0-9]+)(be|le)?$`)
+	integerTypePattern = regexp.MustCompile(`^u([1248])(be|le)?$`)
+
+	supportedKeys = map[string]bool{
+		"meta": true, "doc": true, "doc-ref": true, "seq": true, "enums": true,
+	}
+
+	supportedAttributeKeys = map[string]bool{
+		"id": true, "type": true, "enum": true, "valid": true,
+		"doc": true, "doc-ref": true,
+	}
+)
+
+type group struct {
+	name      string
+	lengths   uint
+	bitEndian string
+	byteOrder string
+	integer   bool
+	bitFields []binary.BitFieldDefinition
+}
+
+func Import(ksyBytes []byte) (format binary.FormatDefinition, e error) {
+	// Read the fixed-layout subset of a .ksy file:
+	// bit-sized integers and unsigned integers of whole bytes,
+	// with enumerations and ranges of valid values.
+	// Every other construct is reported.
+
+	var (
+		attribute   ksyAttribute
+		current     group
+		groups      []group
+		hasWords    bool
+		k           ksy
+		key         string
+		keys        map[string]yaml.Node
+		node        yaml.Node
+		nodes       []yaml.Node
+		unsupported []string
+	)
+
+	e = yaml.Unmarshal(ksyBytes, &keys)
+	if e != nil {
+		return
+	}
+
+	for key = range keys {
+		if !supportedKeys[key] && !strings.HasPrefix(key, "-") {
+			unsupported = append(unsupported,
+				fmt.Sprintf("top-level key %q", key),
+			)
+		}
+	}
+
+	node = keys["meta"]
+
+	e = node.Decode(&k.Meta)
+	if e != nil {
+		return
+	}
+
+	node = keys["enums"]
+
+	if node.Kind != 0 {
+		e = node.Decode(&k.Enums)
+		if e != nil {
+			return
+		}
+	}
+
+	node = keys["seq"]
+
+	e = node.Decode(&nodes)
+	if e != nil {
+		return
+	}
+
+	for _, node = range nodes {
+		keys = nil
+
+		e = node.Decode(&keys)
+		if e != nil {
+			return
+		}
+
+		attribute = ksyAttribute{}
+
+		e = node.Decode(&attribute)
+		if e != nil {
+			return
+		}
+
+		for key = range keys {
+			if !supportedAttributeKeys[key] && !strings.HasPrefix(key, "-") {
+				unsupported = append(unsupported,
+					fmt.Sprintf("key %q of attribute %q", key, attribute.ID),
+				)
+			}
+		}
+
+		hasWords = hasWords || attribute.Word != ""
+
+		k.Seq = append(k.Seq, attribute)
+	}
+
+	format.Name = k.Meta.Title
+
+	if !token.IsIdentifier(format.Name) || !token.IsExported(format.Name) {
+		format.Name = mixedCaps(k.Meta.ID)
+	}
+
+	for _, attribute = range k.Seq {
+		current, groups, unsupported = k.importAttribute(attribute,
+			current, groups, hasWords, unsupported,
+		)
+	}
+
+	if current.lengths%8 != 0 {
+		unsupported = append(unsupported,
+			"bit-sized integers not ending at a byte boundary",
+		)
+	}
+
+	if len(current.bitFields) > 0 {
+		groups = append(groups, current)
+	}
+
+	sort.Strings(unsupported)
+
+	if len(unsupported) > 0 {
+		e = fmt.Errorf("constructs not representable as format-structs: %s",
+			strings.Join(unsupported, "; "),
+		)
+
+		return
+	}
+
+	for _, current = range groups {
+		format.Words = append(format.Words,
+			current.word(format.Name,
+				len(format.Words),
+			),
+		)
+	}
+
+	return
+}
+
+func (k ksy) importAttribute(attribute ksyAttribute, current group,
+	groups []group, hasWords bool, unsupported []string,
+) (
+	group, []group, []string,
+) {
+	// Add the attribute to the current word,
+	// or end the word at a byte boundary and begin another.
+
+	var (
+		bitEndian string
+		bitField  binary.BitFieldDefinition
+		byteOrder string
+		e         error
+		length    uint64
+		matches   []string
+		next      bool
+		options   []string
+	)
+
+	bitField.Name = attribute.OrigID
+
+	if bitField.Name == "" {
+		bitField.Name = mixedCaps(attribute.ID)
+	}
+
+	options, e = k.importOptions(attribute)
+	if e != nil {
+		unsupported = append(unsupported,
+			fmt.Sprintf("attribute %q: %v", attribute.ID, e),
+		)
+	}
+
+	switch {
+	case bitTypePattern.MatchString(attribute.Type):
+		matches = bitTypePattern.FindStringSubmatch(attribute.Type)
+
+		length, _ = strconv.ParseUint(matches[1], 10, 64)
+
+		bitEndian = matches[2]
+
+		if bitEndian == "" {
+			bitEndian = k.Meta.BitEndian
+		}
+
+		if bitEndian == "" {
+			bitEndian = "be"
+		}
+
+		next = current.integer || current.lengths%8 == 0 &&
+			(attribute.Word != "" || !hasWords ||
+				bitEndian != current.bitEndian ||
+				current.lengths+uint(length) > 64)
+
+		if current.lengths%8 != 0 && bitEndian != current.bitEndian {
+			unsupported = append(unsupported,
+				fmt.Sprintf("attribute %q: bit order changing "+
+					"between byte boundaries", attribute.ID,
+				),
+			)
+		}
+
+		if next && len(current.bitFields) > 0 {
+			groups = append(groups, current)
+
+			current = group{}
+		}
+
+		current.bitEndian = bitEndian
+
+	case integerTypePattern.MatchString(attribute.Type):
+		matches = integerTypePattern.FindStringSubmatch(attribute.Type)
+
+		length, _ = strconv.ParseUint(matches[1], 10, 64)
+
+		length *= 8
+
+		byteOrder = matches[2]
+
+		if byteOrder == "" {
+			byteOrder = k.Meta.Endian
+		}
+
+		if byteOrder == "" && length > 8 {
+			unsupported = append(unsupported,
+				fmt.Sprintf("attribute %q: integer of no byte order",
+					attribute.ID,
+				),
+			)
+		}
+
+		if current.lengths%8 != 0 {
+			unsupported = append(unsupported,
+				fmt.Sprintf("attribute %q: integer between byte boundaries",
+					attribute.ID,
+				),
+			)
+		}
+
+		if len(current.bitFields) > 0 {
+			groups = append(groups, current)
+		}
+
+		current = group{
+			integer:   true,
+			byteOrder: byteOrder,
+		}
+
+	default:
+		unsupported = append(unsupported,
+			fmt.Sprintf("attribute %q: type %q", attribute.ID, attribute.Type),
+		)
+
+		return current, groups, unsupported
+	}
+
+	if current.name == "" {
+		current.name = attribute.Word
+	}
+
+	bitField.Length = uint(length)
+
+	bitField.Type = attribute.GoType
+
+	if bitField.Type == "" {
+		bitField.Type = binary.DefaultBitFieldType(bitField.Length)
+	}
+
+	bitField.Options = options
+
+	current.lengths += bitField.Length
+
+	current.bitFields = append(current.bitFields, bitField)
+
+	if current.lengths > 64 {
+		unsupported = append(unsupported,
+			fmt.Sprintf("attribute %q: more than 64 bits "+
+				"between byte boundaries", attribute.ID,
+			),
+		)
+	}
+
+	return current, groups, unsupported
+}
+
+func (k ksy) importOptions(attribute ksyAttribute) (
+	options []string, e error,
+) {
+	// Translate enumerations and ranges of valid values
+	// into options of struct tags.
+
+	var (
+		elements    []string
+		enumeration map[uint64]ksyEnumValue
+		name        string
+		ok          bool
+		value       uint64
+		values      []uint64
+	)
+
+	if attribute.Valid != nil {
+		if len(attribute.Valid.unsupported) > 0 {
+			e = fmt.Errorf("valid %s",
+				strings.Join(attribute.Valid.unsupported, ", "),
+			)
+
+			return
+		}
+
+		switch {
+		case attribute.Valid.Eq != nil && *attribute.Valid.Eq == 0:
+			options = append(options, "reserved")
+
+		case attribute.Valid.Eq != nil:
+			options = append(options,
+				fmt.Sprintf("min=%d", *attribute.Valid.Eq),
+				fmt.Sprintf("max=%d", *attribute.Valid.Eq),
+			)
+		}
+
+		if attribute.Valid.Min != nil {
+			options = append(options,
+				fmt.Sprintf("min=%d", *attribute.Valid.Min),
+			)
+		}
+
+		if attribute.Valid.Max != nil {
+			options = append(options,
+				fmt.Sprintf("max=%d", *attribute.Valid.Max),
+			)
+		}
+	}
+
+	if attribute.Enum == "" {
+		return
+	}
+
+	enumeration, ok = k.Enums[attribute.Enum]
+	if !ok {
+		e = fmt.Errorf("undefined enum %q", attribute.Enum)
+
+		return
+	}
+
+	for value = range enumeration {
+		values = append(values, value)
+	}
+
+	sort.Slice(values,
+		func(i, j int) bool {
+			return values[i] < values[j]
+		},
+	)
+
+	for _, value = range values {
+		name = enumeration[value].OrigID
+
+		if name == "" {
+			name = mixedCaps(enumeration[value].ID)
+		}
+
+		elements = append(elements,
+			fmt.Sprintf("%s:%d", name, value),
+		)
+	}
+
+	options = append(options,
+		"enum="+strings.Join(elements, "|"),
+	)
+
+	return
+}
+
+func (g group) word(formatName string, i int) (
+	word binary.WordDefinition,
+) {
+	// Bits read least significant first, from little-endian bytes,
+	// are those of a little-endian word allocated LSB-first.
+
+	word = binary.WordDefinition{
+		Name:      g.name,
+		Length:    g.lengths,
+		BitFields: g.bitFields,
+	}
+
+	if word.Name == "" {
+		word.Name = fmt.Sprintf("%sWord%d", formatName, i)
+	}
+
+	switch {
+	case g.integer && g.byteOrder == "le" && g.lengths > 8:
+		word.Options = []string{"littleendian"}
+
+	case !g.integer && g.bitEndian == "le":
+		word.Options = []string{"littleendian", "lsbfirst"}
+	}
+
+	return
+}
@@ -0,0 +1,239 @@
+package kaitai
+
+import (
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kaitai Struct files (.ksy) describe formats as sequences of attributes,
+// read one after another, with no notion of words.
+// Bit-sized integers (e.g. "b4" or "b12le") are read
+// from the most significant bit of each byte in big-endian bit order,
+// and from the least significant bit in little-endian bit order,
+// so that a word is a run of bit-sized integers ending at a byte boundary.
+//
+// Names of words, names in mixed caps that snake case would lose,
+// and Go types other than the smallest that fit
+// are kept in keys starting with "-", which Kaitai Struct ignores.
+
+type ksy struct {
+	Meta  ksyMeta                            `yaml:"meta"`
+	Doc   string                             `yaml:"doc,omitempty"`
+	Seq   []ksyAttribute                     `yaml:"seq"`
+	Enums map[string]map[uint64]ksyEnumValue `yaml:"enums,omitempty"`
+}
+
+type ksyMeta struct {
+	ID        string `yaml:"id"`
+	Title     string `yaml:"title,omitempty"`
+	Endian    string `yaml:"endian,omitempty"`
+	BitEndian string `yaml:"bit-endian,omitempty"`
+}
+
+type ksyAttribute struct {
+	ID     string    `yaml:"id"`
+	OrigID string    `yaml:"-orig-id,omitempty"`
+	Word   string    `yaml:"-word,omitempty"`
+	Type   string    `yaml:"type"`
+	GoType string    `yaml:"-type,omitempty"`
+	Enum   string    `yaml:"enum,omitempty"`
+	Valid  *ksyValid `yaml:"valid,omitempty"`
+	Doc    string    `yaml:"doc,omitempty"`
+}
+
+type ksyValid struct {
+	Eq          *uint64 `yaml:"eq,omitempty"`
+	Min         *uint64 `yaml:"min,omitempty"`
+	Max         *uint64 `yaml:"max,omitempty"`
+	boolean     bool
+	unsupported []string
+}
+
+type ksyEnumValue struct {
+	ID     string `yaml:"id"`
+	OrigID string `yaml:"-orig-id,omitempty"`
+}
+
+func (v ksyEnumValue) MarshalYAML() (value interface{}, e error) {
+	// Values are written as bare identifiers unless they need -orig-id.
+
+	type plain ksyEnumValue
+
+	if v.OrigID == "" {
+		value = v.ID
+
+		return
+	}
+
+	value = plain(v)
+
+	return
+}
+
+func (v *ksyEnumValue) UnmarshalYAML(node *yaml.Node) (e error) {
+	type plain ksyEnumValue
+
+	if node.Kind == yaml.ScalarNode {
+		v.ID = node.Value
+
+		return
+	}
+
+	e = node.Decode((*plain)(v))
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (v ksyValid) MarshalYAML() (value interface{}, e error) {
+	// Single valid values are written as bare scalars,
+	// booleans for one-bit fields that Kaitai reads as such.
+
+	type plain ksyValid
+
+	switch {
+	case v.Eq != nil && v.boolean:
+		value = *v.Eq != 0
+
+		return
+
+	case v.Eq != nil:
+		value = *v.Eq
+
+		return
+	}
+
+	value = plain(v)
+
+	return
+}
+
+func (v *ksyValid) UnmarshalYAML(node *yaml.Node) (e error) {
+	// Remember keys other than "eq", "min" and "max" (e.g. "any-of"),
+	// so that they may be reported.
+
+	type plain ksyValid
+
+	var (
+		boolean bool
+		key     string
+		keys    map[string]yaml.Node
+	)
+
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!bool" {
+		e = node.Decode(&boolean)
+		if e != nil {
+			return
+		}
+
+		v.Eq = new(uint64)
+		v.boolean = true
+
+		if boolean {
+			*v.Eq = 1
+		}
+
+		return
+	}
+
+	if node.Kind == yaml.ScalarNode {
+		v.Eq = new(uint64)
+
+		e = node.Decode(v.Eq)
+		if e != nil {
+			return
+		}
+
+		return
+	}
+
+	e = node.Decode(&keys)
+	if e != nil {
+		return
+	}
+
+	for key = range keys {
+		switch key {
+		case "eq", "min", "max":
+
+		default:
+			v.unsupported = append(v.unsupported, key)
+		}
+	}
+
+	e = node.Decode((*plain)(v))
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func snakeCase(name string) string {
+	// Convert mixed caps to snake case,
+	// keeping initialisms and numbers together (e.g. "total_length").
+
+	var (
+		builder strings.Builder
+		i       int
+		r       rune
+		runes   = []rune(name)
+	)
+
+	for i, r = range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(!unicode.IsUpper(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			builder.WriteRune('_')
+		}
+
+		builder.WriteRune(
+			unicode.ToLower(r),
+		)
+	}
+
+	return builder.String()
+}
+
+func mixedCaps(id string) string {
+	// Convert snake case to mixed caps (e.g. "TotalLength").
+
+	var (
+		builder strings.Builder
+		part    string
+		runes   []rune
+	)
+
+	for _, part = range strings.Split(id, "_") {
+		if part == "" {
+			continue
+		}
+
+		runes = []rune(part)
+
+		runes[0] = unicode.ToUpper(runes[0])
+
+		builder.WriteString(
+			string(runes),
+		)
+	}
+
+	return builder.String()
+}
+
+func origID(name string) string {
+	// Names in mixed caps are kept only if snake case would lose them
+	// (e.g. "IHL", which would come back as "Ihl").
+
+	if mixedCaps(
+		snakeCase(name),
+	) == name {
+		return ""
+	}
+
+	return name
+}
@@ -0,0 +1,373 @@
+package kaitai
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/encodingx/binary"
+	"github.com/encodingx/binary/pkg/rfc791"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	telemetryDefinition = binary.FormatDefinition{
+		Name: "Telemetry",
+		Words: []binary.WordDefinition{
+			{
+				Name:    "TelemetryHeader",
+				Length:  16,
+				Options: []string{"littleendian"},
+				BitFields: []binary.BitFieldDefinition{
+					{Name: "Version", Length: 4, Type: "uint8",
+						Options: []string{"min=1", "max=9"},
+					},
+					{Name: "Urgent", Length: 1, Type: "bool"},
+					{Name: "Reserved", Length: 3, Type: "uint8",
+						Options: []string{"reserved"},
+					},
+					{Name: "Kind", Length: 8, Type: "uint16",
+						Options: []string{"enum=Reading:1|SOS:2"},
+					},
+				},
+			},
+			{
+				Name:   "TelemetrySequence",
+				Length: 32,
+				BitFields: []binary.BitFieldDefinition{
+					{Name: "Sequence", Length: 32, Type: "uint32"},
+				},
+			},
+		},
+	}
+)
+
+func TestExport(t *testing.T) {
+	const (
+		ksy = "" +
+			"meta:\n" +
+			"  id: telemetry\n" +
+			"  title: Telemetry\n" +
+			"  bit-endian: be\n" +
+			"seq:\n" +
+			"  - id: kind\n" +
+			"    -word: TelemetryHeader\n" +
+			"    type: b8le\n" +
+			"    -type: uint16\n" +
+			"    enum: kind\n" +
+			"  - id: reserved\n" +
+			"    type: b3le\n" +
+			"    valid: 0\n" +
+			"  - id: urgent\n" +
+			"    type: b1le\n" +
+			"  - id: version\n" +
+			"    type: b4le\n" +
+			"    valid:\n" +
+			"      min: 1\n" +
+			"      max: 9\n" +
+			"  - id: sequence\n" +
+			"    -word: TelemetrySequence\n" +
+			"    type: b32\n" +
+			"enums:\n" +
+			"  kind:\n" +
+			"    1: reading\n" +
+			"    2:\n" +
+			"      id: sos\n" +
+			"      -orig-id: SOS\n"
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+		ksyBytes   []byte
+	)
+
+	descriptor, e = telemetryDefinition.Describe()
+
+	assert.Nil(t, e)
+
+	ksyBytes, e = Export(descriptor)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		ksy, string(ksyBytes),
+	)
+}
+
+func TestExportShouldWriteReservedBooleansAsFalse(t *testing.T) {
+	// Kaitai reads one-bit fields as booleans,
+	// which cannot be compared to zero.
+
+	const (
+		flags = "" +
+			"  - id: flags_bit0_reserved\n" +
+			"    type: b1\n" +
+			"    valid: false\n"
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+		format     binary.FormatDefinition
+		ksyBytes   []byte
+	)
+
+	descriptor, e = binary.Describe(
+		new(rfc791.RFC791InternetHeaderFormatWithoutOptions),
+	)
+
+	assert.Nil(t, e)
+
+	ksyBytes, e = Export(descriptor)
+
+	assert.Nil(t, e)
+
+	assert.Contains(t,
+		string(ksyBytes), flags,
+	)
+
+	format, e = Import(ksyBytes)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		binary.BitFieldDefinition{
+			Name:    "FlagsBit0Reserved",
+			Length:  1,
+			Type:    "bool",
+			Options: []string{"reserved"},
+		},
+		format.Words[1].BitFields[1],
+	)
+}
+
+func TestExportShouldRefuseVarints(t *testing.T) {
+	type lengthPrefixed struct {
+		Length uint64 `word:"64,uleb128"`
+	}
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = binary.Describe(
+		new(lengthPrefixed),
+	)
+
+	assert.Nil(t, e)
+
+	_, e = Export(descriptor)
+
+	assert.EqualError(t, e,
+		"word Length holds a variable-length integer, "+
+			"not representable as bit-sized integers",
+	)
+}
+
+func TestExportShouldRefuseBitStreams(t *testing.T) {
+	type (
+		header struct {
+			CodeNum uint8 `bitfield:"4,ue"`
+		}
+
+		sequenceParameterSet struct {
+			Header header `word:"0,bitstream"`
+		}
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = binary.Describe(
+		new(sequenceParameterSet),
+	)
+
+	assert.Nil(t, e)
+
+	_, e = Export(descriptor)
+
+	assert.EqualError(t, e,
+		"word Header is a bit stream, "+
+			"not representable as bit-sized integers",
+	)
+}
+
+func TestExportShouldRefuseWordsLongerThan64Bits(t *testing.T) {
+	type (
+		flow struct {
+			SourceAddress netip.Addr `word:"128"`
+		}
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = binary.Describe(
+		new(flow),
+	)
+
+	assert.Nil(t, e)
+
+	_, e = Export(descriptor)
+
+	assert.EqualError(t, e,
+		"word SourceAddress is longer than 64 bits, "+
+			"not representable as bit-sized integers",
+	)
+}
+
+func TestImportShouldReverseExport(t *testing.T) {
+	// Little-endian words come back allocated LSB-first,
+	// which puts their bits in the same places.
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+		format     binary.FormatDefinition
+		ksyBytes   []byte
+	)
+
+	descriptor, e = telemetryDefinition.Describe()
+
+	assert.Nil(t, e)
+
+	ksyBytes, e = Export(descriptor)
+
+	assert.Nil(t, e)
+
+	format, e = Import(ksyBytes)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		binary.FormatDefinition{
+			Name: "Telemetry",
+			Words: []binary.WordDefinition{
+				{
+					Name:    "TelemetryHeader",
+					Length:  16,
+					Options: []string{"littleendian", "lsbfirst"},
+					BitFields: []binary.BitFieldDefinition{
+						{Name: "Kind", Length: 8, Type: "uint16",
+							Options: []string{"enum=Reading:1|SOS:2"},
+						},
+						{Name: "Reserved", Length: 3, Type: "uint8",
+							Options: []string{"reserved"},
+						},
+						{Name: "Urgent", Length: 1, Type: "bool"},
+						{Name: "Version", Length: 4, Type: "uint8",
+							Options: []string{"min=1", "max=9"},
+						},
+					},
+				},
+				telemetryDefinition.Words[1],
+			},
+		},
+		format,
+	)
+}
+
+func TestImport(t *testing.T) {
+	const (
+		ksy = "" +
+			"meta:\n" +
+			"  id: gps_fix\n" +
+			"  endian: le\n" +
+			"seq:\n" +
+			"  - id: quality\n" +
+			"    type: b2\n" +
+			"  - id: satellites\n" +
+			"    type: b6\n" +
+			"  - id: altitude\n" +
+			"    type: u2\n" +
+			"  - id: flags\n" +
+			"    type: u1\n"
+	)
+
+	var (
+		e      error
+		format binary.FormatDefinition
+	)
+
+	format, e = Import(
+		[]byte(ksy),
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		binary.FormatDefinition{
+			Name: "GpsFix",
+			Words: []binary.WordDefinition{
+				{
+					Name:   "GpsFixWord0",
+					Length: 8,
+					BitFields: []binary.BitFieldDefinition{
+						{Name: "Quality", Length: 2, Type: "uint8"},
+						{Name: "Satellites", Length: 6, Type: "uint8"},
+					},
+				},
+				{
+					Name:    "GpsFixWord1",
+					Length:  16,
+					Options: []string{"littleendian"},
+					BitFields: []binary.BitFieldDefinition{
+						{Name: "Altitude", Length: 16, Type: "uint16"},
+					},
+				},
+				{
+					Name:   "GpsFixWord2",
+					Length: 8,
+					BitFields: []binary.BitFieldDefinition{
+						{Name: "Flags", Length: 8, Type: "uint8"},
+					},
+				},
+			},
+		},
+		format,
+	)
+}
+
+func TestImportShouldReportUnsupportedConstructs(t *testing.T) {
+	const (
+		ksy = "" +
+			"meta:\n" +
+			"  id: archive\n" +
+			"seq:\n" +
+			"  - id: magic\n" +
+			"    contents: [0x50, 0x4b]\n" +
+			"  - id: name\n" +
+			"    type: str\n" +
+			"    size: 8\n" +
+			"  - id: level\n" +
+			"    type: b3\n" +
+			"    valid:\n" +
+			"      any-of: [1, 2]\n" +
+			"types:\n" +
+			"  entry: {}\n"
+	)
+
+	var (
+		e error
+	)
+
+	_, e = Import(
+		[]byte(ksy),
+	)
+
+	assert.EqualError(t,
+		e, "constructs not representable as format-structs: "+
+			"attribute \"level\": valid any-of; "+
+			"attribute \"magic\": type \"\"; "+
+			"attribute \"name\": type \"str\"; "+
+			"bit-sized integers not ending at a byte boundary; "+
+			"key \"contents\" of attribute \"magic\"; "+
+			"key \"size\" of attribute \"name\"; "+
+			"top-level key \"types\"",
+	)
+}
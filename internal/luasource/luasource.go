@@ -0,0 +1,410 @@
+package luasource
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/encodingx/binary"
+)
+
+func Generate(generator string, format binary.FormatDescriptor,
+	protoName, transport string, port uint,
+) (
+	source []byte, e error,
+) {
+	// Emit a Wireshark dissector in Lua
+	// with a ProtoField for each bit field, masked out of its word,
+	// or of its own type if it is a whole word of an address, string or time,
+	// registered on a port if one is given.
+	// Formats of variable length have no fixed offsets, so are refused.
+
+	const (
+		header = "" +
+			"-- Code generated by %s; DO NOT EDIT.\n" +
+			"\n" +
+			"local proto = Proto(%q, %q)\n" +
+			"\n"
+	)
+
+	var (
+		bitField binary.BitFieldDescriptor
+		buffer   bytes.Buffer
+		fields   []string
+		i        int
+		name     string
+		repeated map[string]bool
+		word     binary.WordDescriptor
+	)
+
+	e = checkFixedLength(format)
+	if e != nil {
+		return
+	}
+
+	// Protocols are named after their formats by default.
+
+	if protoName == "" {
+		protoName = strings.ToLower(
+			baseName(
+				format.Name(),
+			),
+		)
+	}
+
+	repeated = repeatedBitFieldNames(format)
+
+	fmt.Fprintf(&buffer, header, generator, protoName,
+		baseName(
+			format.Name(),
+		),
+	)
+
+	for _, word = range format.Words() {
+		for _, bitField = range word.BitFields() {
+			fields = append(fields,
+				fieldName(word, bitField, repeated),
+			)
+
+			generateProtoField(&buffer, protoName, word, bitField,
+				fields[len(fields)-1],
+			)
+		}
+	}
+
+	buffer.WriteString("\nproto.fields = {\n")
+
+	for _, name = range fields {
+		fmt.Fprintf(&buffer, "\t%s,\n", name)
+	}
+
+	buffer.WriteString("}\n")
+
+	fmt.Fprintf(&buffer, "\n"+
+		"function proto.dissector(buffer, pinfo, tree)\n"+
+		"\tif buffer:len() < %[1]d then\n"+
+		"\t\treturn 0\n"+
+		"\tend\n"+
+		"\n"+
+		"\tpinfo.cols.protocol = proto.name\n"+
+		"\n"+
+		"\tlocal subtree = tree:add(proto, buffer(0, %[1]d))\n"+
+		"\n",
+		format.LengthInBytes(),
+	)
+
+	for _, word = range format.Words() {
+		for _, bitField = range word.BitFields() {
+			generateAdd(&buffer, word, bitField, fields[i])
+
+			i++
+		}
+	}
+
+	fmt.Fprintf(&buffer, "\n\treturn %d\nend\n",
+		format.LengthInBytes(),
+	)
+
+	if port != 0 {
+		fmt.Fprintf(&buffer, "\nDissectorTable.get(\"%s.port\"):add(%d, proto)\n",
+			transport, port,
+		)
+	}
+
+	source = buffer.Bytes()
+
+	return
+}
+
+func checkFixedLength(format binary.FormatDescriptor) (e error) {
+	var (
+		word binary.WordDescriptor
+	)
+
+	for _, word = range format.Words() {
+		if word.Varint() != "" {
+			e = fmt.Errorf("word %s of format %s holds a variable-length "+
+				"integer, not representable at fixed offsets",
+				word.Name(), baseName(format.Name()),
+			)
+
+			return
+		}
+
+		if word.BitStream() {
+			e = fmt.Errorf("word %s of format %s is a bit stream, "+
+				"not representable at fixed offsets",
+				word.Name(), baseName(format.Name()),
+			)
+
+			return
+		}
+	}
+
+	return
+}
+
+func generateProtoField(buffer *bytes.Buffer, protoName string,
+	word binary.WordDescriptor, bitField binary.BitFieldDescriptor,
+	field string,
+) {
+	var (
+		abbreviation string
+		fieldType    string
+		mask         string
+		shift        uint
+	)
+
+	abbreviation = protoName + "." + strings.TrimPrefix(field, "f_")
+
+	fieldType = nativeType(word, bitField)
+
+	switch {
+	case fieldType == "absolute_time":
+		fmt.Fprintf(buffer, "local %s = ProtoField.absolute_time(%q, %q, "+
+			"base.UTC)\n",
+			field, abbreviation, bitField.Name(),
+		)
+
+		return
+
+	case fieldType != "":
+		fmt.Fprintf(buffer, "local %s = ProtoField.%s(%q, %q)\n",
+			field, fieldType, abbreviation, bitField.Name(),
+		)
+
+		return
+	}
+
+	shift = word.LengthInBits() - bitField.BitOffset() -
+		bitField.LengthInBits()
+
+	mask = fmt.Sprintf("%#x",
+		(uint64(1)<<bitField.LengthInBits()-1)<<shift,
+	)
+
+	if word.LengthInBytes() > 4 {
+		// Lua numbers may not hold 64-bit masks exactly.
+
+		mask = fmt.Sprintf("UInt64.fromhex(%q)",
+			strings.TrimPrefix(mask, "0x"),
+		)
+	}
+
+	if bitField.Type().Kind() == reflect.Bool {
+		fmt.Fprintf(buffer, "local %s = ProtoField.bool(%q, %q, %d, nil, %s)\n",
+			field, abbreviation, bitField.Name(),
+			word.LengthInBits(), mask,
+		)
+
+		return
+	}
+
+	fmt.Fprintf(buffer, "local %s = ProtoField.%s(%q, %q, base.DEC, %s, %s)\n",
+		field, protoFieldType(word), abbreviation, bitField.Name(),
+		valueString(bitField), mask,
+	)
+
+	return
+}
+
+func generateAdd(buffer *bytes.Buffer,
+	word binary.WordDescriptor, bitField binary.BitFieldDescriptor,
+	field string,
+) {
+	// PTP timestamps have no encoding in Wireshark,
+	// so are given as the seconds and nanoseconds they hold.
+
+	const (
+		ptpSecondsLength = 6
+	)
+
+	var (
+		nanoseconds string
+		seconds     string
+	)
+
+	if nativeType(word, bitField) != "absolute_time" {
+		fmt.Fprintf(buffer, "\tsubtree:%s(%s, buffer(%d, %d))\n",
+			addFunction(word),
+			field,
+			word.ByteOffset(), word.LengthInBytes(),
+		)
+
+		return
+	}
+
+	seconds = fmt.Sprintf("buffer(%d, %d):uint64()",
+		word.ByteOffset(), ptpSecondsLength,
+	)
+
+	nanoseconds = fmt.Sprintf("buffer(%d, %d):uint()",
+		word.ByteOffset()+ptpSecondsLength,
+		word.LengthInBytes()-ptpSecondsLength,
+	)
+
+	if word.ByteOrder() == binary.LittleEndian {
+		seconds = fmt.Sprintf("buffer(%d, %d):le_uint64()",
+			word.ByteOffset()+word.LengthInBytes()-ptpSecondsLength,
+			ptpSecondsLength,
+		)
+
+		nanoseconds = fmt.Sprintf("buffer(%d, %d):le_uint()",
+			word.ByteOffset(), word.LengthInBytes()-ptpSecondsLength,
+		)
+	}
+
+	fmt.Fprintf(buffer, "\tsubtree:add(%s, buffer(%d, %d), "+
+		"NSTime.new(%s:tonumber(), %s))\n",
+		field,
+		word.ByteOffset(), word.LengthInBytes(),
+		seconds, nanoseconds,
+	)
+
+	return
+}
+
+func nativeType(word binary.WordDescriptor,
+	bitField binary.BitFieldDescriptor,
+) string {
+	// Name the ProtoField type of a bit field making up a whole word
+	// of an address, string or PTP timestamp, or of a word too long
+	// for any integer type, which is left as bytes.
+
+	if bitField.LengthInBits() != word.LengthInBits() {
+		return ""
+	}
+
+	switch {
+	case bitField.Type() == reflect.TypeOf(netip.Addr{}) &&
+		bitField.LengthInBits() == 32:
+		return "ipv4"
+
+	case bitField.Type() == reflect.TypeOf(netip.Addr{}) &&
+		bitField.LengthInBits() == 128:
+		return "ipv6"
+
+	case bitField.Type() == reflect.TypeOf(net.HardwareAddr{}) &&
+		bitField.LengthInBits() == 48:
+		return "ether"
+
+	case bitField.Type() == reflect.TypeOf(net.HardwareAddr{}) &&
+		bitField.LengthInBits() == 64:
+		return "eui64"
+
+	case bitField.Type().Kind() == reflect.String:
+		return "string"
+
+	case bitField.Type() == reflect.TypeOf(time.Time{}) &&
+		bitField.LengthInBits() > 64:
+		return "absolute_time"
+
+	case word.LengthInBits() > 64:
+		return "bytes"
+	}
+
+	return ""
+}
+
+func fieldName(word binary.WordDescriptor,
+	bitField binary.BitFieldDescriptor, repeated map[string]bool,
+) string {
+	if repeated[bitField.Name()] {
+		return "f_" + strings.ToLower(word.Name()+bitField.Name())
+	}
+
+	return "f_" + strings.ToLower(bitField.Name())
+}
+
+func valueString(bitField binary.BitFieldDescriptor) string {
+	// Name enumerated values, as would the "enum=" option in dumps.
+
+	var (
+		enumerated  binary.EnumeratedValue
+		enumeration []binary.EnumeratedValue
+		pairs       []string
+	)
+
+	enumeration = bitField.Enumeration()
+	if len(enumeration) == 0 {
+		return "nil"
+	}
+
+	sort.Slice(enumeration,
+		func(i, j int) bool {
+			return enumeration[i].Value < enumeration[j].Value
+		},
+	)
+
+	for _, enumerated = range enumeration {
+		pairs = append(pairs,
+			fmt.Sprintf("[%d] = %q", enumerated.Value, enumerated.Name),
+		)
+	}
+
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+func protoFieldType(word binary.WordDescriptor) string {
+	switch word.LengthInBytes() {
+	case 1:
+		return "uint8"
+
+	case 2:
+		return "uint16"
+
+	case 3:
+		return "uint24"
+
+	case 4:
+		return "uint32"
+	}
+
+	return "uint64"
+}
+
+func addFunction(word binary.WordDescriptor) string {
+	if word.ByteOrder() == binary.LittleEndian {
+		return "add_le"
+	}
+
+	return "add"
+}
+
+func repeatedBitFieldNames(format binary.FormatDescriptor) (
+	repeated map[string]bool,
+) {
+	// Bit fields need only be named uniquely in their words,
+	// so names repeated in a format are qualified by word.
+
+	var (
+		bitField binary.BitFieldDescriptor
+		seen     = make(map[string]bool)
+		word     binary.WordDescriptor
+	)
+
+	repeated = make(map[string]bool)
+
+	for _, word = range format.Words() {
+		for _, bitField = range word.BitFields() {
+			if seen[bitField.Name()] {
+				repeated[bitField.Name()] = true
+			}
+
+			seen[bitField.Name()] = true
+		}
+	}
+
+	return
+}
+
+func baseName(name string) string {
+	// Drop package names of format-structs (e.g. "rfc791.").
+
+	return name[strings.LastIndex(name, ".")+1:]
+}
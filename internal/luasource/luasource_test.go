@@ -0,0 +1,246 @@
+package luasource
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/encodingx/binary"
+)
+
+func TestGenerate(t *testing.T) {
+	// Boolean bit fields are ProtoField.bool,
+	// names repeated in a format are qualified by word,
+	// and dissectors are registered on no port unless one is given.
+
+	const (
+		dissector = "" +
+			"-- Code generated by test; DO NOT EDIT.\n" +
+			"\n" +
+			"local proto = Proto(\"frame\", \"Frame\")\n" +
+			"\n" +
+			"local f_urgent = ProtoField.bool(\"frame.urgent\", " +
+			"\"Urgent\", 8, nil, 0x80)\n" +
+			"local f_headerreserved = ProtoField.uint8(" +
+			"\"frame.headerreserved\", \"Reserved\", base.DEC, nil, 0x7f)\n" +
+			"local f_trailerreserved = ProtoField.uint16(" +
+			"\"frame.trailerreserved\", \"Reserved\", base.DEC, nil, 0xffff)\n" +
+			"\n" +
+			"proto.fields = {\n" +
+			"\tf_urgent,\n" +
+			"\tf_headerreserved,\n" +
+			"\tf_trailerreserved,\n" +
+			"}\n" +
+			"\n" +
+			"function proto.dissector(buffer, pinfo, tree)\n" +
+			"\tif buffer:len() < 3 then\n" +
+			"\t\treturn 0\n" +
+			"\tend\n" +
+			"\n" +
+			"\tpinfo.cols.protocol = proto.name\n" +
+			"\n" +
+			"\tlocal subtree = tree:add(proto, buffer(0, 3))\n" +
+			"\n" +
+			"\tsubtree:add(f_urgent, buffer(0, 1))\n" +
+			"\tsubtree:add(f_headerreserved, buffer(0, 1))\n" +
+			"\tsubtree:add(f_trailerreserved, buffer(1, 2))\n" +
+			"\n" +
+			"\treturn 3\n" +
+			"end\n"
+	)
+
+	type (
+		Header struct {
+			Urgent   bool  `bitfield:"1"`
+			Reserved uint8 `bitfield:"7"`
+		}
+
+		Trailer struct {
+			Reserved uint16 `bitfield:"16"`
+		}
+
+		Frame struct {
+			Header  `word:"8"`
+			Trailer `word:"16"`
+		}
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+		source     []byte
+	)
+
+	descriptor, e = binary.Describe(
+		new(Frame),
+	)
+
+	assert.Nil(t, e)
+
+	source, e = Generate("test", descriptor, "", "udp", 0)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		dissector, string(source),
+	)
+}
+
+func TestGenerateShouldWriteMasksOfLongWordsAsUInt64(t *testing.T) {
+	// Lua numbers may not hold 64-bit masks exactly.
+
+	const (
+		protoField = "" +
+			"local f_high = ProtoField.uint64(\"counter.high\", " +
+			"\"High\", base.DEC, nil, UInt64.fromhex(\"ffffffff00000000\"))\n"
+	)
+
+	type (
+		Word struct {
+			High uint32 `bitfield:"32"`
+			Low  uint32 `bitfield:"32"`
+		}
+
+		Counter struct {
+			Word `word:"64"`
+		}
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+		source     []byte
+	)
+
+	descriptor, e = binary.Describe(
+		new(Counter),
+	)
+
+	assert.Nil(t, e)
+
+	source, e = Generate("test", descriptor, "", "udp", 0)
+
+	assert.Nil(t, e)
+
+	assert.Contains(t,
+		string(source), protoField,
+	)
+}
+
+func TestGenerateShouldRefuseVarints(t *testing.T) {
+	type (
+		Header struct {
+			Kind uint8 `bitfield:"8"`
+		}
+
+		Telemetry struct {
+			Length uint16 `word:"14,quic"`
+			Header `word:"8"`
+		}
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = binary.Describe(
+		new(Telemetry),
+	)
+
+	assert.Nil(t, e)
+
+	_, e = Generate("test", descriptor, "", "udp", 0)
+
+	assert.EqualError(t, e,
+		"word Length of format Telemetry holds a variable-length "+
+			"integer, not representable at fixed offsets",
+	)
+}
+
+func TestGenerateShouldRefuseBitStreams(t *testing.T) {
+	type (
+		Header struct {
+			CodeNum uint8 `bitfield:"4,ue"`
+		}
+
+		SequenceParameterSet struct {
+			Header `word:"0,bitstream"`
+		}
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+	)
+
+	descriptor, e = binary.Describe(
+		new(SequenceParameterSet),
+	)
+
+	assert.Nil(t, e)
+
+	_, e = Generate("test", descriptor, "", "udp", 0)
+
+	assert.EqualError(t, e,
+		"word Header of format SequenceParameterSet is a bit stream, "+
+			"not representable at fixed offsets",
+	)
+}
+
+func TestGenerateShouldGiveWholeWordsOfTypesTheirOwnFields(t *testing.T) {
+	type (
+		Ports struct {
+			Source      uint16 `bitfield:"16"`
+			Destination uint16 `bitfield:"16"`
+		}
+
+		Flow struct {
+			SourceAddress netip.Addr       `word:"128"`
+			Gateway       netip.Addr       `word:"32"`
+			Hardware      net.HardwareAddr `word:"48"`
+			Origin        time.Time        `word:"80,ptp"`
+			Ports         `word:"32"`
+		}
+	)
+
+	var (
+		descriptor binary.FormatDescriptor
+		e          error
+		line       string
+		lines      = []string{
+			"local f_sourceaddress = " +
+				"ProtoField.ipv6(\"flow.sourceaddress\", \"SourceAddress\")\n",
+			"local f_gateway = " +
+				"ProtoField.ipv4(\"flow.gateway\", \"Gateway\")\n",
+			"local f_hardware = " +
+				"ProtoField.ether(\"flow.hardware\", \"Hardware\")\n",
+			"local f_origin = " +
+				"ProtoField.absolute_time(\"flow.origin\", \"Origin\", " +
+				"base.UTC)\n",
+			"\tsubtree:add(f_sourceaddress, buffer(0, 16))\n",
+			"\tsubtree:add(f_origin, buffer(26, 10), " +
+				"NSTime.new(buffer(26, 6):uint64():tonumber(), " +
+				"buffer(32, 4):uint()))\n",
+			"\tsubtree:add(f_source, buffer(36, 4))\n",
+		}
+		source []byte
+	)
+
+	descriptor, e = binary.Describe(
+		new(Flow),
+	)
+
+	assert.Nil(t, e)
+
+	source, e = Generate("test", descriptor, "", "", 0)
+
+	assert.Nil(t, e)
+
+	for _, line = range lines {
+		assert.Contains(t, string(source), line)
+	}
+}
@@ -40,7 +40,10 @@ func (e *bitFieldOfUnsupportedTypeError) Error() (s string) {
 		format = "" +
 			"A bit field is represented " +
 			"by an exported field of a word-struct " +
-			"of type uintN or bool. " +
+			"of type uintN, intN, bool, string, " +
+			"netip.Addr, netip.AddrPort, net.HardwareAddr, [N]byte, " +
+			"time.Time or time.Duration, " +
+			"or of a type implementing encoding.BinaryMarshaler. " +
 			"Argument to %s points to a format-struct \"%s\" " +
 			"nesting a word-struct \"%s\" " +
 			"that has a bit field \"%s\" " +
@@ -125,6 +128,40 @@ func (e *bitFieldWithMalformedTagError) Error() (s string) {
 	return
 }
 
+type bitFieldWithUnknownOptionError struct {
+	DefaultBitFieldError
+	option string
+}
+
+func NewBitFieldWithUnknownOptionError(option string) (
+	e *bitFieldWithUnknownOptionError,
+) {
+	e = &bitFieldWithUnknownOptionError{
+		option: option,
+	}
+
+	return
+}
+
+func (e *bitFieldWithUnknownOptionError) Error() (s string) {
+	const (
+		format = "" +
+			"Options following the length of a bit field in its struct tag " +
+			"should be those documented (e.g. `bitfield:\"2,reserved\"`). " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"with an unknown option \"%s\"."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+		e.option,
+	)
+
+	return
+}
+
 type bitFieldWithNoStructTagError struct {
 	DefaultBitFieldError
 }
@@ -153,3 +190,736 @@ func (e *bitFieldWithNoStructTagError) Error() (s string) {
 
 	return
 }
+
+type bitFieldWithInvalidNameError struct {
+	DefaultBitFieldError
+}
+
+func NewBitFieldWithInvalidNameError() *bitFieldWithInvalidNameError {
+	return new(bitFieldWithInvalidNameError)
+}
+
+func (e *bitFieldWithInvalidNameError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field should be named by an exported identifier " +
+			"unique in its word, or in its format if defined at run time. " +
+			"Argument to %s points to a format \"%s\" " +
+			"nesting a word \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"whose name is not a unique exported identifier."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+	)
+
+	return
+}
+
+type bitFieldValueOfIncompatibleTypeError struct {
+	DefaultBitFieldError
+	bitFieldType string
+	valueType    string
+}
+
+func NewBitFieldValueOfIncompatibleTypeError(
+	bitFieldType, valueType string,
+) (
+	e *bitFieldValueOfIncompatibleTypeError,
+) {
+	e = &bitFieldValueOfIncompatibleTypeError{
+		bitFieldType: bitFieldType,
+		valueType:    valueType,
+	}
+
+	return
+}
+
+func (e *bitFieldValueOfIncompatibleTypeError) Error() (s string) {
+	const (
+		format = "" +
+			"A value given for a bit field " +
+			"should be convertible to the type of the bit field " +
+			"without loss. " +
+			"Argument to %s points to a format \"%s\" " +
+			"nesting a word \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"of type \"%s\" given a value %s."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+		e.bitFieldType, e.valueType,
+	)
+
+	return
+}
+
+type bitFieldMarshalerFailedError struct {
+	DefaultBitFieldError
+	cause error
+}
+
+func NewBitFieldMarshalerFailedError(cause error) (
+	e *bitFieldMarshalerFailedError,
+) {
+	e = &bitFieldMarshalerFailedError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *bitFieldMarshalerFailedError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field implementing encoding.BinaryMarshaler " +
+			"and encoding.BinaryUnmarshaler should convert " +
+			"to and from as many bytes as needed to hold its length. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"that failed: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName, e.cause,
+	)
+
+	return
+}
+
+func (e *bitFieldMarshalerFailedError) Unwrap() error {
+	return e.cause
+}
+
+type bitFieldOfLengthUnfitForNetworkTypeError struct {
+	DefaultBitFieldError
+	bitFieldLength  uint
+	bitFieldType    string
+	bitFieldLengths string
+}
+
+func NewBitFieldOfLengthUnfitForNetworkTypeError(
+	bitFieldLength uint, bitFieldType, bitFieldLengths string,
+) (
+	e *bitFieldOfLengthUnfitForNetworkTypeError,
+) {
+	e = &bitFieldOfLengthUnfitForNetworkTypeError{
+		bitFieldLength:  bitFieldLength,
+		bitFieldType:    bitFieldType,
+		bitFieldLengths: bitFieldLengths,
+	}
+
+	return
+}
+
+func (e *bitFieldOfLengthUnfitForNetworkTypeError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field of a network type should be exactly as long " +
+			"as the addresses or ports it holds. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"of type \"%s\" and length %d not in {%s}."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+		e.bitFieldType, e.bitFieldLength, e.bitFieldLengths,
+	)
+
+	return
+}
+
+type bitFieldNetworkValueUnfitError struct {
+	DefaultBitFieldError
+	cause error
+}
+
+func NewBitFieldNetworkValueUnfitError(cause error) (
+	e *bitFieldNetworkValueUnfitError,
+) {
+	e = &bitFieldNetworkValueUnfitError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *bitFieldNetworkValueUnfitError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field of a network type should hold a value " +
+			"that fits its length (e.g. an IPv4 address in 32 bits). " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"that does not: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName, e.cause,
+	)
+
+	return
+}
+
+func (e *bitFieldNetworkValueUnfitError) Unwrap() error {
+	return e.cause
+}
+
+type bitFieldOfLengthUnfitForTimeEncodingError struct {
+	DefaultBitFieldError
+	bitFieldLength  uint
+	encoding        string
+	bitFieldLengths string
+}
+
+func NewBitFieldOfLengthUnfitForTimeEncodingError(
+	bitFieldLength uint, encoding, bitFieldLengths string,
+) (
+	e *bitFieldOfLengthUnfitForTimeEncodingError,
+) {
+	e = &bitFieldOfLengthUnfitForTimeEncodingError{
+		bitFieldLength:  bitFieldLength,
+		encoding:        encoding,
+		bitFieldLengths: bitFieldLengths,
+	}
+
+	return
+}
+
+func (e *bitFieldOfLengthUnfitForTimeEncodingError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field holding a time or duration should be as long " +
+			"as its encoding requires. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"encoded as %s of length %d not in %s."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+		e.encoding, e.bitFieldLength, e.bitFieldLengths,
+	)
+
+	return
+}
+
+type bitFieldTimeOutOfRangeError struct {
+	DefaultBitFieldError
+	cause error
+}
+
+func NewBitFieldTimeOutOfRangeError(cause error) (
+	e *bitFieldTimeOutOfRangeError,
+) {
+	e = &bitFieldTimeOutOfRangeError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *bitFieldTimeOutOfRangeError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field holding a time or duration should be given one " +
+			"that its encoding can represent. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"given one out of range: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName, e.cause,
+	)
+
+	return
+}
+
+func (e *bitFieldTimeOutOfRangeError) Unwrap() error {
+	return e.cause
+}
+
+type bitFieldInvalidTimeError struct {
+	DefaultBitFieldError
+	cause error
+}
+
+func NewBitFieldInvalidTimeError(cause error) (
+	e *bitFieldInvalidTimeError,
+) {
+	e = &bitFieldInvalidTimeError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *bitFieldInvalidTimeError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field holding a time or duration should hold one " +
+			"that its type can represent. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"holding one out of range: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName, e.cause,
+	)
+
+	return
+}
+
+func (e *bitFieldInvalidTimeError) Unwrap() error {
+	return e.cause
+}
+
+type bitFieldWithMalformedEnumerationError struct {
+	DefaultBitFieldError
+	bitFieldType string
+	cause        error
+}
+
+func NewBitFieldWithMalformedEnumerationError(bitFieldType string,
+	cause error,
+) (
+	e *bitFieldWithMalformedEnumerationError,
+) {
+	e = &bitFieldWithMalformedEnumerationError{
+		bitFieldType: bitFieldType,
+		cause:        cause,
+	}
+
+	return
+}
+
+func (e *bitFieldWithMalformedEnumerationError) Error() (s string) {
+	const (
+		format = "" +
+			"Enumerated values registered for the type of a bit field " +
+			"should have unique names that are identifiers " +
+			"and values that fit the bit field. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"of type \"%s\" with enumerated values that do not: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+		e.bitFieldType, e.cause,
+	)
+
+	return
+}
+
+func (e *bitFieldWithMalformedEnumerationError) Unwrap() error {
+	return e.cause
+}
+
+type bitFieldValueNotEnumeratedError struct {
+	DefaultBitFieldError
+	value uint64
+}
+
+func NewBitFieldValueNotEnumeratedError(value uint64) (
+	e *bitFieldValueNotEnumeratedError,
+) {
+	e = &bitFieldValueNotEnumeratedError{
+		value: value,
+	}
+
+	return
+}
+
+func (e *bitFieldValueNotEnumeratedError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field with the option \"strict\" " +
+			"should hold one of its enumerated values. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"holding a value %d not enumerated."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName, e.value,
+	)
+
+	return
+}
+
+type bitFieldWithMalformedFlagsError struct {
+	DefaultBitFieldError
+	bitFieldType string
+	cause        error
+}
+
+func NewBitFieldWithMalformedFlagsError(bitFieldType string, cause error) (
+	e *bitFieldWithMalformedFlagsError,
+) {
+	e = &bitFieldWithMalformedFlagsError{
+		bitFieldType: bitFieldType,
+		cause:        cause,
+	}
+
+	return
+}
+
+func (e *bitFieldWithMalformedFlagsError) Error() (s string) {
+	const (
+		format = "" +
+			"Flags registered for the type of a bit field " +
+			"should have unique names that are identifiers " +
+			"and values that are distinct single bits of the bit field. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"of type \"%s\" with flags that do not: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+		e.bitFieldType, e.cause,
+	)
+
+	return
+}
+
+func (e *bitFieldWithMalformedFlagsError) Unwrap() error {
+	return e.cause
+}
+
+type bitFieldUndeclaredFlagsError struct {
+	DefaultBitFieldError
+	undeclared uint64
+}
+
+func NewBitFieldUndeclaredFlagsError(undeclared uint64) (
+	e *bitFieldUndeclaredFlagsError,
+) {
+	e = &bitFieldUndeclaredFlagsError{
+		undeclared: undeclared,
+	}
+
+	return
+}
+
+func (e *bitFieldUndeclaredFlagsError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field of flags with the option \"strict\" " +
+			"should have no bits set other than its flags. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"with undeclared bits %#b set."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+		e.undeclared,
+	)
+
+	return
+}
+
+type bitFieldOfLengthUnfitForBCDError struct {
+	DefaultBitFieldError
+	bitFieldLength uint
+}
+
+func NewBitFieldOfLengthUnfitForBCDError(bitFieldLength uint) (
+	e *bitFieldOfLengthUnfitForBCDError,
+) {
+	e = &bitFieldOfLengthUnfitForBCDError{
+		bitFieldLength: bitFieldLength,
+	}
+
+	return
+}
+
+func (e *bitFieldOfLengthUnfitForBCDError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field encoded as binary-coded decimal " +
+			"should have a length that is a multiple of 4. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"of length %d."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+		e.bitFieldLength,
+	)
+
+	return
+}
+
+type bitFieldNumberOutOfRangeError struct {
+	DefaultBitFieldError
+	cause error
+}
+
+func NewBitFieldNumberOutOfRangeError(cause error) (
+	e *bitFieldNumberOutOfRangeError,
+) {
+	e = &bitFieldNumberOutOfRangeError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *bitFieldNumberOutOfRangeError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field encoded as binary-coded decimal should be given " +
+			"a number of no more decimal digits than it holds. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"given one out of range: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName, e.cause,
+	)
+
+	return
+}
+
+func (e *bitFieldNumberOutOfRangeError) Unwrap() error {
+	return e.cause
+}
+
+type bitFieldInvalidBCDError struct {
+	DefaultBitFieldError
+	value uint64
+}
+
+func NewBitFieldInvalidBCDError(value uint64) (
+	e *bitFieldInvalidBCDError,
+) {
+	e = &bitFieldInvalidBCDError{
+		value: value,
+	}
+
+	return
+}
+
+func (e *bitFieldInvalidBCDError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field encoded as binary-coded decimal " +
+			"should hold a decimal digit in every four bits. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"holding a value %#x with a digit greater than 9."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName, e.value,
+	)
+
+	return
+}
+
+type bitFieldSignedOutOfRangeError struct {
+	DefaultBitFieldError
+	cause error
+}
+
+func NewBitFieldSignedOutOfRangeError(cause error) (
+	e *bitFieldSignedOutOfRangeError,
+) {
+	e = &bitFieldSignedOutOfRangeError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *bitFieldSignedOutOfRangeError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field holding a signed integer should be given one " +
+			"that its length and encoding can represent. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"given one out of range: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName, e.cause,
+	)
+
+	return
+}
+
+func (e *bitFieldSignedOutOfRangeError) Unwrap() error {
+	return e.cause
+}
+
+type bitFieldOfLengthUnfitForStringError struct {
+	DefaultBitFieldError
+	bitFieldLength uint
+}
+
+func NewBitFieldOfLengthUnfitForStringError(bitFieldLength uint) (
+	e *bitFieldOfLengthUnfitForStringError,
+) {
+	e = &bitFieldOfLengthUnfitForStringError{
+		bitFieldLength: bitFieldLength,
+	}
+
+	return
+}
+
+func (e *bitFieldOfLengthUnfitForStringError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field holding a string should span whole bytes. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"of length %d."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+		e.bitFieldLength,
+	)
+
+	return
+}
+
+type bitFieldStringUnfitError struct {
+	DefaultBitFieldError
+	cause error
+}
+
+func NewBitFieldStringUnfitError(cause error) (
+	e *bitFieldStringUnfitError,
+) {
+	e = &bitFieldStringUnfitError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *bitFieldStringUnfitError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field holding a string should hold no more bytes " +
+			"than its length and only characters of its character set. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"that does not: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName, e.cause,
+	)
+
+	return
+}
+
+func (e *bitFieldStringUnfitError) Unwrap() error {
+	return e.cause
+}
+
+type bitFieldOfLengthUnfitForVarintError struct {
+	DefaultBitFieldError
+	bitFieldLength  uint
+	encoding        string
+	bitFieldLengths string
+}
+
+func NewBitFieldOfLengthUnfitForVarintError(
+	bitFieldLength uint, encoding, bitFieldLengths string,
+) (
+	e *bitFieldOfLengthUnfitForVarintError,
+) {
+	e = &bitFieldOfLengthUnfitForVarintError{
+		bitFieldLength:  bitFieldLength,
+		encoding:        encoding,
+		bitFieldLengths: bitFieldLengths,
+	}
+
+	return
+}
+
+func (e *bitFieldOfLengthUnfitForVarintError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field holding a variable-length integer " +
+			"should be no longer than its encoding allows. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"encoded as %s of length %d not in %s."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName,
+		e.encoding, e.bitFieldLength, e.bitFieldLengths,
+	)
+
+	return
+}
+
+type bitFieldVarintOutOfRangeError struct {
+	DefaultBitFieldError
+	cause error
+}
+
+func NewBitFieldVarintOutOfRangeError(cause error) (
+	e *bitFieldVarintOutOfRangeError,
+) {
+	e = &bitFieldVarintOutOfRangeError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *bitFieldVarintOutOfRangeError) Error() (s string) {
+	const (
+		format = "" +
+			"A bit field holding a variable-length integer should be given " +
+			"one of no more bits than its length. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that has a bit field \"%s\" " +
+			"given one out of range: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.bitFieldName, e.cause,
+	)
+
+	return
+}
+
+func (e *bitFieldVarintOutOfRangeError) Unwrap() error {
+	return e.cause
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,16 +13,19 @@ const (
 
 func TestBitFieldOfUnsupportedTypeError(t *testing.T) {
 	const (
-		bitFieldType = "int"
+		bitFieldType = "float64"
 
 		errorMessage = "" +
 			"A bit field is represented " +
 			"by an exported field of a word-struct " +
-			"of type uintN or bool. " +
+			"of type uintN, intN, bool, string, " +
+			"netip.Addr, netip.AddrPort, net.HardwareAddr, [N]byte, " +
+			"time.Time or time.Duration, " +
+			"or of a type implementing encoding.BinaryMarshaler. " +
 			"Argument to Marshal points to a format-struct \"Format\" " +
 			"nesting a word-struct \"Word\" " +
 			"that has a bit field \"BitField\" " +
-			"of unsupported type \"int\"."
+			"of unsupported type \"float64\"."
 	)
 
 	var (
@@ -109,6 +113,38 @@ func TestBitFieldWithMalformedTagError(t *testing.T) {
 	)
 }
 
+func TestBitFieldWithUnknownOptionError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"Options following the length of a bit field in its struct tag " +
+			"should be those documented (e.g. `bitfield:\"2,reserved\"`). " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"with an unknown option \"reversed\"."
+
+		option = "reversed"
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldWithUnknownOptionError(option)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
 func TestBitFieldWithNoStructTagError(t *testing.T) {
 	const (
 		errorMessage = "" +
@@ -141,3 +177,692 @@ func TestBitFieldWithNoStructTagError(t *testing.T) {
 		errorMessage, e.Error(),
 	)
 }
+
+func TestBitFieldWithInvalidNameError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A bit field should be named by an exported identifier " +
+			"unique in its word, or in its format if defined at run time. " +
+			"Argument to Marshal points to a format \"Format\" " +
+			"nesting a word \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"whose name is not a unique exported identifier."
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldWithInvalidNameError()
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestBitFieldValueOfIncompatibleTypeError(t *testing.T) {
+	const (
+		bitFieldType = "uint8"
+		valueType    = "-1 of type \"int\""
+
+		errorMessage = "" +
+			"A value given for a bit field " +
+			"should be convertible to the type of the bit field " +
+			"without loss. " +
+			"Argument to Marshal points to a format \"Format\" " +
+			"nesting a word \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of type \"uint8\" given a value -1 of type \"int\"."
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldValueOfIncompatibleTypeError(bitFieldType, valueType)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestBitFieldMarshalerFailedError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A bit field implementing encoding.BinaryMarshaler " +
+			"and encoding.BinaryUnmarshaler should convert " +
+			"to and from as many bytes as needed to hold its length. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"that failed: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     BitFieldError
+	)
+
+	e = NewBitFieldMarshalerFailedError(cause)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
+
+func TestBitFieldOfLengthUnfitForNetworkTypeError(t *testing.T) {
+	const (
+		bitFieldLength  = 24
+		bitFieldType    = "netip.Addr"
+		bitFieldLengths = "32, 128"
+
+		errorMessage = "" +
+			"A bit field of a network type should be exactly as long " +
+			"as the addresses or ports it holds. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of type \"netip.Addr\" and length 24 not in {32, 128}."
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldOfLengthUnfitForNetworkTypeError(bitFieldLength,
+		bitFieldType, bitFieldLengths,
+	)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestBitFieldNetworkValueUnfitError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A bit field of a network type should hold a value " +
+			"that fits its length (e.g. an IPv4 address in 32 bits). " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"that does not: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     BitFieldError
+	)
+
+	e = NewBitFieldNetworkValueUnfitError(cause)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
+
+func TestBitFieldOfLengthUnfitForTimeEncodingError(t *testing.T) {
+	const (
+		bitFieldLength  = 48
+		encoding        = "ntp"
+		bitFieldLengths = "{32, 64}"
+
+		errorMessage = "" +
+			"A bit field holding a time or duration should be as long " +
+			"as its encoding requires. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"encoded as ntp of length 48 not in {32, 64}."
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldOfLengthUnfitForTimeEncodingError(bitFieldLength,
+		encoding, bitFieldLengths,
+	)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestBitFieldTimeOutOfRangeError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A bit field holding a time or duration should be given one " +
+			"that its encoding can represent. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"given one out of range: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     BitFieldError
+	)
+
+	e = NewBitFieldTimeOutOfRangeError(cause)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
+
+func TestBitFieldInvalidTimeError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A bit field holding a time or duration should hold one " +
+			"that its type can represent. " +
+			"Argument to Unmarshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"holding one out of range: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     BitFieldError
+	)
+
+	e = NewBitFieldInvalidTimeError(cause)
+
+	e.SetFunctionName("Unmarshal")
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
+
+func TestBitFieldWithMalformedEnumerationError(t *testing.T) {
+	const (
+		bitFieldType = "binary.Kind"
+
+		errorMessage = "" +
+			"Enumerated values registered for the type of a bit field " +
+			"should have unique names that are identifiers " +
+			"and values that fit the bit field. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of type \"binary.Kind\" with enumerated values that do not: " +
+			"cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     BitFieldError
+	)
+
+	e = NewBitFieldWithMalformedEnumerationError(bitFieldType, cause)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
+
+func TestBitFieldValueNotEnumeratedError(t *testing.T) {
+	const (
+		value = 3
+
+		errorMessage = "" +
+			"A bit field with the option \"strict\" " +
+			"should hold one of its enumerated values. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"holding a value 3 not enumerated."
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldValueNotEnumeratedError(value)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestBitFieldWithMalformedFlagsError(t *testing.T) {
+	const (
+		bitFieldType = "binary.IPFlags"
+
+		errorMessage = "" +
+			"Flags registered for the type of a bit field " +
+			"should have unique names that are identifiers " +
+			"and values that are distinct single bits of the bit field. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of type \"binary.IPFlags\" with flags that do not: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     BitFieldError
+	)
+
+	e = NewBitFieldWithMalformedFlagsError(bitFieldType, cause)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
+
+func TestBitFieldUndeclaredFlagsError(t *testing.T) {
+	const (
+		undeclared = 0b100
+
+		errorMessage = "" +
+			"A bit field of flags with the option \"strict\" " +
+			"should have no bits set other than its flags. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"with undeclared bits 0b100 set."
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldUndeclaredFlagsError(undeclared)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestBitFieldOfLengthUnfitForBCDError(t *testing.T) {
+	const (
+		bitFieldLength = 6
+
+		errorMessage = "" +
+			"A bit field encoded as binary-coded decimal " +
+			"should have a length that is a multiple of 4. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of length 6."
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldOfLengthUnfitForBCDError(bitFieldLength)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestBitFieldNumberOutOfRangeError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A bit field encoded as binary-coded decimal should be given " +
+			"a number of no more decimal digits than it holds. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"given one out of range: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     BitFieldError
+	)
+
+	e = NewBitFieldNumberOutOfRangeError(cause)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
+
+func TestBitFieldInvalidBCDError(t *testing.T) {
+	const (
+		value = 0x5a
+
+		errorMessage = "" +
+			"A bit field encoded as binary-coded decimal " +
+			"should hold a decimal digit in every four bits. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"holding a value 0x5a with a digit greater than 9."
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldInvalidBCDError(value)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestBitFieldSignedOutOfRangeError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A bit field holding a signed integer should be given one " +
+			"that its length and encoding can represent. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"given one out of range: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     BitFieldError
+	)
+
+	e = NewBitFieldSignedOutOfRangeError(cause)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
+
+func TestBitFieldOfLengthUnfitForStringError(t *testing.T) {
+	const (
+		bitFieldLength = 12
+
+		errorMessage = "" +
+			"A bit field holding a string should span whole bytes. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"of length 12."
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldOfLengthUnfitForStringError(bitFieldLength)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestBitFieldStringUnfitError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A bit field holding a string should hold no more bytes " +
+			"than its length and only characters of its character set. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"that does not: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     BitFieldError
+	)
+
+	e = NewBitFieldStringUnfitError(cause)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
+
+func TestBitFieldOfLengthUnfitForVarintError(t *testing.T) {
+	const (
+		bitFieldLength  = 32
+		encoding        = "mqtt"
+		bitFieldLengths = "[1, 28]"
+
+		errorMessage = "" +
+			"A bit field holding a variable-length integer " +
+			"should be no longer than its encoding allows. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"encoded as mqtt of length 32 not in [1, 28]."
+	)
+
+	var (
+		e BitFieldError
+	)
+
+	e = NewBitFieldOfLengthUnfitForVarintError(bitFieldLength,
+		encoding, bitFieldLengths,
+	)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestBitFieldVarintOutOfRangeError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A bit field holding a variable-length integer should be given " +
+			"one of no more bits than its length. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that has a bit field \"BitField\" " +
+			"given one out of range: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     BitFieldError
+	)
+
+	e = NewBitFieldVarintOutOfRangeError(cause)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	e.SetBitFieldName(bitFieldName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
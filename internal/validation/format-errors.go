@@ -64,12 +64,13 @@ func (e *lengthOfByteSliceNotEqualToFormatLengthError) Error() (s string) {
 			"A byte slice into which a format-struct would be unmarshalled " +
 			"should be of length equal to the sum of lengths of words " +
 			"in the format represented by the struct. " +
-			"Argument to Unmarshal points to a format-struct \"%s\" " +
+			"Argument to %s points to a format-struct \"%s\" " +
 			"of length %d byte(s) " +
 			"not equal to the length of the byte slice, %d byte(s)."
 	)
 
 	s = fmt.Sprintf(format,
+		e.functionName,
 		e.formatName,
 		e.formatLengthInBytes,
 		e.byteSliceLength,
@@ -77,3 +78,155 @@ func (e *lengthOfByteSliceNotEqualToFormatLengthError) Error() (s string) {
 
 	return
 }
+
+type valueForUnknownBitFieldError struct {
+	DefaultFormatError
+	bitFieldName string
+}
+
+func NewValueForUnknownBitFieldError(bitFieldName string) (
+	e *valueForUnknownBitFieldError,
+) {
+	e = &valueForUnknownBitFieldError{
+		bitFieldName: bitFieldName,
+	}
+
+	return
+}
+
+func (e *valueForUnknownBitFieldError) Error() (s string) {
+	const (
+		format = "" +
+			"Values should be given only for bit fields in a format. " +
+			"Argument to %s gives a value for a bit field \"%s\" " +
+			"not in format \"%s\"."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.bitFieldName, e.formatName,
+	)
+
+	return
+}
+
+type unknownBitFieldError struct {
+	DefaultFormatError
+	bitFieldName string
+}
+
+func NewUnknownBitFieldError(bitFieldName string) (
+	e *unknownBitFieldError,
+) {
+	e = &unknownBitFieldError{
+		bitFieldName: bitFieldName,
+	}
+
+	return
+}
+
+func (e *unknownBitFieldError) Error() (s string) {
+	const (
+		format = "" +
+			"Bit fields should be named as in their format, " +
+			"optionally qualified by the names of their words. " +
+			"Argument to %s names a bit field \"%s\" " +
+			"not in format \"%s\"."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.bitFieldName, e.formatName,
+	)
+
+	return
+}
+
+type byteSliceUnfitForVariableLengthFormatError struct {
+	DefaultFormatError
+	cause error
+}
+
+func NewByteSliceUnfitForVariableLengthFormatError(cause error) (
+	e *byteSliceUnfitForVariableLengthFormatError,
+) {
+	e = &byteSliceUnfitForVariableLengthFormatError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *byteSliceUnfitForVariableLengthFormatError) Error() (s string) {
+	const (
+		format = "" +
+			"A byte slice into which a variable-length format-struct " +
+			"would be unmarshalled should hold each of its words in turn " +
+			"and nothing more. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"that the byte slice does not fit: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.cause,
+	)
+
+	return
+}
+
+func (e *byteSliceUnfitForVariableLengthFormatError) Unwrap() error {
+	return e.cause
+}
+
+type formatOfVariableLengthError struct {
+	DefaultFormatError
+}
+
+func NewFormatOfVariableLengthError() *formatOfVariableLengthError {
+	return new(formatOfVariableLengthError)
+}
+
+func (e *formatOfVariableLengthError) Error() string {
+	const (
+		format = "" +
+			"Bit fields can be read and written in place only in formats " +
+			"of fixed length. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"that has a word holding a variable-length integer or a bit stream."
+	)
+
+	return fmt.Sprintf(format, e.functionName, e.formatName)
+}
+
+type generatedMethodFailedError struct {
+	DefaultFormatError
+	cause error
+}
+
+func NewGeneratedMethodFailedError(cause error) (
+	e *generatedMethodFailedError,
+) {
+	e = &generatedMethodFailedError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *generatedMethodFailedError) Error() (s string) {
+	const (
+		format = "" +
+			"Methods generated by binary-gen should marshal and unmarshal " +
+			"format-structs as reflection would. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"whose generated method failed: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.cause,
+	)
+
+	return
+}
+
+func (e *generatedMethodFailedError) Unwrap() error {
+	return e.cause
+}
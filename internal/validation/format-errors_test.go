@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,9 +57,146 @@ func TestLengthOfByteSliceNotEqualToFormatLengthError(t *testing.T) {
 		byteSliceLength,
 	)
 
+	e.SetFunctionName("Unmarshal")
+
+	e.SetFormatName(formatName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestValueForUnknownBitFieldError(t *testing.T) {
+	const (
+		bitFieldName = "BitField"
+
+		errorMessage = "" +
+			"Values should be given only for bit fields in a format. " +
+			"Argument to Marshal gives a value for a bit field \"BitField\" " +
+			"not in format \"Format\"."
+	)
+
+	var (
+		e FormatError
+	)
+
+	e = NewValueForUnknownBitFieldError(bitFieldName)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestUnknownBitFieldError(t *testing.T) {
+	const (
+		bitFieldName = "Word.BitField"
+
+		errorMessage = "" +
+			"Bit fields should be named as in their format, " +
+			"optionally qualified by the names of their words. " +
+			"Argument to Marshal names a bit field \"Word.BitField\" " +
+			"not in format \"Format\"."
+	)
+
+	var (
+		e FormatError
+	)
+
+	e = NewUnknownBitFieldError(bitFieldName)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestByteSliceUnfitForVariableLengthFormatError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A byte slice into which a variable-length format-struct " +
+			"would be unmarshalled should hold each of its words in turn " +
+			"and nothing more. " +
+			"Argument to Unmarshal points to a format-struct \"Format\" " +
+			"that the byte slice does not fit: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     FormatError
+	)
+
+	e = NewByteSliceUnfitForVariableLengthFormatError(cause)
+
+	e.SetFunctionName("Unmarshal")
+
 	e.SetFormatName(formatName)
 
 	assert.Equal(t,
 		errorMessage, e.Error(),
 	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
+
+func TestFormatOfVariableLengthError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"Bit fields can be read and written in place only in formats " +
+			"of fixed length. " +
+			"Argument to ViewOf points to a format-struct \"Format\" " +
+			"that has a word holding a variable-length integer " +
+			"or a bit stream."
+	)
+
+	var (
+		e FormatError
+	)
+
+	e = NewFormatOfVariableLengthError()
+
+	e.SetFunctionName("ViewOf")
+
+	e.SetFormatName(formatName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestGeneratedMethodFailedError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"Methods generated by binary-gen should marshal and unmarshal " +
+			"format-structs as reflection would. " +
+			"Argument to Unmarshal points to a format-struct \"Format\" " +
+			"whose generated method failed: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     FormatError
+	)
+
+	e = NewGeneratedMethodFailedError(cause)
+
+	e.SetFunctionName("Unmarshal")
+
+	e.SetFormatName(formatName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
 }
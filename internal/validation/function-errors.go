@@ -54,3 +54,125 @@ func (e *pointerToNonStructVariableError) Error() string {
 
 	return fmt.Sprintf(format, e.functionName)
 }
+
+type nilPointerError struct {
+	DefaultFunctionError
+}
+
+func NewNilPointerError() *nilPointerError {
+	return new(nilPointerError)
+}
+
+func (e *nilPointerError) Error() string {
+	const (
+		format = "" +
+			"Argument to %[1]s should be a pointer to a format-struct. " +
+			"Argument to %[1]s is a nil pointer."
+	)
+
+	return fmt.Sprintf(format, e.functionName)
+}
+
+type typeParameterNotStructError struct {
+	DefaultFunctionError
+	typeName string
+}
+
+func NewTypeParameterNotStructError(typeName string) (
+	e *typeParameterNotStructError,
+) {
+	e = &typeParameterNotStructError{
+		typeName: typeName,
+	}
+
+	return
+}
+
+func (e *typeParameterNotStructError) Error() string {
+	const (
+		format = "" +
+			"Type parameter to %[1]s should be a format-struct. " +
+			"Type parameter \"%[2]s\" to %[1]s is not a struct."
+	)
+
+	return fmt.Sprintf(format, e.functionName, e.typeName)
+}
+
+type typeRegisteredAfterUseError struct {
+	DefaultFunctionError
+	typeName string
+}
+
+func NewTypeRegisteredAfterUseError(typeName string) (
+	e *typeRegisteredAfterUseError,
+) {
+	e = &typeRegisteredAfterUseError{
+		typeName: typeName,
+	}
+
+	return
+}
+
+func (e *typeRegisteredAfterUseError) Error() string {
+	const (
+		format = "" +
+			"Type parameter to %[1]s should be registered " +
+			"before first use of the type (e.g. in func init). " +
+			"Type parameter \"%[2]s\" to %[1]s is used already."
+	)
+
+	return fmt.Sprintf(format, e.functionName, e.typeName)
+}
+
+type pointersToDifferentTypesError struct {
+	DefaultFunctionError
+	typeName0 string
+	typeName1 string
+}
+
+func NewPointersToDifferentTypesError(typeName0, typeName1 string) (
+	e *pointersToDifferentTypesError,
+) {
+	e = &pointersToDifferentTypesError{
+		typeName0: typeName0,
+		typeName1: typeName1,
+	}
+
+	return
+}
+
+func (e *pointersToDifferentTypesError) Error() string {
+	const (
+		format = "" +
+			"Arguments to %[1]s should be pointers to format-structs " +
+			"of the same type. " +
+			"Arguments to %[1]s point to variables of different types " +
+			"\"%[2]s\" and \"%[3]s\"."
+	)
+
+	return fmt.Sprintf(format, e.functionName, e.typeName0, e.typeName1)
+}
+
+type malformedSchemaError struct {
+	DefaultFunctionError
+	reason string
+}
+
+func NewMalformedSchemaError(reason string) (e *malformedSchemaError) {
+	e = &malformedSchemaError{
+		reason: reason,
+	}
+
+	return
+}
+
+func (e *malformedSchemaError) Error() string {
+	const (
+		format = "" +
+			"Argument to %[1]s should be a schema of formats " +
+			"in YAML or JSON. " +
+			"Argument to %[1]s is malformed: %[2]s."
+	)
+
+	return fmt.Sprintf(format, e.functionName, e.reason)
+}
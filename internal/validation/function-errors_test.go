@@ -49,3 +49,118 @@ func TestPointerToNonStructVariableError(t *testing.T) {
 		errorMessage, e.Error(),
 	)
 }
+
+func TestNilPointerError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"Argument to Marshal should be a pointer to a format-struct. " +
+			"Argument to Marshal is a nil pointer."
+	)
+
+	var (
+		e FunctionError
+	)
+
+	e = NewNilPointerError()
+
+	e.SetFunctionName(functionName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestTypeParameterNotStructError(t *testing.T) {
+	const (
+		typeName = "map[string]int"
+
+		errorMessage = "" +
+			"Type parameter to Marshal should be a format-struct. " +
+			"Type parameter \"map[string]int\" to Marshal is not a struct."
+	)
+
+	var (
+		e FunctionError
+	)
+
+	e = NewTypeParameterNotStructError(typeName)
+
+	e.SetFunctionName(functionName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestTypeRegisteredAfterUseError(t *testing.T) {
+	const (
+		typeName = "rfc791.Protocol"
+
+		errorMessage = "" +
+			"Type parameter to Marshal should be registered " +
+			"before first use of the type (e.g. in func init). " +
+			"Type parameter \"rfc791.Protocol\" to Marshal is used already."
+	)
+
+	var (
+		e FunctionError
+	)
+
+	e = NewTypeRegisteredAfterUseError(typeName)
+
+	e.SetFunctionName(functionName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestPointersToDifferentTypesError(t *testing.T) {
+	const (
+		typeName0 = "rfc791.RFC791InternetHeaderFormatWithoutOptions"
+		typeName1 = "v1p1.RFC791InternetHeaderFormatWithoutOptions"
+
+		errorMessage = "" +
+			"Arguments to Marshal should be pointers to format-structs " +
+			"of the same type. " +
+			"Arguments to Marshal point to variables of different types " +
+			"\"rfc791.RFC791InternetHeaderFormatWithoutOptions\" and " +
+			"\"v1p1.RFC791InternetHeaderFormatWithoutOptions\"."
+	)
+
+	var (
+		e FunctionError
+	)
+
+	e = NewPointersToDifferentTypesError(typeName0, typeName1)
+
+	e.SetFunctionName(functionName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestMalformedSchemaError(t *testing.T) {
+	const (
+		reason = "unknown byte order \"middle\""
+
+		errorMessage = "" +
+			"Argument to Marshal should be a schema of formats " +
+			"in YAML or JSON. " +
+			"Argument to Marshal is malformed: " +
+			"unknown byte order \"middle\"."
+	)
+
+	var (
+		e FunctionError
+	)
+
+	e = NewMalformedSchemaError(reason)
+
+	e.SetFunctionName(functionName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
@@ -177,3 +177,61 @@ func (e *wordWithNoStructTagError) Error() string {
 
 	return fmt.Sprintf(format, e.functionName, e.formatName, e.wordName)
 }
+
+type wordWithInvalidNameError struct {
+	DefaultWordError
+}
+
+func NewWordWithInvalidNameError() *wordWithInvalidNameError {
+	return new(wordWithInvalidNameError)
+}
+
+func (e *wordWithInvalidNameError) Error() string {
+	const (
+		format = "" +
+			"A word should be named by an exported identifier " +
+			"unique in its format. " +
+			"Argument to %s points to a format \"%s\" " +
+			"nesting a word \"%s\" " +
+			"whose name is not a unique exported identifier."
+	)
+
+	return fmt.Sprintf(format, e.functionName, e.formatName, e.wordName)
+}
+
+type wordMarshalerFailedError struct {
+	DefaultWordError
+	cause error
+}
+
+func NewWordMarshalerFailedError(cause error) (
+	e *wordMarshalerFailedError,
+) {
+	e = &wordMarshalerFailedError{
+		cause: cause,
+	}
+
+	return
+}
+
+func (e *wordMarshalerFailedError) Error() (s string) {
+	const (
+		format = "" +
+			"A word implementing encoding.BinaryMarshaler " +
+			"and encoding.BinaryUnmarshaler should convert " +
+			"to and from as many bytes as its length. " +
+			"Argument to %s points to a format-struct \"%s\" " +
+			"nesting a word-struct \"%s\" " +
+			"that failed: %v."
+	)
+
+	s = fmt.Sprintf(format,
+		e.functionName, e.formatName, e.wordName, e.cause,
+	)
+
+	return
+}
+
+func (e *wordMarshalerFailedError) Unwrap() error {
+	return e.cause
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -182,3 +183,63 @@ func TestWordWithNoStructTagError(t *testing.T) {
 		errorMessage, e.Error(),
 	)
 }
+
+func TestWordWithInvalidNameError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A word should be named by an exported identifier " +
+			"unique in its format. " +
+			"Argument to Marshal points to a format \"Format\" " +
+			"nesting a word \"Word\" " +
+			"whose name is not a unique exported identifier."
+	)
+
+	var (
+		e WordError
+	)
+
+	e = NewWordWithInvalidNameError()
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestWordMarshalerFailedError(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"A word implementing encoding.BinaryMarshaler " +
+			"and encoding.BinaryUnmarshaler should convert " +
+			"to and from as many bytes as its length. " +
+			"Argument to Marshal points to a format-struct \"Format\" " +
+			"nesting a word-struct \"Word\" " +
+			"that failed: cause."
+	)
+
+	var (
+		cause = errors.New("cause")
+		e     WordError
+	)
+
+	e = NewWordMarshalerFailedError(cause)
+
+	e.SetFunctionName(functionName)
+
+	e.SetFormatName(formatName)
+
+	e.SetWordName(wordName)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.ErrorIs(t,
+		e, cause,
+	)
+}
@@ -0,0 +1,3 @@
+package rfc791
+
+//go:generate go run github.com/encodingx/binary/cmd/binary-gen -type RFC791InternetHeaderFormatWithoutOptions
@@ -44,7 +44,7 @@ type RFC791InternetHeaderFormatWord0 struct {
 	// >   The Version field indicates the format of the internet header.  This
 	// >   document describes version 4.
 
-	IHL uint8 `bitfield:"4"`
+	IHL uint8 `bitfield:"4,min=5"`
 	// > IHL:  4 bits
 	// >
 	// >   Internet Header Length is the length of the internet header in 32
@@ -55,7 +55,7 @@ type RFC791InternetHeaderFormatWord0 struct {
 	Delay       bool  `bitfield:"1"`
 	Throughput  bool  `bitfield:"1"`
 	Reliability bool  `bitfield:"1"`
-	Reserved    uint8 `bitfield:"2"`
+	Reserved    uint8 `bitfield:"2,reserved"`
 	// > Type of Service:  8 bits
 	// >
 	// >   The Type of Service provides an indication of the abstract
@@ -143,7 +143,7 @@ type RFC791InternetHeaderFormatWord1 struct {
 	// >   An identifying value assigned by the sender to aid in assembling the
 	// >   fragments of a datagram.
 
-	FlagsBit0Reserved bool `bitfield:"1"`
+	FlagsBit0Reserved bool `bitfield:"1,reserved"`
 	FlagsBit1         bool `bitfield:"1"`
 	FlagsBit2         bool `bitfield:"1"`
 	// > Flags:  3 bits
@@ -0,0 +1,144 @@
+// Code generated by binary-gen; DO NOT EDIT.
+
+package rfc791
+
+import (
+	"fmt"
+)
+
+func (f *RFC791InternetHeaderFormatWithoutOptions) BinaryFormatLength() int {
+	return 20
+}
+
+func (f *RFC791InternetHeaderFormatWithoutOptions) MarshalBinary() ([]byte, error) {
+	return f.AppendBinary(
+		make([]byte, 0, 20),
+	)
+}
+
+func (f *RFC791InternetHeaderFormatWithoutOptions) AppendBinary(bytes []byte) ([]byte, error) {
+	var (
+		word uint64
+	)
+
+	word = 0
+	word |= uint64(f.RFC791InternetHeaderFormatWord0.Version) & 0xf << 28
+	word |= uint64(f.RFC791InternetHeaderFormatWord0.IHL) & 0xf << 24
+	word |= uint64(f.RFC791InternetHeaderFormatWord0.Precedence) & 0x7 << 21
+
+	if f.RFC791InternetHeaderFormatWord0.Delay {
+		word |= 1 << 20
+	}
+
+	if f.RFC791InternetHeaderFormatWord0.Throughput {
+		word |= 1 << 19
+	}
+
+	if f.RFC791InternetHeaderFormatWord0.Reliability {
+		word |= 1 << 18
+	}
+
+	word |= uint64(f.RFC791InternetHeaderFormatWord0.Reserved) & 0x3 << 16
+	word |= uint64(f.RFC791InternetHeaderFormatWord0.TotalLength) & 0xffff << 0
+
+	bytes = append(bytes, byte(word>>24), byte(word>>16), byte(word>>8), byte(word>>0))
+
+	word = 0
+	word |= uint64(f.RFC791InternetHeaderFormatWord1.Identification) & 0xffff << 16
+
+	if f.RFC791InternetHeaderFormatWord1.FlagsBit0Reserved {
+		word |= 1 << 15
+	}
+
+	if f.RFC791InternetHeaderFormatWord1.FlagsBit1 {
+		word |= 1 << 14
+	}
+
+	if f.RFC791InternetHeaderFormatWord1.FlagsBit2 {
+		word |= 1 << 13
+	}
+
+	word |= uint64(f.RFC791InternetHeaderFormatWord1.FragmentOffset) & 0x1fff << 0
+
+	bytes = append(bytes, byte(word>>24), byte(word>>16), byte(word>>8), byte(word>>0))
+
+	word = 0
+	word |= uint64(f.RFC791InternetHeaderFormatWord2.TimeToLive) & 0xff << 24
+	word |= uint64(f.RFC791InternetHeaderFormatWord2.Protocol) & 0xff << 16
+	word |= uint64(f.RFC791InternetHeaderFormatWord2.HeaderChecksum) & 0xffff << 0
+
+	bytes = append(bytes, byte(word>>24), byte(word>>16), byte(word>>8), byte(word>>0))
+
+	word = 0
+	word |= uint64(f.RFC791InternetHeaderFormatWord3.SourceAddressOctet0) & 0xff << 24
+	word |= uint64(f.RFC791InternetHeaderFormatWord3.SourceAddressOctet1) & 0xff << 16
+	word |= uint64(f.RFC791InternetHeaderFormatWord3.SourceAddressOctet2) & 0xff << 8
+	word |= uint64(f.RFC791InternetHeaderFormatWord3.SourceAddressOctet3) & 0xff << 0
+
+	bytes = append(bytes, byte(word>>24), byte(word>>16), byte(word>>8), byte(word>>0))
+
+	word = 0
+	word |= uint64(f.RFC791InternetHeaderFormatWord4.DestinationAddressOctet0) & 0xff << 24
+	word |= uint64(f.RFC791InternetHeaderFormatWord4.DestinationAddressOctet1) & 0xff << 16
+	word |= uint64(f.RFC791InternetHeaderFormatWord4.DestinationAddressOctet2) & 0xff << 8
+	word |= uint64(f.RFC791InternetHeaderFormatWord4.DestinationAddressOctet3) & 0xff << 0
+
+	bytes = append(bytes, byte(word>>24), byte(word>>16), byte(word>>8), byte(word>>0))
+
+	return bytes, nil
+}
+
+func (f *RFC791InternetHeaderFormatWithoutOptions) UnmarshalBinary(bytes []byte) error {
+	var (
+		word uint64
+	)
+
+	if len(bytes) != 20 {
+		return fmt.Errorf("UnmarshalBinary error: "+
+			"length of byte slice, %d byte(s), "+
+			"not equal to length of format RFC791InternetHeaderFormatWithoutOptions, 20 byte(s)",
+			len(bytes),
+		)
+	}
+
+	word = uint64(bytes[0])<<24 | uint64(bytes[1])<<16 | uint64(bytes[2])<<8 | uint64(bytes[3])<<0
+
+	f.RFC791InternetHeaderFormatWord0.Version = uint8(word >> 28 & 0xf)
+	f.RFC791InternetHeaderFormatWord0.IHL = uint8(word >> 24 & 0xf)
+	f.RFC791InternetHeaderFormatWord0.Precedence = uint8(word >> 21 & 0x7)
+	f.RFC791InternetHeaderFormatWord0.Delay = word>>20&1 == 1
+	f.RFC791InternetHeaderFormatWord0.Throughput = word>>19&1 == 1
+	f.RFC791InternetHeaderFormatWord0.Reliability = word>>18&1 == 1
+	f.RFC791InternetHeaderFormatWord0.Reserved = uint8(word >> 16 & 0x3)
+	f.RFC791InternetHeaderFormatWord0.TotalLength = uint16(word >> 0 & 0xffff)
+
+	word = uint64(bytes[4])<<24 | uint64(bytes[5])<<16 | uint64(bytes[6])<<8 | uint64(bytes[7])<<0
+
+	f.RFC791InternetHeaderFormatWord1.Identification = uint16(word >> 16 & 0xffff)
+	f.RFC791InternetHeaderFormatWord1.FlagsBit0Reserved = word>>15&1 == 1
+	f.RFC791InternetHeaderFormatWord1.FlagsBit1 = word>>14&1 == 1
+	f.RFC791InternetHeaderFormatWord1.FlagsBit2 = word>>13&1 == 1
+	f.RFC791InternetHeaderFormatWord1.FragmentOffset = uint16(word >> 0 & 0x1fff)
+
+	word = uint64(bytes[8])<<24 | uint64(bytes[9])<<16 | uint64(bytes[10])<<8 | uint64(bytes[11])<<0
+
+	f.RFC791InternetHeaderFormatWord2.TimeToLive = uint8(word >> 24 & 0xff)
+	f.RFC791InternetHeaderFormatWord2.Protocol = uint8(word >> 16 & 0xff)
+	f.RFC791InternetHeaderFormatWord2.HeaderChecksum = uint16(word >> 0 & 0xffff)
+
+	word = uint64(bytes[12])<<24 | uint64(bytes[13])<<16 | uint64(bytes[14])<<8 | uint64(bytes[15])<<0
+
+	f.RFC791InternetHeaderFormatWord3.SourceAddressOctet0 = uint8(word >> 24 & 0xff)
+	f.RFC791InternetHeaderFormatWord3.SourceAddressOctet1 = uint8(word >> 16 & 0xff)
+	f.RFC791InternetHeaderFormatWord3.SourceAddressOctet2 = uint8(word >> 8 & 0xff)
+	f.RFC791InternetHeaderFormatWord3.SourceAddressOctet3 = uint8(word >> 0 & 0xff)
+
+	word = uint64(bytes[16])<<24 | uint64(bytes[17])<<16 | uint64(bytes[18])<<8 | uint64(bytes[19])<<0
+
+	f.RFC791InternetHeaderFormatWord4.DestinationAddressOctet0 = uint8(word >> 24 & 0xff)
+	f.RFC791InternetHeaderFormatWord4.DestinationAddressOctet1 = uint8(word >> 16 & 0xff)
+	f.RFC791InternetHeaderFormatWord4.DestinationAddressOctet2 = uint8(word >> 8 & 0xff)
+	f.RFC791InternetHeaderFormatWord4.DestinationAddressOctet3 = uint8(word >> 0 & 0xff)
+
+	return nil
+}
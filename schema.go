@@ -0,0 +1,259 @@
+package binary
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/encodingx/binary/internal/validation"
+)
+
+// Schemas describe formats in YAML, or in JSON as a subset of YAML,
+// for those who own message definitions but do not write Go, e.g.
+//
+//	formats:
+//	  - name: Telemetry
+//	    words:
+//	      - length: 16
+//	        byteorder: little
+//	        bitorder: msb
+//	        bitfields:
+//	          - {name: Version, length: 4, min: 1}
+//	          - {name: Urgent, length: 1}
+//	          - {name: Reserved, length: 3, reserved: true}
+//	          - name: Kind
+//	            length: 8
+//	            enum:
+//	              - {name: Reading, value: 1}
+//	              - {name: Alarm, value: 2}
+//
+// Words without names are named after their formats and positions,
+// and bit fields without types are given the smallest types that fit.
+
+type schema struct {
+	Formats []schemaFormat `yaml:"formats"`
+}
+
+type schemaFormat struct {
+	Name  string       `yaml:"name"`
+	Words []schemaWord `yaml:"words"`
+}
+
+type schemaWord struct {
+	Name      string           `yaml:"name"`
+	Length    uint             `yaml:"length"`
+	ByteOrder string           `yaml:"byteorder"`
+	BitOrder  string           `yaml:"bitorder"`
+	Options   []string         `yaml:"options"`
+	BitFields []schemaBitField `yaml:"bitfields"`
+}
+
+type schemaBitField struct {
+	Name        string                  `yaml:"name"`
+	Length      uint                    `yaml:"length"`
+	Type        string                  `yaml:"type"`
+	Reserved    bool                    `yaml:"reserved"`
+	Minimum     *uint64                 `yaml:"min"`
+	Maximum     *uint64                 `yaml:"max"`
+	Enumeration []schemaEnumeratedValue `yaml:"enum"`
+	Options     []string                `yaml:"options"`
+}
+
+type schemaEnumeratedValue struct {
+	Name  string `yaml:"name"`
+	Value uint64 `yaml:"value"`
+}
+
+func ParseSchema(schemaBytes []byte) (definitions []FormatDefinition, e error) {
+	const (
+		functionName = "ParseSchema"
+	)
+
+	var (
+		decoder    *yaml.Decoder
+		definition FormatDefinition
+		format     schemaFormat
+		s          schema
+	)
+
+	defer func() {
+		const (
+			parseSchemaError = "ParseSchema error: %w"
+		)
+
+		if e != nil {
+			definitions = nil
+
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(parseSchemaError, e)
+		}
+
+		return
+	}()
+
+	decoder = yaml.NewDecoder(
+		bytes.NewReader(schemaBytes),
+	)
+
+	decoder.KnownFields(true)
+
+	e = decoder.Decode(&s)
+	if e != nil {
+		e = validation.NewMalformedSchemaError(
+			e.Error(),
+		)
+
+		return
+	}
+
+	if len(s.Formats) == 0 {
+		e = validation.NewMalformedSchemaError("no formats")
+
+		return
+	}
+
+	for _, format = range s.Formats {
+		definition, e = format.definition()
+		if e != nil {
+			return
+		}
+
+		_, _, e = definition.formatMetadata()
+		if e != nil {
+			return
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	return
+}
+
+func (s schemaFormat) definition() (definition FormatDefinition, e error) {
+	var (
+		bitField schemaBitField
+		i        int
+		word     schemaWord
+	)
+
+	definition = FormatDefinition{
+		Name: s.Name,
+		Words: make([]WordDefinition,
+			len(s.Words),
+		),
+	}
+
+	for i, word = range s.Words {
+		definition.Words[i] = WordDefinition{
+			Name:    word.Name,
+			Length:  word.Length,
+			Options: word.Options,
+		}
+
+		if word.Name == "" {
+			definition.Words[i].Name = fmt.Sprintf("%sWord%d", s.Name, i)
+		}
+
+		switch word.ByteOrder {
+		case "":
+			// Big-endian by default
+
+		case "big", "little":
+			definition.Words[i].Options = append(
+				definition.Words[i].Options,
+				word.ByteOrder+"endian",
+			)
+
+		default:
+			e = validation.NewMalformedSchemaError(
+				fmt.Sprintf("word %q of format %q has unknown byte order %q",
+					definition.Words[i].Name, s.Name, word.ByteOrder,
+				),
+			)
+
+			return
+		}
+
+		switch word.BitOrder {
+		case "":
+			// Most significant bit first by default
+
+		case "msb", "lsb":
+			definition.Words[i].Options = append(
+				definition.Words[i].Options,
+				word.BitOrder+"first",
+			)
+
+		default:
+			e = validation.NewMalformedSchemaError(
+				fmt.Sprintf("word %q of format %q has unknown bit order %q",
+					definition.Words[i].Name, s.Name, word.BitOrder,
+				),
+			)
+
+			return
+		}
+
+		for _, bitField = range word.BitFields {
+			definition.Words[i].BitFields = append(
+				definition.Words[i].BitFields,
+				bitField.definition(),
+			)
+		}
+	}
+
+	return
+}
+
+func (s schemaBitField) definition() (definition BitFieldDefinition) {
+	// Translate constraints into the options of struct tags.
+
+	var (
+		enumerated  schemaEnumeratedValue
+		enumeration []string
+	)
+
+	definition = BitFieldDefinition{
+		Name:    s.Name,
+		Length:  s.Length,
+		Type:    s.Type,
+		Options: s.Options,
+	}
+
+	if definition.Type == "" {
+		definition.Type = DefaultBitFieldType(s.Length)
+	}
+
+	if s.Reserved {
+		definition.Options = append(definition.Options, "reserved")
+	}
+
+	if s.Minimum != nil {
+		definition.Options = append(definition.Options,
+			fmt.Sprintf("min=%d", *s.Minimum),
+		)
+	}
+
+	if s.Maximum != nil {
+		definition.Options = append(definition.Options,
+			fmt.Sprintf("max=%d", *s.Maximum),
+		)
+	}
+
+	for _, enumerated = range s.Enumeration {
+		enumeration = append(enumeration,
+			fmt.Sprintf("%s:%d", enumerated.Name, enumerated.Value),
+		)
+	}
+
+	if len(enumeration) > 0 {
+		definition.Options = append(definition.Options,
+			"enum="+strings.Join(enumeration, "|"),
+		)
+	}
+
+	return
+}
@@ -0,0 +1,208 @@
+package binary
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	telemetrySchema = "" +
+		"formats:\n" +
+		"  - name: Telemetry\n" +
+		"    words:\n" +
+		"      - length: 16\n" +
+		"        byteorder: little\n" +
+		"        bitfields:\n" +
+		"          - {name: Version, length: 4, min: 1, max: 9}\n" +
+		"          - {name: Urgent, length: 1}\n" +
+		"          - {name: Reserved, length: 3, reserved: true}\n" +
+		"          - name: Kind\n" +
+		"            length: 8\n" +
+		"            enum:\n" +
+		"              - {name: Reading, value: 1}\n" +
+		"              - {name: Alarm, value: 2}\n" +
+		"      - name: Counters\n" +
+		"        length: 32\n" +
+		"        bitorder: lsb\n" +
+		"        bitfields:\n" +
+		"          - {name: Sequence, length: 32, type: uint64}\n"
+)
+
+func TestParseSchema(t *testing.T) {
+	var (
+		definitions []FormatDefinition
+		e           error
+	)
+
+	definitions, e = ParseSchema(
+		[]byte(telemetrySchema),
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]FormatDefinition{
+			{
+				Name: "Telemetry",
+				Words: []WordDefinition{
+					{
+						Name:    "TelemetryWord0",
+						Length:  16,
+						Options: []string{"littleendian"},
+						BitFields: []BitFieldDefinition{
+							{"Version", 4, "uint8",
+								[]string{"min=1", "max=9"},
+							},
+							{"Urgent", 1, "bool", nil},
+							{"Reserved", 3, "uint8",
+								[]string{"reserved"},
+							},
+							{"Kind", 8, "uint8",
+								[]string{"enum=Reading:1|Alarm:2"},
+							},
+						},
+					},
+					{
+						Name:    "Counters",
+						Length:  32,
+						Options: []string{"lsbfirst"},
+						BitFields: []BitFieldDefinition{
+							{"Sequence", 32, "uint64", nil},
+						},
+					},
+				},
+			},
+		},
+		definitions,
+	)
+}
+
+func TestParseSchemaJSON(t *testing.T) {
+	const (
+		schema = `{"formats": [{"name": "Flags", "words": [` +
+			`{"length": 8, "bitfields": [{"name": "Mask", "length": 8}]}` +
+			`]}]}`
+	)
+
+	var (
+		definitions []FormatDefinition
+		e           error
+	)
+
+	definitions, e = ParseSchema(
+		[]byte(schema),
+	)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]FormatDefinition{
+			{
+				Name: "Flags",
+				Words: []WordDefinition{
+					{
+						Name:   "FlagsWord0",
+						Length: 8,
+						BitFields: []BitFieldDefinition{
+							{"Mask", 8, "uint8", nil},
+						},
+					},
+				},
+			},
+		},
+		definitions,
+	)
+}
+
+func TestParseSchemaShouldReturnErrorGivenMalformedSchema(t *testing.T) {
+	const (
+		errorMessage = "ParseSchema error: " +
+			"Argument to ParseSchema should be a schema of formats " +
+			"in YAML or JSON. " +
+			"Argument to ParseSchema is malformed: %s."
+	)
+
+	var (
+		e error
+	)
+
+	_, e = ParseSchema(
+		[]byte("formats: []\n"),
+	)
+
+	assert.EqualError(t,
+		e, fmt.Sprintf(errorMessage, "no formats"),
+	)
+
+	_, e = ParseSchema(
+		[]byte("formats:\n" +
+			"  - name: Flags\n" +
+			"    words:\n" +
+			"      - {length: 8, byteorder: middle}\n",
+		),
+	)
+
+	assert.EqualError(t,
+		e, fmt.Sprintf(errorMessage,
+			"word \"FlagsWord0\" of format \"Flags\" "+
+				"has unknown byte order \"middle\"",
+		),
+	)
+
+	_, e = ParseSchema(
+		[]byte("formats:\n" +
+			"  - name: Flags\n" +
+			"    words:\n" +
+			"      - {length: 8, bitorder: middle}\n",
+		),
+	)
+
+	assert.EqualError(t,
+		e, fmt.Sprintf(errorMessage,
+			"word \"FlagsWord0\" of format \"Flags\" "+
+				"has unknown bit order \"middle\"",
+		),
+	)
+
+	_, e = ParseSchema(
+		[]byte("formats:\n" +
+			"  - name: Flags\n" +
+			"    colour: blue\n",
+		),
+	)
+
+	assert.Contains(t,
+		e.Error(), "field colour not found",
+	)
+}
+
+func TestParseSchemaShouldReturnErrorGivenInvalidFormat(t *testing.T) {
+	const (
+		errorMessage = "ParseSchema error: " +
+			"The length of a word should be equal " +
+			"to the sum of lengths of its bit fields. " +
+			"Argument to ParseSchema points to a format-struct \"Flags\" " +
+			"that has a word \"FlagsWord0\" " +
+			"of length 8 " +
+			"not equal to the sum of the lengths of its bit fields, 4."
+	)
+
+	var (
+		e error
+	)
+
+	_, e = ParseSchema(
+		[]byte("formats:\n" +
+			"  - name: Flags\n" +
+			"    words:\n" +
+			"      - length: 8\n" +
+			"        bitfields: [{name: Mask, length: 4}]\n",
+		),
+	)
+
+	assert.EqualError(t,
+		e, errorMessage,
+	)
+}
@@ -0,0 +1,409 @@
+package binary
+
+import (
+	"fmt"
+
+	"github.com/encodingx/binary/internal/codecs/metadata"
+	"github.com/encodingx/binary/internal/validation"
+)
+
+type View[T any] struct {
+	format metadata.FormatMetadata
+	bytes  []byte
+}
+
+func ViewOf[T any](bytes []byte) (view View[T], e error) {
+	// Read and write bit fields of a format-struct of type T
+	// in place in a byte slice, without unmarshalling it.
+
+	const (
+		functionName = "ViewOf"
+	)
+
+	var (
+		format metadata.FormatMetadata
+	)
+
+	defer func() {
+		const (
+			viewOfError = "ViewOf error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(viewOfError, e)
+		}
+
+		return
+	}()
+
+	format, e = defaultCodec.FormatMetadata((*T)(nil))
+	if e != nil {
+		return
+	}
+
+	view, e = newView[T](format, bytes)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func (f Format[T]) View(bytes []byte) (view View[T], e error) {
+	const (
+		functionName = "View"
+	)
+
+	defer func() {
+		const (
+			viewError = "View error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(viewError, e)
+		}
+
+		return
+	}()
+
+	view, e = newView[T](f.format, bytes)
+	if e != nil {
+		return
+	}
+
+	return
+}
+
+func newView[T any](format metadata.FormatMetadata, bytes []byte) (
+	view View[T], e error,
+) {
+	// Bit fields are found at fixed offsets,
+	// which formats holding variable-length integers or bit streams
+	// do not have.
+
+	if format.Variable() {
+		e = validation.NewFormatOfVariableLengthError()
+
+		e.(validation.FormatError).SetFormatName(
+			format.Name(),
+		)
+
+		return
+	}
+
+	if len(bytes) != format.LengthInBytes() {
+		e = validation.NewLengthOfByteSliceNotEqualToFormatLengthError(
+			uint(format.LengthInBytes()),
+			uint(len(bytes)),
+		)
+
+		e.(validation.FormatError).SetFormatName(
+			format.Name(),
+		)
+
+		return
+	}
+
+	view = View[T]{
+		format: format,
+		bytes:  bytes,
+	}
+
+	return
+}
+
+func (v View[T]) Get(name string) (value uint64, e error) {
+	// Read a bit field, named as in its format-struct
+	// or qualified by the name of its word (e.g. "Word.BitField"),
+	// decoding binary-coded decimal and Gray code as when unmarshalled.
+
+	const (
+		functionName = "Get"
+	)
+
+	var (
+		bitField metadata.BitFieldMetadata
+		ok       bool
+		word     metadata.WordMetadata
+	)
+
+	defer func() {
+		const (
+			getError = "Get error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(getError, e)
+		}
+
+		return
+	}()
+
+	word, bitField, e = v.bitField(name)
+	if e != nil {
+		return
+	}
+
+	// Bit fields longer than 64 bits (e.g. IPv6 addresses)
+	// do not fit in a uint64.
+
+	if bitField.Signed() != "" || bitField.Length() > 64 {
+		e = validation.NewBitFieldValueOfIncompatibleTypeError(
+			bitField.Type().String(), "of type uint64",
+		)
+
+		v.setBitFieldErrorNames(e, word, bitField)
+
+		return
+	}
+
+	value = bitField.Uint64(
+		word.Uint64(v.bytes),
+	)
+
+	value, ok = bitField.Decode(value)
+	if !ok {
+		e = validation.NewBitFieldInvalidBCDError(
+			bitField.Uint64(
+				word.Uint64(v.bytes),
+			),
+		)
+
+		v.setBitFieldErrorNames(e, word, bitField)
+
+		return
+	}
+
+	return
+}
+
+func (v View[T]) Set(name string, value uint64) (e error) {
+	// Write a bit field, leaving every other bit as it was,
+	// encoding binary-coded decimal and Gray code as when marshalled.
+
+	const (
+		functionName = "Set"
+	)
+
+	var (
+		bitField metadata.BitFieldMetadata
+		word     metadata.WordMetadata
+	)
+
+	defer func() {
+		const (
+			setError = "Set error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(setError, e)
+		}
+
+		return
+	}()
+
+	word, bitField, e = v.bitField(name)
+	if e != nil {
+		return
+	}
+
+	// Bit fields longer than 64 bits (e.g. IPv6 addresses)
+	// do not fit in a uint64.
+
+	if bitField.Signed() != "" || bitField.Length() > 64 {
+		e = validation.NewBitFieldValueOfIncompatibleTypeError(
+			bitField.Type().String(), "of type uint64",
+		)
+
+		v.setBitFieldErrorNames(e, word, bitField)
+
+		return
+	}
+
+	if bitField.Length() < 64 && value>>bitField.Length() != 0 {
+		e = validation.NewBitFieldValueOfIncompatibleTypeError(
+			bitField.Type().String(),
+			fmt.Sprintf("%d overflowing %d bit(s)", value, bitField.Length()),
+		)
+
+		v.setBitFieldErrorNames(e, word, bitField)
+
+		return
+	}
+
+	value, e = bitField.Encode(value)
+	if e != nil {
+		v.setBitFieldErrorNames(e, word, bitField)
+
+		return
+	}
+
+	word.PutUint64(v.bytes,
+		bitField.PutUint64(
+			word.Uint64(v.bytes), value,
+		),
+	)
+
+	return
+}
+
+func (v View[T]) GetInt(name string) (number int64, e error) {
+	// Read a bit field holding a signed integer,
+	// decoding its encoding as when unmarshalled.
+
+	const (
+		functionName = "GetInt"
+	)
+
+	var (
+		bitField metadata.BitFieldMetadata
+		ok       bool
+		word     metadata.WordMetadata
+	)
+
+	defer func() {
+		const (
+			getIntError = "GetInt error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(getIntError, e)
+		}
+
+		return
+	}()
+
+	word, bitField, e = v.bitField(name)
+	if e != nil {
+		return
+	}
+
+	number, ok = bitField.SignedValue(
+		bitField.Uint64(
+			word.Uint64(v.bytes),
+		),
+	)
+	if !ok {
+		e = validation.NewBitFieldValueOfIncompatibleTypeError(
+			bitField.Type().String(), "of type int64",
+		)
+
+		v.setBitFieldErrorNames(e, word, bitField)
+
+		return
+	}
+
+	return
+}
+
+func (v View[T]) SetInt(name string, number int64) (e error) {
+	// Write a bit field holding a signed integer,
+	// leaving every other bit as it was.
+
+	const (
+		functionName = "SetInt"
+	)
+
+	var (
+		bitField metadata.BitFieldMetadata
+		value    uint64
+		word     metadata.WordMetadata
+	)
+
+	defer func() {
+		const (
+			setIntError = "SetInt error: %w"
+		)
+
+		if e != nil {
+			e.(validation.FunctionError).SetFunctionName(functionName)
+
+			e = fmt.Errorf(setIntError, e)
+		}
+
+		return
+	}()
+
+	word, bitField, e = v.bitField(name)
+	if e != nil {
+		return
+	}
+
+	if bitField.Signed() == "" {
+		e = validation.NewBitFieldValueOfIncompatibleTypeError(
+			bitField.Type().String(), "of type int64",
+		)
+
+		v.setBitFieldErrorNames(e, word, bitField)
+
+		return
+	}
+
+	value, e = bitField.EncodeSigned(number)
+	if e != nil {
+		v.setBitFieldErrorNames(e, word, bitField)
+
+		return
+	}
+
+	word.PutUint64(v.bytes,
+		bitField.PutUint64(
+			word.Uint64(v.bytes), value,
+		),
+	)
+
+	return
+}
+
+func (v View[T]) bitField(name string) (
+	word metadata.WordMetadata, bitField metadata.BitFieldMetadata, e error,
+) {
+	var (
+		ok bool
+	)
+
+	word, bitField, ok = v.format.BitField(name)
+	if !ok {
+		e = validation.NewUnknownBitFieldError(name)
+
+		e.(validation.FormatError).SetFormatName(
+			v.format.Name(),
+		)
+
+		return
+	}
+
+	return
+}
+
+func (v View[T]) setBitFieldErrorNames(e error,
+	word metadata.WordMetadata, bitField metadata.BitFieldMetadata,
+) {
+	e.(validation.BitFieldError).SetFormatName(
+		v.format.Name(),
+	)
+
+	e.(validation.BitFieldError).SetWordName(
+		word.Name(),
+	)
+
+	e.(validation.BitFieldError).SetBitFieldName(
+		bitField.Name(),
+	)
+
+	return
+}
+
+func (v View[T]) Bytes() []byte {
+	return v.bytes
+}
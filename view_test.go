@@ -0,0 +1,534 @@
+package binary
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/encodingx/binary/pkg/rfc791"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewOfGet(t *testing.T) {
+	var (
+		e     error
+		value uint64
+		view  View[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+	)
+
+	view, e = ViewOf[rfc791.RFC791InternetHeaderFormatWithoutOptions](
+		internetHeaderBytes,
+	)
+
+	assert.Nil(t, e)
+
+	value, e = view.Get("TimeToLive")
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		uint64(timeToLive), value,
+	)
+
+	value, e = view.Get("RFC791InternetHeaderFormatWord3.SourceAddressOctet1")
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		uint64(sourceAddressOctet1), value,
+	)
+}
+
+func TestViewOfSet(t *testing.T) {
+	var (
+		bytes    = append([]byte(nil), internetHeaderBytes...)
+		e        error
+		expected = internetHeaderStruct
+		header   rfc791.RFC791InternetHeaderFormatWithoutOptions
+		view     View[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+	)
+
+	view, e = ViewOf[rfc791.RFC791InternetHeaderFormatWithoutOptions](bytes)
+
+	assert.Nil(t, e)
+
+	e = view.Set("TimeToLive", 64)
+
+	assert.Nil(t, e)
+
+	e = view.Set("HeaderChecksum", 0xbeef)
+
+	assert.Nil(t, e)
+
+	e = Unmarshal(view.Bytes(), &header)
+
+	assert.Nil(t, e)
+
+	expected.RFC791InternetHeaderFormatWord2.TimeToLive = 64
+	expected.RFC791InternetHeaderFormatWord2.HeaderChecksum = 0xbeef
+
+	assert.Equal(t,
+		expected, header,
+	)
+}
+
+func TestViewOfLittleEndianLSBFirstWord(t *testing.T) {
+	type (
+		Register struct {
+			Enable bool   `bitfield:"1"`
+			Mode   uint8  `bitfield:"3"`
+			Count  uint16 `bitfield:"12"`
+		}
+
+		Header struct {
+			Register `word:"16,littleendian,lsbfirst"`
+		}
+	)
+
+	var (
+		bytes  = []byte{0x3b, 0x12}
+		e      error
+		format Format[Header]
+		value  uint64
+		view   View[Header]
+	)
+
+	format, e = FormatOf[Header]()
+
+	assert.Nil(t, e)
+
+	view, e = format.View(bytes)
+
+	assert.Nil(t, e)
+
+	value, e = view.Get("Mode")
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		uint64(5), value,
+	)
+
+	e = view.Set("Count", 0x456)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0x6b, 0x45}, bytes,
+	)
+}
+
+func TestViewOfCodedBitFields(t *testing.T) {
+	// Fifty-nine is held as 0x59 in binary-coded decimal
+	// and three as 0b10 in Gray code.
+
+	type (
+		Clock struct {
+			Seconds uint8 `bitfield:"8,bcd"`
+			Phase   uint8 `bitfield:"8,gray"`
+		}
+
+		Format struct {
+			Clock `word:"16"`
+		}
+	)
+
+	var (
+		bytes  = []byte{0x59, 0x02}
+		e      error
+		format Format
+		value  uint64
+		view   View[Format]
+	)
+
+	view, e = ViewOf[Format](bytes)
+
+	assert.Nil(t, e)
+
+	value, e = view.Get("Seconds")
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		uint64(59), value,
+	)
+
+	value, e = view.Get("Phase")
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		uint64(3), value,
+	)
+
+	e = view.Set("Seconds", 42)
+
+	assert.Nil(t, e)
+
+	e = view.Set("Phase", 4)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		[]byte{0x42, 0x06}, bytes,
+	)
+
+	e = Unmarshal(bytes, &format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		Clock{Seconds: 42, Phase: 4}, format.Clock,
+	)
+
+	e = view.Set("Seconds", 100)
+
+	assert.Contains(t, e.Error(), "100 overflowing 2 decimal digit(s)")
+
+	bytes[0] = 0x5a
+
+	_, e = view.Get("Seconds")
+
+	assert.Contains(t, e.Error(),
+		"holding a value 0x5a with a digit greater than 9",
+	)
+}
+
+func TestViewOfSignedBitFields(t *testing.T) {
+	// Minus two is held as 0xfe in two's complement,
+	// minus three as 0x83 in sign-magnitude
+	// and as 0xfc in ones' complement.
+
+	type (
+		Offsets struct {
+			Twos      int8 `bitfield:"8"`
+			Magnitude int8 `bitfield:"8,signmagnitude"`
+			Ones      int8 `bitfield:"8,onescomplement"`
+		}
+
+		Format struct {
+			Offsets `word:"24"`
+		}
+	)
+
+	var (
+		bytes  = []byte{0xfe, 0x83, 0xfc}
+		e      error
+		format Format
+		number int64
+		view   View[Format]
+	)
+
+	view, e = ViewOf[Format](bytes)
+
+	assert.Nil(t, e)
+
+	number, e = view.GetInt("Twos")
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		int64(-2), number,
+	)
+
+	number, e = view.GetInt("Magnitude")
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		int64(-3), number,
+	)
+
+	number, e = view.GetInt("Ones")
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		int64(-3), number,
+	)
+
+	assert.Nil(t,
+		view.SetInt("Twos", -128),
+	)
+
+	assert.Nil(t,
+		view.SetInt("Magnitude", -127),
+	)
+
+	assert.Nil(t,
+		view.SetInt("Ones", -127),
+	)
+
+	assert.Equal(t,
+		[]byte{0x80, 0xff, 0x80}, bytes,
+	)
+
+	e = Unmarshal(bytes, &format)
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		Offsets{Twos: -128, Magnitude: -127, Ones: -127}, format.Offsets,
+	)
+
+	e = view.SetInt("Magnitude", -128)
+
+	assert.Contains(t, e.Error(),
+		"-128 overflowing 8 bit(s) in signmagnitude",
+	)
+
+	assert.Equal(t,
+		[]byte{0x80, 0xff, 0x80}, bytes,
+	)
+}
+
+func TestViewShouldReturnErrorGivenSignednessMismatch(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"Get error: " +
+			"A value given for a bit field " +
+			"should be convertible to the type of the bit field " +
+			"without loss. " +
+			"Argument to Get points to a format \"binary.Format\" " +
+			"nesting a word \"Word\" " +
+			"that has a bit field \"Signed\" " +
+			"of type \"int8\" given a value of type uint64."
+	)
+
+	type (
+		Word struct {
+			Signed   int8  `bitfield:"4"`
+			Unsigned uint8 `bitfield:"4"`
+		}
+
+		Format struct {
+			Word `word:"8"`
+		}
+	)
+
+	var (
+		e    error
+		view View[Format]
+	)
+
+	view, e = ViewOf[Format](
+		[]byte{0x00},
+	)
+
+	assert.Nil(t, e)
+
+	_, e = view.Get("Signed")
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.NotNil(t,
+		view.Set("Signed", 1),
+	)
+
+	_, e = view.GetInt("Unsigned")
+
+	assert.NotNil(t, e)
+
+	assert.NotNil(t,
+		view.SetInt("Unsigned", 1),
+	)
+}
+
+func TestViewShouldReturnErrorGivenBitFieldLongerThan64Bits(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"Get error: " +
+			"A value given for a bit field " +
+			"should be convertible to the type of the bit field " +
+			"without loss. " +
+			"Argument to Get points to a format \"binary.Format\" " +
+			"nesting a word \"Addr\" " +
+			"that has a bit field \"Addr\" " +
+			"of type \"netip.Addr\" given a value of type uint64."
+	)
+
+	type (
+		Word struct {
+			Port uint16 `bitfield:"16"`
+		}
+
+		Format struct {
+			Addr netip.Addr `word:"128"`
+			Word `word:"16"`
+		}
+	)
+
+	var (
+		e     error
+		value uint64
+		view  View[Format]
+	)
+
+	view, e = ViewOf[Format](
+		append(make([]byte, 16), 0x01, 0xbb),
+	)
+
+	assert.Nil(t, e)
+
+	_, e = view.Get("Addr")
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.NotNil(t,
+		view.Set("Addr", 1),
+	)
+
+	value, e = view.Get("Port")
+
+	assert.Nil(t, e)
+
+	assert.Equal(t,
+		uint64(443), value,
+	)
+}
+
+func TestViewOfShouldNotAllocate(t *testing.T) {
+	var (
+		bytes = append([]byte(nil), internetHeaderBytes...)
+		view  View[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+	)
+
+	view, _ = ViewOf[rfc791.RFC791InternetHeaderFormatWithoutOptions](bytes)
+
+	assert.Zero(t,
+		testing.AllocsPerRun(100,
+			func() {
+				var (
+					value uint64
+				)
+
+				value, _ = view.Get("TimeToLive")
+
+				_ = view.Set("TimeToLive", value)
+			},
+		),
+	)
+}
+
+func TestViewOfShouldReturnErrorGivenByteSliceOfWrongLength(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"ViewOf error: " +
+			"A byte slice into which a format-struct would be unmarshalled " +
+			"should be of length equal to the sum of lengths of words " +
+			"in the format represented by the struct. " +
+			"Argument to ViewOf points to a format-struct " +
+			"\"rfc791.RFC791InternetHeaderFormatWithoutOptions\" " +
+			"of length 20 byte(s) " +
+			"not equal to the length of the byte slice, 19 byte(s)."
+	)
+
+	var (
+		e error
+	)
+
+	_, e = ViewOf[rfc791.RFC791InternetHeaderFormatWithoutOptions](
+		internetHeaderBytes[1:],
+	)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestViewOfShouldReturnErrorGivenFormatOfVariableLength(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"ViewOf error: " +
+			"Bit fields can be read and written in place only in formats " +
+			"of fixed length. " +
+			"Argument to ViewOf points to a format-struct \"binary.Format\" " +
+			"that has a word holding a variable-length integer " +
+			"or a bit stream."
+	)
+
+	type (
+		Format struct {
+			Length uint64 `word:"64,uleb128"`
+		}
+	)
+
+	var (
+		e error
+	)
+
+	_, e = ViewOf[Format](
+		[]byte{0x00},
+	)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestViewShouldReturnErrorGivenUnknownBitField(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"Get error: " +
+			"Bit fields should be named as in their format, " +
+			"optionally qualified by the names of their words. " +
+			"Argument to Get names a bit field " +
+			"\"RFC791InternetHeaderFormatWord0.TimeToLive\" " +
+			"not in format \"rfc791.RFC791InternetHeaderFormatWithoutOptions\"."
+	)
+
+	var (
+		e    error
+		view View[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+	)
+
+	view, e = ViewOf[rfc791.RFC791InternetHeaderFormatWithoutOptions](
+		internetHeaderBytes,
+	)
+
+	assert.Nil(t, e)
+
+	_, e = view.Get("RFC791InternetHeaderFormatWord0.TimeToLive")
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+}
+
+func TestViewShouldReturnErrorGivenOverflowingValue(t *testing.T) {
+	const (
+		errorMessage = "" +
+			"Set error: " +
+			"A value given for a bit field " +
+			"should be convertible to the type of the bit field " +
+			"without loss. " +
+			"Argument to Set points to a format " +
+			"\"rfc791.RFC791InternetHeaderFormatWithoutOptions\" " +
+			"nesting a word \"RFC791InternetHeaderFormatWord2\" " +
+			"that has a bit field \"TimeToLive\" " +
+			"of type \"uint8\" given a value 256 overflowing 8 bit(s)."
+	)
+
+	var (
+		bytes = append([]byte(nil), internetHeaderBytes...)
+		e     error
+		view  View[rfc791.RFC791InternetHeaderFormatWithoutOptions]
+	)
+
+	view, e = ViewOf[rfc791.RFC791InternetHeaderFormatWithoutOptions](bytes)
+
+	assert.Nil(t, e)
+
+	e = view.Set("TimeToLive", 256)
+
+	assert.Equal(t,
+		errorMessage, e.Error(),
+	)
+
+	assert.Equal(t,
+		internetHeaderBytes, bytes,
+	)
+}